@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+type addManager interface {
+	ResolveProject(input string) (string, error)
+	InsertEntry(project, title string, from, to time.Time, tags ...string) (*models.TimeEntry, error)
+}
+
+var addCmd = &cobra.Command{
+	Use:   "add <project> <title> --from <time> --to <time>",
+	Short: "Add a finished entry in the past",
+	Long: `Add an entry you forgot to track, from --from to --to, into the timeline:
+
+  time-tracker add my-project "Planning" --from 2026-10-01T09:00 --to 10:30
+
+Entries and gaps that overlap the interval are trimmed or split around it, and whatever was
+tracked at the end of the interval continues from there, so the rest of the day keeps its times.
+
+The times accept the same forms as --at; a --to of just "HH:MM" is on the --from day. The
+project can be given by its code or an alias, like with "start".`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fromExpr, err := cmd.Flags().GetString("from")
+		if err != nil {
+			return fmt.Errorf("failed to parse from flag: %w", err)
+		}
+		toExpr, err := cmd.Flags().GetString("to")
+		if err != nil {
+			return fmt.Errorf("failed to parse to flag: %w", err)
+		}
+		if fromExpr == "" || toExpr == "" {
+			return fmt.Errorf("--from and --to are required")
+		}
+		tags, err := cmd.Flags().GetStringSlice("tag")
+		if err != nil {
+			return fmt.Errorf("failed to parse tag flag: %w", err)
+		}
+
+		now := time.Now()
+		from, err := utils.ParseTimeExpression(fromExpr, now)
+		if err != nil {
+			return err
+		}
+		to, err := utils.ParseEndTime(toExpr, from, now)
+		if err != nil {
+			return err
+		}
+
+		storage, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		return addPastEntry(newTaskManager(storage), args[0], args[1], from, to, tags, os.Stdout)
+	},
+}
+
+// addPastEntry inserts a finished entry from `from` to `to`, resolving the project by code or alias
+func addPastEntry(taskManager addManager, project, title string, from, to time.Time, tags []string, out io.Writer) error {
+	project, err := taskManager.ResolveProject(project)
+	if err != nil {
+		return fmt.Errorf("failed to resolve project: %w", err)
+	}
+
+	var entry *models.TimeEntry
+	err = retryOnConflict(func() error {
+		var err error
+		entry, err = taskManager.InsertEntry(project, title, from, to, tags...)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add entry: %w", err)
+	}
+
+	fmt.Fprintf(out, "Added \"%s\" in project \"%s\" from %s to %s (duration: %s)\n", entry.Title, entry.Project, utils.FormatDateTime(from), utils.FormatDateTime(to), utils.FormatDuration(entry.Duration()))
+	return nil
+}
+
+func init() {
+	addCmd.Flags().String("from", "", `when the entry started, e.g. "09:00" or "2026-10-01T09:00"`)
+	addCmd.Flags().String("to", "", `when the entry ended, e.g. "10:30" (on the --from day) or "2026-10-01 10:30"`)
+	addCmd.Flags().StringSliceP("tag", "t", nil, "tag to attach to the entry (repeatable or comma-separated)")
+	rootCmd.AddCommand(addCmd)
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+func TestAddPastEntry_InsertsIntoRunningEntry(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	start := time.Now().Add(-3 * time.Hour).Truncate(time.Minute)
+	if err := storage.Save([]models.TimeEntry{{Start: start, Project: "Acme", Title: "Build"}}); err != nil {
+		t.Fatalf("failed to seed entries: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := addPastEntry(tm, "Beta", "Call", start.Add(time.Hour), start.Add(90*time.Minute), []string{"meeting"}, &out); err != nil {
+		t.Fatalf("addPastEntry returned error: %v", err)
+	}
+
+	entries, _ := storage.Load()
+	if len(entries) != 3 || entries[1].Project != "Beta" || entries[2].Project != "Acme" || !entries[2].Start.Equal(start.Add(90*time.Minute)) {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+	if !strings.Contains(out.String(), `Added "Call" in project "Beta"`) || !strings.Contains(out.String(), "duration: 30m") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestAddPastEntry_RejectsEndBeforeStart(t *testing.T) {
+	tm := utils.NewTaskManager(utils.NewMemoryStorage())
+	from := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)
+
+	err := addPastEntry(tm, "Beta", "Call", from, from.Add(-time.Hour), nil, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "must be after") {
+		t.Fatalf("expected an interval error, got %v", err)
+	}
+}
+
+func TestAddPastEntry_ResolvesProjectCodeAndAlias(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	if err := storage.SaveProjects([]models.Project{
+		{Name: "Acme Website", Code: "ABC-12572"},
+		{Name: "Internal", Aliases: []string{"ops"}},
+		{Name: "Support", Aliases: []string{"ops"}},
+	}); err != nil {
+		t.Fatalf("failed to seed projects: %v", err)
+	}
+	from := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)
+
+	var out bytes.Buffer
+	if err := addPastEntry(tm, "abc-12572", "Call", from, from.Add(time.Hour), nil, &out); err != nil {
+		t.Fatalf("addPastEntry returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), `in project "Acme Website"`) {
+		t.Fatalf("expected the code to resolve to the project, got %q", out.String())
+	}
+
+	err := addPastEntry(tm, "ops", "Call", from.Add(2*time.Hour), from.Add(3*time.Hour), nil, &out)
+	if err == nil || !strings.Contains(err.Error(), "Internal (alias), Support (alias)") {
+		t.Fatalf("expected an ambiguity error listing the candidates, got %v", err)
+	}
+}
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"time-tracker/config"
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+type backupStorage interface {
+	Load() ([]models.TimeEntry, error)
+	LoadProjects() ([]models.Project, error)
+	ListBackups() ([]utils.Backup, error)
+	FindBackup(id string) (utils.Backup, error)
+	RestoreBackup(backup utils.Backup) error
+}
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "List, inspect, and restore backups of the data file",
+	Long: fmt.Sprintf(`List, inspect, and restore backups of data.json.
+
+A backup of data.json is kept every time it is replaced, in the backups directory next to it.
+Only the most recent backups are kept: 10 by default, configurable with %s (0 disables backups).`, config.BackupsEnvVar),
+}
+
+var backupListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List backups, newest first",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		storage, err := openBackupStorage()
+		if err != nil {
+			return err
+		}
+
+		return listBackups(storage, os.Stdout)
+	},
+}
+
+var backupShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show how a backup differs from the current data",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		storage, err := openBackupStorage()
+		if err != nil {
+			return err
+		}
+
+		_, err = showBackup(storage, args[0], os.Stdout)
+		return err
+	},
+}
+
+var backupRestoreCmd = &cobra.Command{
+	Use:   "restore <id>",
+	Short: "Replace the current data with a backup",
+	Long: `Show how a backup differs from the current data and, after confirmation, replace the
+current data with it. The replaced data is backed up first, so a restore can be undone.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		yes, err := cmd.Flags().GetBool("yes")
+		if err != nil {
+			return fmt.Errorf("failed to parse yes flag: %w", err)
+		}
+
+		storage, err := openBackupStorage()
+		if err != nil {
+			return err
+		}
+
+		return restoreBackup(storage, args[0], yes, os.Stdin, os.Stdout)
+	},
+}
+
+// openBackupStorage opens data.json; backups are only kept by the json storage backend
+func openBackupStorage() (*utils.FileStorage, error) {
+	if backend := config.StorageBackend(); backend != utils.StorageBackendJSON {
+		return nil, fmt.Errorf("backups are only kept by the %q storage backend (current: %q)", utils.StorageBackendJSON, backend)
+	}
+
+	maxBackups, err := config.MaxBackups()
+	if err != nil {
+		return nil, err
+	}
+
+	storage, err := utils.NewFileStorage(config.DataFilePath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	storage.MaxBackups = maxBackups
+	return storage, nil
+}
+
+func listBackups(storage backupStorage, out io.Writer) error {
+	backups, err := storage.ListBackups()
+	if err != nil {
+		return fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	if len(backups) == 0 {
+		fmt.Fprintln(out, "No backups found")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(out)
+	table.SetHeader([]string{"ID", "Created", "Entries", "Projects"})
+	table.SetAutoFormatHeaders(false)
+	table.SetBorder(true)
+	table.SetRowLine(true)
+	table.SetAutoWrapText(false)
+
+	for _, backup := range backups {
+		entries, projects, err := utils.LoadBackup(backup)
+		entryCount, projectCount := "invalid", "invalid"
+		if err == nil {
+			entryCount = fmt.Sprintf("%d", len(entries))
+			projectCount = fmt.Sprintf("%d", len(projects))
+		}
+		table.Append([]string{
+			backup.ID,
+			backup.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+			entryCount,
+			projectCount,
+		})
+	}
+
+	table.Render()
+	return nil
+}
+
+// showBackup prints the difference between a backup and the current data
+func showBackup(storage backupStorage, id string, out io.Writer) (utils.BackupDiff, error) {
+	backup, err := storage.FindBackup(id)
+	if err != nil {
+		return utils.BackupDiff{}, err
+	}
+
+	backupEntries, backupProjects, err := utils.LoadBackup(backup)
+	if err != nil {
+		return utils.BackupDiff{}, err
+	}
+
+	currentEntries, err := storage.Load()
+	if err != nil {
+		return utils.BackupDiff{}, fmt.Errorf("failed to load entries: %w", err)
+	}
+	currentProjects, err := storage.LoadProjects()
+	if err != nil {
+		return utils.BackupDiff{}, fmt.Errorf("failed to load projects: %w", err)
+	}
+
+	diff := utils.DiffBackup(currentEntries, currentProjects, backupEntries, backupProjects)
+
+	fmt.Fprintf(out, "Backup %s (%s)\n", backup.ID, backup.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(out, "Entries:  current %d, backup %d\n", diff.CurrentEntries, diff.BackupEntries)
+	fmt.Fprintf(out, "Projects: current %d, backup %d\n", diff.CurrentProjects, diff.BackupProjects)
+
+	if !diff.HasChanges() {
+		fmt.Fprintln(out, "The backup matches the current data.")
+		return diff, nil
+	}
+
+	fmt.Fprintf(out, "Restoring would bring back %d entries, drop %d entries, and change %d entries.\n", diff.Added, diff.Removed, diff.Changed)
+	if len(diff.Ranges) > 0 {
+		fmt.Fprintln(out, "Changed ranges:")
+		for _, changed := range diff.Ranges {
+			fmt.Fprintf(out, "  %s - %s\n", changed.Start.Local().Format("2006-01-02 15:04"), changed.End.Local().Format("2006-01-02 15:04"))
+		}
+	}
+
+	return diff, nil
+}
+
+func restoreBackup(storage backupStorage, id string, yes bool, in io.Reader, out io.Writer) error {
+	diff, err := showBackup(storage, id, out)
+	if err != nil {
+		return err
+	}
+	if !diff.HasChanges() {
+		return nil
+	}
+
+	if !yes {
+		fmt.Fprint(out, "Restore this backup? [y/N] ")
+		answer, err := bufio.NewReader(in).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("failed to read confirmation: %w", err)
+		}
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Fprintln(out, "Restore cancelled")
+			return nil
+		}
+	}
+
+	backup, err := storage.FindBackup(id)
+	if err != nil {
+		return err
+	}
+	if err := storage.RestoreBackup(backup); err != nil {
+		return fmt.Errorf("failed to restore backup: %w", err)
+	}
+
+	fmt.Fprintf(out, "Restored backup %s (the replaced data was backed up first)\n", backup.ID)
+	return nil
+}
+
+func init() {
+	backupRestoreCmd.Flags().BoolP("yes", "y", false, "restore without asking for confirmation")
+
+	backupCmd.AddCommand(backupListCmd)
+	backupCmd.AddCommand(backupShowCmd)
+	backupCmd.AddCommand(backupRestoreCmd)
+	rootCmd.AddCommand(backupCmd)
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"time-tracker/utils"
+)
+
+func newBackupTestStorage(t *testing.T) *utils.FileStorage {
+	t.Helper()
+
+	storage, err := utils.NewFileStorage(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("failed to create file storage: %v", err)
+	}
+
+	tm := utils.NewTaskManager(storage)
+	if _, err := tm.StartEntry("Keep", "Task"); err != nil {
+		t.Fatalf("StartEntry returned error: %v", err)
+	}
+	if err := tm.DeleteEntry(0); err != nil {
+		t.Fatalf("DeleteEntry returned error: %v", err)
+	}
+	return storage
+}
+
+func TestListBackups_ShowsBackups(t *testing.T) {
+	storage := newBackupTestStorage(t)
+
+	var out bytes.Buffer
+	if err := listBackups(storage, &out); err != nil {
+		t.Fatalf("listBackups returned error: %v", err)
+	}
+
+	backups, _ := storage.ListBackups()
+	if !strings.Contains(out.String(), backups[0].ID) {
+		t.Fatalf("expected backup ID in output, got %q", out.String())
+	}
+}
+
+func TestListBackups_NoBackups(t *testing.T) {
+	storage, err := utils.NewFileStorage(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("failed to create file storage: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := listBackups(storage, &out); err != nil {
+		t.Fatalf("listBackups returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), "No backups found") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestRestoreBackup_CancelledWithoutConfirmation(t *testing.T) {
+	storage := newBackupTestStorage(t)
+	backups, _ := storage.ListBackups()
+
+	var out bytes.Buffer
+	if err := restoreBackup(storage, backups[0].ID, false, strings.NewReader("n\n"), &out); err != nil {
+		t.Fatalf("restoreBackup returned error: %v", err)
+	}
+
+	if !strings.Contains(out.String(), "change 1 entries") || !strings.Contains(out.String(), "Restore cancelled") {
+		t.Fatalf("expected diff and cancellation, got %q", out.String())
+	}
+
+	entries, _ := storage.Load()
+	if !entries[0].IsBlank() {
+		t.Fatalf("expected data to be unchanged, got %+v", entries)
+	}
+}
+
+func TestRestoreBackup_RestoresAfterConfirmation(t *testing.T) {
+	storage := newBackupTestStorage(t)
+	backups, _ := storage.ListBackups()
+
+	var out bytes.Buffer
+	if err := restoreBackup(storage, backups[0].ID, false, strings.NewReader("y\n"), &out); err != nil {
+		t.Fatalf("restoreBackup returned error: %v", err)
+	}
+
+	entries, _ := storage.Load()
+	if entries[0].Project != "Keep" {
+		t.Fatalf("expected entry to be restored, got %+v", entries)
+	}
+	if !strings.Contains(out.String(), "Restored backup "+backups[0].ID) {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"time-tracker/config"
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+var balanceCmd = &cobra.Command{
+	Use:   "balance",
+	Short: "Show the overtime or undertime against the expected hours",
+	Long: `Compare the tracked time with the hours expected by the target-hours setting, e.g.
+
+  time-tracker config set target-hours mon-thu=8,fri=6
+  time-tracker config set holidays 2026-12-25,2026-12-26
+  time-tracker config set vacation 2026-08-03..2026-08-14
+
+No hours are expected on holidays and vacation days. The balance counts every day from
+--since (default: the balance-start setting, or the first tracked day) through yesterday;
+today and the current week are shown as progress. Use --weeks to list recent weeks.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		schedule := configuredSchedule()
+		if schedule == nil {
+			return fmt.Errorf(`no target hours configured: set them with "time-tracker config set target-hours mon-fri=8"`)
+		}
+
+		sinceExpr, err := cmd.Flags().GetString("since")
+		if err != nil {
+			return fmt.Errorf("failed to parse since flag: %w", err)
+		}
+		since := config.BalanceStart()
+		if sinceExpr != "" {
+			since, err = time.ParseInLocation("2006-01-02", sinceExpr, time.Local)
+			if err != nil {
+				return fmt.Errorf("invalid --since date %q: expected YYYY-MM-DD", sinceExpr)
+			}
+		}
+		weeks, err := cmd.Flags().GetInt("weeks")
+		if err != nil {
+			return fmt.Errorf("failed to parse weeks flag: %w", err)
+		}
+		if weeks < 0 {
+			return fmt.Errorf("--weeks cannot be negative")
+		}
+
+		storage, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+		entries, err := storage.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load entries: %w", err)
+		}
+
+		printBalance(entries, schedule, since, weeks, time.Now(), os.Stdout)
+		return nil
+	},
+}
+
+// configuredSchedule returns the configured working schedule, or nil without target hours
+func configuredSchedule() *utils.Schedule {
+	schedule := utils.NewSchedule(config.TargetHours(), config.DaysOff())
+	if schedule.IsEmpty() {
+		return nil
+	}
+	return schedule
+}
+
+// printBalance reports the balance through yesterday, today, this week, and the last weeks
+func printBalance(entries []models.TimeEntry, schedule *utils.Schedule, since time.Time, weeks int, now time.Time, out io.Writer) {
+	if since.IsZero() {
+		first, ok := utils.FirstTrackedDay(entries)
+		if !ok {
+			first = now
+		}
+		since = first
+	}
+
+	yesterday := now.AddDate(0, 0, -1)
+	balance := utils.CalculateBalance(entries, schedule, since, yesterday)
+	if balance.Last.Before(balance.First) {
+		fmt.Fprintf(out, "Balance: no full days since %s yet\n", balance.First.Format("2006-01-02"))
+	} else {
+		fmt.Fprintf(out, "Balance since %s: %s (%s tracked, %s expected through %s)\n",
+			balance.First.Format("2006-01-02"), utils.FormatDelta(balance.Delta()),
+			utils.FormatDuration(balance.Tracked), utils.FormatDuration(balance.Expected), balance.Last.Format("2006-01-02"))
+	}
+
+	weekStart := utils.WeekStartOf(now)
+	week := utils.CalculateBalance(entries, schedule, weekStart, weekStart.AddDate(0, 0, 6))
+	fmt.Fprintf(out, "This week: %s of %s (%s)\n", utils.FormatDuration(week.Tracked), utils.FormatDuration(week.Expected), utils.FormatDelta(week.Delta()))
+	today := utils.CalculateBalance(entries, schedule, now, now)
+	fmt.Fprintf(out, "Today: %s of %s (%s)\n", utils.FormatDuration(today.Tracked), utils.FormatDuration(today.Expected), utils.FormatDelta(today.Delta()))
+
+	if weeks == 0 {
+		return
+	}
+
+	fmt.Fprintln(out)
+	table := tablewriter.NewWriter(out)
+	table.SetHeader([]string{"Week Starting", "Tracked", "Target", "Delta"})
+	table.SetBorder(true)
+	table.SetAutoWrapText(false)
+	for i := weeks - 1; i >= 0; i-- {
+		first := weekStart.AddDate(0, 0, -7*i)
+		week := utils.CalculateBalance(entries, schedule, first, first.AddDate(0, 0, 6))
+		table.Append([]string{first.Format("2006-01-02"), formatTimeHHMM(week.Tracked), formatTimeHHMM(week.Expected), formatDeltaHHMM(week.Delta())})
+	}
+	table.Render()
+}
+
+func init() {
+	balanceCmd.Flags().String("since", "", "first day counted, as YYYY-MM-DD (default from the balance-start setting, or the first tracked day)")
+	balanceCmd.Flags().IntP("weeks", "w", 0, "also list this many recent weeks with their target and delta")
+	rootCmd.AddCommand(balanceCmd)
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+func TestPrintBalance_ReportsBalanceAndProgress(t *testing.T) {
+	hours := map[time.Weekday]time.Duration{}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		hours[day] = 8 * time.Hour
+	}
+	now := time.Date(2026, 3, 18, 12, 0, 0, 0, time.Local)
+	yesterday := now.AddDate(0, 0, -1)
+	// The day before yesterday is a holiday
+	schedule := utils.NewSchedule(hours, []time.Time{now.AddDate(0, 0, -2)})
+
+	at := func(day time.Time, hour int) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day(), hour, 0, 0, 0, time.Local)
+	}
+	yesterdayEnd := at(yesterday, 18)
+	todayEnd := at(now, 11)
+	entries := []models.TimeEntry{
+		{Start: at(yesterday, 9), End: &yesterdayEnd, Project: "Acme", Title: "Build"},
+		{Start: yesterdayEnd, End: ptrTime(at(now, 8))},
+		{Start: at(now, 8), End: &todayEnd, Project: "Acme", Title: "Build"},
+	}
+
+	var out bytes.Buffer
+	printBalance(entries, schedule, now.AddDate(0, 0, -2), 2, now, &out)
+
+	for _, want := range []string{"+1h (9h tracked, 8h expected through", "Today: 3h of 8h (-5h)", "WEEK STARTING", "| 2026-03-16    | 12:00   | 48:00  | -36:00 |"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
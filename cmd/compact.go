@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"time-tracker/config"
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+type compactManager interface {
+	ListEntries() ([]models.TimeEntry, error)
+	Compact(threshold time.Duration) (*utils.CompactResult, error)
+}
+
+var compactCmd = &cobra.Command{
+	Use:   "compact",
+	Short: "Merge repeated entries and drop very short ones",
+	Long: `Clean up the fragments left by starting and stopping in quick succession:
+
+- entries and gaps shorter than the threshold are dropped, so the previous entry absorbs their time
+- adjacent entries with the same project and title are merged, combining their tags and notes
+
+The running entry is never dropped. The threshold defaults to the compact-threshold setting
+(60 seconds). Use --dry-run to see the changes without saving them; compact can be undone.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return fmt.Errorf("failed to parse dry-run flag: %w", err)
+		}
+
+		threshold := config.CompactThreshold()
+		if cmd.Flags().Changed("threshold") {
+			threshold, err = cmd.Flags().GetDuration("threshold")
+			if err != nil {
+				return fmt.Errorf("failed to parse threshold flag: %w", err)
+			}
+			if threshold < 0 {
+				return fmt.Errorf("--threshold cannot be negative")
+			}
+		}
+
+		storage, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		return compactEntries(newTaskManager(storage), threshold, dryRun, os.Stdout)
+	},
+}
+
+// compactEntries compacts the stored entries, or with dryRun only reports what would change
+func compactEntries(taskManager compactManager, threshold time.Duration, dryRun bool, out io.Writer) error {
+	var result *utils.CompactResult
+	if dryRun {
+		entries, err := taskManager.ListEntries()
+		if err != nil {
+			return fmt.Errorf("failed to load entries: %w", err)
+		}
+		_, result = utils.CompactEntries(entries, threshold)
+	} else {
+		err := retryOnConflict(func() error {
+			var err error
+			result, err = taskManager.Compact(threshold)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to compact entries: %w", err)
+		}
+	}
+
+	if len(result.Changes) == 0 {
+		fmt.Fprintf(out, "Nothing to compact in %d entries\n", result.Before)
+		return nil
+	}
+
+	for _, change := range result.Changes {
+		fmt.Fprintf(out, "  %s\n", change)
+	}
+
+	summary := fmt.Sprintf("%d entries into %d (%d merged, %d dropped)", result.Before, result.After, result.Merged(), result.Dropped())
+	if dryRun {
+		fmt.Fprintf(out, "Would compact %s; run without --dry-run to apply\n", summary)
+		return nil
+	}
+	fmt.Fprintf(out, "Compacted %s\n", summary)
+	return nil
+}
+
+func init() {
+	compactCmd.Flags().Bool("dry-run", false, "show the changes without saving them")
+	compactCmd.Flags().Duration("threshold", 0, `drop entries and gaps shorter than this, e.g. "30s" or "2m" (default from the compact-threshold setting)`)
+	rootCmd.AddCommand(compactCmd)
+}
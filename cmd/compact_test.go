@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+func seedChurnEntries(t *testing.T, storage *utils.MemoryStorage) {
+	t.Helper()
+
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	err := storage.Save([]models.TimeEntry{
+		{Start: start, Project: "Acme", Title: "Build"},
+		{Start: start.Add(30 * time.Minute), Project: "Acme", Title: "Build"},
+		{Start: start.Add(time.Hour)},
+		{Start: start.Add(time.Hour + 20*time.Second), Project: "Beta", Title: "Mail"},
+	})
+	if err != nil {
+		t.Fatalf("failed to seed entries: %v", err)
+	}
+}
+
+func TestCompactEntries_DryRunDoesNotSave(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	seedChurnEntries(t, storage)
+
+	var out bytes.Buffer
+	if err := compactEntries(tm, time.Minute, true, &out); err != nil {
+		t.Fatalf("compactEntries returned error: %v", err)
+	}
+
+	entries, _ := storage.Load()
+	if len(entries) != 4 {
+		t.Fatalf("expected a dry run to keep all entries, got %d", len(entries))
+	}
+	if !strings.Contains(out.String(), "Would compact 4 entries into 2 (1 merged, 1 dropped)") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestCompactEntries_SavesChanges(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	seedChurnEntries(t, storage)
+
+	var out bytes.Buffer
+	if err := compactEntries(tm, time.Minute, false, &out); err != nil {
+		t.Fatalf("compactEntries returned error: %v", err)
+	}
+
+	entries, _ := storage.Load()
+	if len(entries) != 2 || entries[0].Project != "Acme" || entries[1].Project != "Beta" {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+	if !strings.Contains(out.String(), "drop 2026-03-16") || !strings.Contains(out.String(), "Compacted 4 entries into 2") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+
+	out.Reset()
+	if err := compactEntries(tm, time.Minute, false, &out); err != nil {
+		t.Fatalf("compactEntries returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Nothing to compact in 2 entries") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
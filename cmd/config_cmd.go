@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"time-tracker/config"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show and change configuration settings",
+	Long: `Show and change configuration settings.
+
+Settings are stored in config.json next to the data file. Each setting can be
+overridden with a TIME_TRACKER_* environment variable, and the data file also with
+the --data-file flag. config list shows every setting, its value, and its source.`,
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all settings with their values and sources",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listConfig(os.Stdout)
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the effective value of a setting",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return getConfig(args[0], os.Stdout)
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Store a setting in the configuration file",
+	Long:  `Store a setting in the configuration file. An empty value ("") removes it, restoring the default.`,
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setConfig(args[0], args[1], os.Stdout)
+	},
+}
+
+func listConfig(out io.Writer) error {
+	settings, err := config.List()
+	if err != nil {
+		return err
+	}
+
+	table := tablewriter.NewWriter(out)
+	table.SetHeader([]string{"Key", "Value", "Source", "Environment Variable"})
+	table.SetAutoFormatHeaders(false)
+	table.SetBorder(true)
+	table.SetRowLine(true)
+	table.SetAutoWrapText(false)
+
+	for _, setting := range settings {
+		value := setting.Value
+		if value == "" {
+			value = "(system)"
+		}
+		table.Append([]string{setting.Key, value, setting.Source, setting.EnvVar})
+	}
+
+	table.Render()
+	fmt.Fprintf(out, "Config file: %s\n", config.FilePath())
+	return nil
+}
+
+func getConfig(key string, out io.Writer) error {
+	setting, err := config.Resolve(key)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, setting.Value)
+	return nil
+}
+
+func setConfig(key, value string, out io.Writer) error {
+	stored, err := config.Set(key, value)
+	if err != nil {
+		return err
+	}
+
+	if stored == "" {
+		fmt.Fprintf(out, "Removed %s from %s\n", key, config.FilePath())
+	} else {
+		fmt.Fprintf(out, "Set %s to %q in %s\n", key, stored, config.FilePath())
+	}
+
+	// The stored value has no effect while a flag or environment variable overrides it
+	resolved, err := config.Resolve(key)
+	if err != nil {
+		return err
+	}
+	switch resolved.Source {
+	case config.SourceFlag:
+		fmt.Fprintf(out, "Note: %s is currently overridden by the --%s flag\n", key, key)
+	case config.SourceEnv:
+		fmt.Fprintf(out, "Note: %s is currently overridden by %s\n", key, resolved.EnvVar)
+	}
+	return nil
+}
+
+func init() {
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	rootCmd.AddCommand(configCmd)
+}
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"time-tracker/config"
@@ -9,22 +11,35 @@ import (
 )
 
 var currentCmd = &cobra.Command{
-	Use:     "current",
-	Short:   "Show the currently running task",
-	Long:    `Display the currently running task without entering the TUI. Can be called as 'current', 'curr', or 'c'.`,
+	Use:   "current",
+	Short: "Show the currently running task",
+	Long: `Display the currently running task without entering the TUI. Can be called as 'current', 'curr', or 'c'.
+
+When the task has been running for longer than the forgotten-after setting, a warning is
+printed and, in a terminal, you are asked when it really ended. A timeboxed task or pomodoro
+shows how much of it is left.`,
 	Aliases: []string{"curr", "c"},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		storage, err := utils.NewFileStorage(config.DataFilePath())
+		storage, err := openStorage()
 		if err != nil {
 			return fmt.Errorf("failed to initialize storage: %w", err)
 		}
-		taskManager := utils.NewTaskManager(storage)
+		taskManager := newTaskManager(storage)
 
 		entries, err := taskManager.ListEntries()
 		if err != nil {
 			return fmt.Errorf("failed to load entries: %w", err)
 		}
 
+		ended, err := checkForgottenEntry(taskManager, newActivityStore(), entries, config.ForgottenAfter(), time.Now(), interactiveInput(), os.Stderr)
+		if err != nil {
+			return err
+		}
+		if ended {
+			fmt.Println("No active task")
+			return nil
+		}
+
 		// Find the last entry
 		if len(entries) == 0 {
 			fmt.Println("No active task")
@@ -41,6 +56,10 @@ var currentCmd = &cobra.Command{
 
 		// Format and print duration
 		durationStr := utils.FormatDuration(lastEntry.Duration())
+		if box, err := utils.ActiveTimebox(newTimeboxStore(), entries); err == nil && box != nil {
+			fmt.Printf("%s %s, duration %s, %s left in %s\n", lastEntry.Project, lastEntry.Title, durationStr, utils.FormatDuration(box.Remaining(time.Now())), box.Label())
+			return nil
+		}
 		fmt.Printf("%s %s, duration %s\n", lastEntry.Project, lastEntry.Title, durationStr)
 		return nil
 	},
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+type deleteManager interface {
+	FindEntry(id string) (int, *models.TimeEntry, error)
+	DeleteEntry(idx int) error
+}
+
+var deleteCmd = &cobra.Command{
+	Use:   "delete <id>",
+	Short: "Delete an entry by its ID",
+	Long: `Delete the entry with the given ID, as shown by "time-tracker list". The time it covered
+becomes a gap; deleting a gap removes it, so the previous entry absorbs its time.
+
+Deleting can be undone with "time-tracker undo".`,
+	Aliases: []string{"rm"},
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		storage, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		return deleteEntryByID(newTaskManager(storage), args[0], os.Stdout)
+	},
+}
+
+// deleteEntryByID deletes the entry with the given ID
+func deleteEntryByID(taskManager deleteManager, id string, out io.Writer) error {
+	var entry *models.TimeEntry
+	err := retryOnConflict(func() error {
+		var idx int
+		var err error
+		idx, entry, err = taskManager.FindEntry(id)
+		if err != nil {
+			return err
+		}
+		return taskManager.DeleteEntry(idx)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete entry: %w", err)
+	}
+
+	if entry.IsBlank() {
+		fmt.Fprintf(out, "Removed the gap starting at %s\n", utils.FormatDateTime(entry.Start))
+		return nil
+	}
+	fmt.Fprintf(out, "Deleted \"%s\" in project \"%s\" from %s\n", entry.Title, entry.Project, utils.FormatDateTime(entry.Start))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(deleteCmd)
+}
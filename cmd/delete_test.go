@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+func seedIDEntries(t *testing.T, storage *utils.MemoryStorage) time.Time {
+	t.Helper()
+
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	err := storage.Save([]models.TimeEntry{
+		{Start: start, Project: "Acme", Title: "Build", Tags: []string{"dev"}},
+		{Start: start.Add(time.Hour)},
+		{Start: start.Add(2 * time.Hour), Project: "Beta", Title: "Review"},
+	})
+	if err != nil {
+		t.Fatalf("failed to seed entries: %v", err)
+	}
+	return start
+}
+
+// storedIDs returns the IDs of the stored entries in order
+func storedIDs(t *testing.T, storage *utils.MemoryStorage) []string {
+	t.Helper()
+
+	entries, err := storage.Load()
+	if err != nil {
+		t.Fatalf("failed to load entries: %v", err)
+	}
+	ids := make([]string, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.ID
+	}
+	return ids
+}
+
+func TestDeleteEntryByID_TurnsEntryIntoGap(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	seedIDEntries(t, storage)
+	ids := storedIDs(t, storage)
+
+	var out bytes.Buffer
+	if err := deleteEntryByID(tm, ids[2], &out); err != nil {
+		t.Fatalf("deleteEntryByID returned error: %v", err)
+	}
+
+	entries, _ := storage.Load()
+	if !entries[2].IsBlank() || entries[0].Project != "Acme" {
+		t.Fatalf("expected only entry 3 to be deleted, got %+v", entries)
+	}
+	if !strings.Contains(out.String(), `Deleted "Review" in project "Beta"`) {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+
+	if err := deleteEntryByID(tm, ids[2], &out); err == nil || !strings.Contains(err.Error(), "no entry with ID "+ids[2]) {
+		t.Fatalf("expected the deleted ID to be gone, got %v", err)
+	}
+}
+
+func TestDeleteEntryByID_RemovesGap(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	seedIDEntries(t, storage)
+	ids := storedIDs(t, storage)
+
+	var out bytes.Buffer
+	if err := deleteEntryByID(tm, "#"+ids[1], &out); err != nil {
+		t.Fatalf("deleteEntryByID returned error: %v", err)
+	}
+
+	entries, _ := storage.Load()
+	if len(entries) != 2 || entries[1].ID != ids[2] {
+		t.Fatalf("expected the gap to be removed and IDs kept, got %+v", entries)
+	}
+	if !strings.Contains(out.String(), "Removed the gap") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
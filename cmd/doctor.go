@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"time-tracker/config"
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+type doctorStorage interface {
+	CreateBackup() error
+	BackupsEnabled() bool
+	LoadTasks() ([]models.Task, error)
+	SaveAll([]models.TimeEntry, []models.Project, []models.Task) error
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the data file for problems and optionally fix them",
+	Long: fmt.Sprintf(`Check data.json for problems that are easy to introduce with time-tracker edit:
+entries starting in the future, entries stored out of order or sharing a start time,
+entries running longer than %s, project names that differ only by case or whitespace,
+repeated consecutive entries, and unknown schema versions.
+
+With --fix, the fixable problems are repaired after showing the changes as a diff.
+Add --dry-run to only show the diff. Unless backups are disabled, the data file is
+backed up before it is changed.`, utils.FormatDuration(utils.LongEntryThreshold)),
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fix, err := cmd.Flags().GetBool("fix")
+		if err != nil {
+			return fmt.Errorf("failed to parse fix flag: %w", err)
+		}
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return fmt.Errorf("failed to parse dry-run flag: %w", err)
+		}
+		if dryRun && !fix {
+			return fmt.Errorf("--dry-run can only be used with --fix")
+		}
+
+		if backend := config.StorageBackend(); backend != utils.StorageBackendJSON {
+			return fmt.Errorf("doctor only checks the %q storage backend (current: %q)", utils.StorageBackendJSON, backend)
+		}
+
+		storage, err := openBackupStorage()
+		if err != nil {
+			return err
+		}
+
+		jsonData, err := os.ReadFile(storage.FilePath)
+		if err != nil {
+			return fmt.Errorf("failed to read data file: %w", err)
+		}
+
+		return runDoctor(storage, jsonData, time.Now(), fix, dryRun, os.Stdout)
+	},
+}
+
+// runDoctor reports the problems in jsonData and, with fix, saves the repaired data to storage
+func runDoctor(storage doctorStorage, jsonData []byte, now time.Time, fix, dryRun bool, out io.Writer) error {
+	report, err := utils.Diagnose(jsonData, now)
+	if err != nil {
+		return err
+	}
+
+	if len(report.Issues) == 0 {
+		fmt.Fprintf(out, "No problems found in %d entries and %d projects\n", len(report.Entries), len(report.Projects))
+		return nil
+	}
+
+	fmt.Fprintf(out, "Found %d problems (%d fixable):\n", len(report.Issues), report.Fixable())
+	for _, issue := range report.Issues {
+		suffix := ""
+		if issue.Fixable {
+			suffix = " (fixable)"
+		}
+		fmt.Fprintf(out, "  [%s] %s%s\n", issue.Check, issue.Message, suffix)
+	}
+
+	if !fix || report.Fixable() == 0 {
+		if report.Fixable() > 0 {
+			fmt.Fprintln(out, "Run with --fix --dry-run to preview the fixes, or --fix to apply them.")
+		}
+		return nil
+	}
+
+	entries, projects := report.Fix()
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Changes:")
+	for _, line := range utils.DiffLines(utils.DoctorEntryLines(report.Entries, report.Projects), utils.DoctorEntryLines(entries, projects)) {
+		fmt.Fprintf(out, "  %s\n", line)
+	}
+	fmt.Fprintf(out, "Entries: %d -> %d, projects: %d -> %d\n", len(report.Entries), len(entries), len(report.Projects), len(projects))
+
+	if dryRun {
+		fmt.Fprintln(out, "Dry run: no changes were saved")
+		return nil
+	}
+
+	tasks, err := storage.LoadTasks()
+	if err != nil {
+		return fmt.Errorf("failed to load tasks: %w", err)
+	}
+	if err := storage.CreateBackup(); err != nil {
+		return fmt.Errorf("failed to back up data file: %w", err)
+	}
+	if err := storage.SaveAll(entries, projects, tasks); err != nil {
+		return fmt.Errorf("failed to save the fixed data: %w", err)
+	}
+
+	if storage.BackupsEnabled() {
+		fmt.Fprintln(out, "Fixed; the previous data was backed up first (see time-tracker backup list)")
+	} else {
+		fmt.Fprintln(out, "Fixed; backups are disabled, so the previous data was not backed up")
+	}
+	return nil
+}
+
+func init() {
+	doctorCmd.Flags().Bool("fix", false, "repair the fixable problems")
+	doctorCmd.Flags().Bool("dry-run", false, "with --fix, show the changes without saving them")
+	rootCmd.AddCommand(doctorCmd)
+}
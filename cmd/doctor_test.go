@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+type fakeDoctorStorage struct {
+	entries   []models.TimeEntry
+	projects  []models.Project
+	saved     bool
+	backedUp  bool
+	noBackups bool
+}
+
+func (f *fakeDoctorStorage) CreateBackup() error {
+	f.backedUp = !f.noBackups
+	return nil
+}
+
+func (f *fakeDoctorStorage) BackupsEnabled() bool {
+	return !f.noBackups
+}
+
+func (f *fakeDoctorStorage) LoadTasks() ([]models.Task, error) {
+	return nil, nil
+}
+
+func (f *fakeDoctorStorage) SaveAll(entries []models.TimeEntry, projects []models.Project, tasks []models.Task) error {
+	f.entries = entries
+	f.projects = projects
+	f.saved = true
+	return nil
+}
+
+const doctorTestData = `{"version": 7, "time-entries": [
+	{"start": "2025-03-10T10:00:00Z", "project": "Alpha", "title": "Design"},
+	{"start": "2025-03-10T09:00:00Z", "project": "Alpha", "title": "Design"}
+], "projects": []}`
+
+var doctorTestNow = time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func TestRunDoctor_ReportsWithoutSaving(t *testing.T) {
+	storage := &fakeDoctorStorage{}
+	var out bytes.Buffer
+
+	if err := runDoctor(storage, []byte(doctorTestData), doctorTestNow, false, false, &out); err != nil {
+		t.Fatalf("runDoctor returned error: %v", err)
+	}
+
+	if !strings.Contains(out.String(), "Found 2 problems (2 fixable)") || !strings.Contains(out.String(), "[ordering]") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+	if storage.saved {
+		t.Fatal("expected nothing to be saved without --fix")
+	}
+}
+
+func TestRunDoctor_DryRunShowsDiff(t *testing.T) {
+	storage := &fakeDoctorStorage{}
+	var out bytes.Buffer
+
+	if err := runDoctor(storage, []byte(doctorTestData), doctorTestNow, true, true, &out); err != nil {
+		t.Fatalf("runDoctor returned error: %v", err)
+	}
+
+	if !strings.Contains(out.String(), "  - entry") || !strings.Contains(out.String(), "Dry run") {
+		t.Fatalf("expected a diff and dry run notice, got: %q", out.String())
+	}
+	if storage.saved {
+		t.Fatal("expected nothing to be saved on a dry run")
+	}
+}
+
+func TestRunDoctor_FixSaves(t *testing.T) {
+	storage := &fakeDoctorStorage{}
+	var out bytes.Buffer
+
+	if err := runDoctor(storage, []byte(doctorTestData), doctorTestNow, true, false, &out); err != nil {
+		t.Fatalf("runDoctor returned error: %v", err)
+	}
+
+	if !storage.saved || len(storage.entries) != 1 {
+		t.Fatalf("expected the merged entry to be saved, got %+v", storage.entries)
+	}
+	if !storage.backedUp {
+		t.Fatal("expected the data to be backed up before the fix")
+	}
+}
+
+func TestRunDoctor_NoProblems(t *testing.T) {
+	var out bytes.Buffer
+	data := `{"version": 7, "time-entries": [], "projects": []}`
+
+	if err := runDoctor(&fakeDoctorStorage{}, []byte(data), doctorTestNow, true, false, &out); err != nil {
+		t.Fatalf("runDoctor returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), "No problems found") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestRunDoctor_FixBacksUpDataFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	if err := os.WriteFile(path, []byte(doctorTestData), 0644); err != nil {
+		t.Fatalf("failed to write data file: %v", err)
+	}
+	storage, err := utils.NewFileStorage(path)
+	if err != nil {
+		t.Fatalf("failed to create file storage: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := runDoctor(storage, []byte(doctorTestData), doctorTestNow, true, false, &out); err != nil {
+		t.Fatalf("runDoctor returned error: %v", err)
+	}
+
+	backups, err := storage.ListBackups()
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("expected one backup after --fix, got %+v (%v)", backups, err)
+	}
+	backedUp, _ := os.ReadFile(backups[0].Path)
+	if string(backedUp) != doctorTestData {
+		t.Fatalf("expected the backup to hold the data before the fix, got %q", backedUp)
+	}
+	if entries, _ := storage.Load(); len(entries) != 1 {
+		t.Fatalf("expected the fixed entries to be saved, got %+v", entries)
+	}
+}
+
+func TestRunDoctor_FixWithBackupsDisabled(t *testing.T) {
+	storage := &fakeDoctorStorage{noBackups: true}
+	var out bytes.Buffer
+
+	if err := runDoctor(storage, []byte(doctorTestData), doctorTestNow, true, false, &out); err != nil {
+		t.Fatalf("runDoctor returned error: %v", err)
+	}
+
+	if !storage.saved || storage.backedUp {
+		t.Fatalf("expected a save without a backup, got saved=%v backedUp=%v", storage.saved, storage.backedUp)
+	}
+	if strings.Contains(out.String(), "was backed up") || !strings.Contains(out.String(), "backups are disabled") {
+		t.Fatalf("expected the output to say no backup was made, got %q", out.String())
+	}
+}
@@ -2,19 +2,79 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"time"
 
 	"github.com/spf13/cobra"
 	"time-tracker/config"
+	"time-tracker/models"
+	"time-tracker/utils"
 )
 
+type entryEditor interface {
+	ResolveProject(input string) (string, error)
+	FindEntry(id string) (int, *models.TimeEntry, error)
+	UpdateEntry(idx int, project, title string, startTime time.Time, tags []string, notes string) error
+}
+
+// entryChanges holds the fields given on the command line; nil fields are left unchanged
+type entryChanges struct {
+	Project *string
+	Title   *string
+	Start   *time.Time
+	Tags    []string
+	SetTags bool
+	Notes   *string
+}
+
 var editCmd = &cobra.Command{
-	Use:     "edit",
-	Short:   "Edit the data file directly",
-	Long:    `Open the data.json file in your default editor (EDITOR environment variable).`,
+	Use:   "edit [id]",
+	Short: "Edit an entry by its ID, or the data file directly",
+	Long: `With an entry ID, the short code in the first column of "time-tracker list", change that
+entry's fields:
+
+  time-tracker edit k3x9a --title "Code review" --start 09:15
+  time-tracker edit k3x9a --tag billable --notes "Reviewed the API"
+
+--tag replaces the entry's tags (pass --tag "" to clear them). Editing an entry can be undone.
+
+Without an ID, open the data.json file in your default editor (EDITOR environment variable).
+A backup is taken first; use 'time-tracker backup restore' to undo a bad edit.`,
 	Aliases: []string{"e"},
+	Args:    cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 1 {
+			changes, err := parseEntryChanges(cmd, time.Now())
+			if err != nil {
+				return err
+			}
+
+			storage, err := openStorage()
+			if err != nil {
+				return fmt.Errorf("failed to initialize storage: %w", err)
+			}
+			return editEntryByID(newTaskManager(storage), args[0], changes, os.Stdout)
+		}
+		for _, flag := range []string{"project", "title", "start", "tag", "notes"} {
+			if cmd.Flags().Changed(flag) {
+				return fmt.Errorf("--%s requires an entry ID", flag)
+			}
+		}
+
+		if backend := config.StorageBackend(); backend != utils.StorageBackendJSON {
+			return fmt.Errorf("edit only works with the %q storage backend (current: %q)", utils.StorageBackendJSON, backend)
+		}
+
+		storage, err := openBackupStorage()
+		if err != nil {
+			return err
+		}
+		if err := storage.CreateBackup(); err != nil {
+			return fmt.Errorf("failed to back up data file: %w", err)
+		}
+
 		dataFilePath := config.DataFilePath()
 
 		// Get the editor from the EDITOR environment variable, fallback to nano
@@ -37,6 +97,110 @@ var editCmd = &cobra.Command{
 	},
 }
 
+// parseEntryChanges reads the flags that were given into entryChanges
+func parseEntryChanges(cmd *cobra.Command, now time.Time) (entryChanges, error) {
+	var changes entryChanges
+	flags := cmd.Flags()
+
+	for _, field := range []struct {
+		flag  string
+		value **string
+	}{
+		{"project", &changes.Project},
+		{"title", &changes.Title},
+		{"notes", &changes.Notes},
+	} {
+		if !flags.Changed(field.flag) {
+			continue
+		}
+		value, err := flags.GetString(field.flag)
+		if err != nil {
+			return changes, fmt.Errorf("failed to parse %s flag: %w", field.flag, err)
+		}
+		*field.value = &value
+	}
+
+	if flags.Changed("start") {
+		expr, err := flags.GetString("start")
+		if err != nil {
+			return changes, fmt.Errorf("failed to parse start flag: %w", err)
+		}
+		start, err := utils.ParseTimeExpression(expr, now)
+		if err != nil {
+			return changes, err
+		}
+		changes.Start = &start
+	}
+
+	if flags.Changed("tag") {
+		tags, err := flags.GetStringSlice("tag")
+		if err != nil {
+			return changes, fmt.Errorf("failed to parse tag flag: %w", err)
+		}
+		changes.Tags = tags
+		changes.SetTags = true
+	}
+
+	return changes, nil
+}
+
+// editEntryByID applies the changes to the entry with the given ID
+func editEntryByID(taskManager entryEditor, id string, changes entryChanges, out io.Writer) error {
+	if changes.Project == nil && changes.Title == nil && changes.Start == nil && !changes.SetTags && changes.Notes == nil {
+		return fmt.Errorf("nothing to change: use --project, --title, --start, --tag, or --notes")
+	}
+
+	if changes.Project != nil {
+		// The new project may be given by its code or an alias
+		project, err := taskManager.ResolveProject(*changes.Project)
+		if err != nil {
+			return fmt.Errorf("failed to resolve project: %w", err)
+		}
+		changes.Project = &project
+	}
+
+	var updated models.TimeEntry
+	err := retryOnConflict(func() error {
+		idx, entry, err := taskManager.FindEntry(id)
+		if err != nil {
+			return err
+		}
+
+		updated = *entry
+		if changes.Project != nil {
+			updated.Project = *changes.Project
+		}
+		if changes.Title != nil {
+			updated.Title = *changes.Title
+		}
+		if changes.Start != nil {
+			updated.Start = *changes.Start
+		}
+		if changes.SetTags {
+			updated.Tags = utils.NormalizeTags(changes.Tags)
+		}
+		if changes.Notes != nil {
+			updated.Notes = *changes.Notes
+		}
+		return taskManager.UpdateEntry(idx, updated.Project, updated.Title, updated.Start, updated.Tags, updated.Notes)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to edit entry: %w", err)
+	}
+
+	if updated.IsBlank() {
+		fmt.Fprintf(out, "Updated entry %s: gap from %s\n", updated.ID, utils.FormatDateTime(updated.Start))
+		return nil
+	}
+	fmt.Fprintf(out, "Updated entry %s: \"%s\" in project \"%s\" from %s\n", updated.ID, updated.Title, updated.Project, utils.FormatDateTime(updated.Start))
+	return nil
+}
+
 func init() {
+	editCmd.Flags().String("project", "", "new project for the entry")
+	editCmd.Flags().String("title", "", "new title for the entry")
+	editCmd.Flags().String("start", "", `new start time: "09:15", "yesterday 17:30", "2025-03-10 09:15"`)
+	editCmd.Flags().StringSliceP("tag", "t", nil, "replace the entry's tags (repeatable or comma-separated)")
+	editCmd.Flags().String("notes", "", "replace the entry's notes")
 	rootCmd.AddCommand(editCmd)
 }
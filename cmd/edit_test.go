@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/utils"
+)
+
+func TestEditEntryByID_ChangesOnlyGivenFields(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	start := seedIDEntries(t, storage)
+	id := storedIDs(t, storage)[0]
+
+	title := "Code review"
+	newStart := start.Add(15 * time.Minute)
+	var out bytes.Buffer
+	if err := editEntryByID(tm, id, entryChanges{Title: &title, Start: &newStart}, &out); err != nil {
+		t.Fatalf("editEntryByID returned error: %v", err)
+	}
+
+	entries, _ := storage.Load()
+	entry := entries[0]
+	if entry.ID != id || entry.Project != "Acme" || entry.Title != "Code review" || !entry.Start.Equal(newStart) || utils.FormatTags(entry.Tags) != "dev" {
+		t.Fatalf("unexpected entry %+v", entry)
+	}
+	if !strings.Contains(out.String(), `Updated entry `+id+`: "Code review" in project "Acme"`) {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestEditEntryByID_ReplacesTagsAndRequiresChanges(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	seedIDEntries(t, storage)
+	id := storedIDs(t, storage)[0]
+
+	if err := editEntryByID(tm, id, entryChanges{Tags: []string{"billable"}, SetTags: true}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("editEntryByID returned error: %v", err)
+	}
+	entries, _ := storage.Load()
+	if utils.FormatTags(entries[0].Tags) != "billable" {
+		t.Fatalf("expected tags to be replaced, got %+v", entries[0].Tags)
+	}
+
+	if err := editEntryByID(tm, id, entryChanges{}, &bytes.Buffer{}); err == nil || !strings.Contains(err.Error(), "nothing to change") {
+		t.Fatalf("expected an error without changes, got %v", err)
+	}
+}
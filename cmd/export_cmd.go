@@ -22,6 +22,9 @@ Supports two export formats:
 - raw: Individual time entries with start/end times
 
 Running entries (without end times) and blank entries are excluded from exports.
+Use --tag to restrict the export to entries carrying the given tags.
+Use --depth with daily-projects to roll sub-projects up into their ancestors, e.g. --depth 1
+exports one row per top-level project and day.
 By default, output is written to stdout. Use --output to write to a file.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		format, err := cmd.Flags().GetString("format")
@@ -44,6 +47,19 @@ By default, output is written to stdout. Use --output to write to a file.`,
 		if err != nil {
 			return fmt.Errorf("failed to parse days flag: %w", err)
 		}
+		if !cmd.Flags().Changed("days") {
+			days = config.ExportDays()
+		}
+
+		tags, err := cmd.Flags().GetStringSlice("tag")
+		if err != nil {
+			return fmt.Errorf("failed to parse tag flag: %w", err)
+		}
+
+		depth, err := cmd.Flags().GetInt("depth")
+		if err != nil {
+			return fmt.Errorf("failed to parse depth flag: %w", err)
+		}
 
 		// Validate format
 		if format != "daily-projects" && format != "raw" {
@@ -51,12 +67,19 @@ By default, output is written to stdout. Use --output to write to a file.`,
 		}
 
 		// Load data
-		storage, err := utils.NewFileStorage(config.DataFilePath())
+		storage, err := openStorage()
 		if err != nil {
 			return fmt.Errorf("failed to initialize storage: %w", err)
 		}
 
-		exportData, err := buildExportData(storage, format, category, categoryProvided, days, time.Now())
+		exportData, err := buildExportData(storage, exportOptions{
+			Format:           format,
+			Category:         category,
+			CategoryProvided: categoryProvided,
+			Days:             days,
+			Tags:             tags,
+			Depth:            depth,
+		}, time.Now())
 		if err != nil {
 			return err
 		}
@@ -86,7 +109,9 @@ func init() {
 	exportCmd.Flags().StringP("format", "f", "daily-projects", "Export format: \"daily-projects\" or \"raw\"")
 	exportCmd.Flags().StringP("output", "o", "", "Output file path (default: stdout)")
 	exportCmd.Flags().String("category", "", "Filter exported rows by project category (case-insensitive)")
-	exportCmd.Flags().IntP("days", "d", 7, "Number of past days to include in export")
+	exportCmd.Flags().IntP("days", "d", 7, "Number of past days to include in export (default from the export-days setting)")
+	exportCmd.Flags().Int("depth", 0, "Roll daily-projects rows up to the ancestor project at this depth (0 keeps sub-projects separate)")
+	exportCmd.Flags().StringSliceP("tag", "t", nil, "Only export entries carrying this tag (repeatable; all given tags must match)")
 
 	rootCmd.AddCommand(exportCmd)
 }
@@ -96,23 +121,39 @@ type exportStorage interface {
 	LoadProjects() ([]models.Project, error)
 }
 
-func buildExportData(storage exportStorage, format, category string, categoryProvided bool, days int, now time.Time) (string, error) {
-	entries, err := storage.Load()
-	if err != nil {
-		return "", fmt.Errorf("failed to load entries: %w", err)
-	}
+// exportOptions selects the format and the entries of an export
+type exportOptions struct {
+	Format           string   // "daily-projects" or "raw"
+	Category         string   // Project category to keep, when CategoryProvided
+	CategoryProvided bool     // Whether to filter by Category
+	Days             int      // Number of past days to include
+	Tags             []string // Tags every exported entry must carry
+	Depth            int      // Depth daily-projects rows are rolled up to (0 keeps sub-projects separate)
+}
 
-	if days <= 0 {
+func buildExportData(storage exportStorage, opts exportOptions, now time.Time) (string, error) {
+	if opts.Days <= 0 {
 		return "", fmt.Errorf("days must be a positive integer")
 	}
-	entries = filterEntriesByPastDays(entries, days, now)
+	if opts.Depth < 0 {
+		return "", fmt.Errorf("depth cannot be negative")
+	}
+
+	// Only the entries of the exported days are loaded, with a day to spare on either side
+	entries, err := utils.LoadEntriesBetween(storage, now.AddDate(0, 0, -opts.Days-1), now.AddDate(0, 0, 2))
+	if err != nil {
+		return "", fmt.Errorf("failed to load entries: %w", err)
+	}
+	entries = filterEntriesByPastDays(entries, opts.Days, now)
+	entries = utils.FilterEntriesByTags(entries, opts.Tags)
 
-	trimmedCategory := strings.TrimSpace(category)
+	categoryProvided := opts.CategoryProvided
+	trimmedCategory := strings.TrimSpace(opts.Category)
 	if categoryProvided && trimmedCategory == "" {
 		return "", fmt.Errorf("category cannot be empty or whitespace")
 	}
 
-	switch format {
+	switch opts.Format {
 	case "daily-projects":
 		aggregated := utils.AggregateByProjectDate(entries)
 		projects, err := storage.LoadProjects()
@@ -123,6 +164,7 @@ func buildExportData(storage exportStorage, format, category string, categoryPro
 		if categoryProvided {
 			aggregated = filterAggregatedByCategory(aggregated, trimmedCategory)
 		}
+		aggregated = utils.RollUpProjectDates(aggregated, utils.NewProjectTree(projects), opts.Depth)
 
 		exportData, err := utils.ExportDailyProjects(aggregated)
 		if err != nil {
@@ -146,7 +188,7 @@ func buildExportData(storage exportStorage, format, category string, categoryPro
 		return exportData, nil
 
 	default:
-		return "", fmt.Errorf("invalid format %q. Must be 'daily-projects' or 'raw'", format)
+		return "", fmt.Errorf("invalid format %q. Must be 'daily-projects' or 'raw'", opts.Format)
 	}
 }
 
@@ -50,7 +50,7 @@ func TestBuildExportData_DailyProjectsFiltersByCategory(t *testing.T) {
 		t.Fatalf("SaveProjects returned error: %v", err)
 	}
 
-	exported, err := buildExportData(storage, "daily-projects", "  client ", true, 7, now)
+	exported, err := buildExportData(storage, exportOptions{Format: "daily-projects", Category: "  client ", CategoryProvided: true, Days: 7}, now)
 	if err != nil {
 		t.Fatalf("buildExportData returned error: %v", err)
 	}
@@ -69,11 +69,50 @@ func TestBuildExportData_DailyProjectsFiltersByCategory(t *testing.T) {
 	}
 }
 
+func TestBuildExportData_DailyProjectsRollsUpSubProjects(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	now := time.Date(2026, 3, 17, 12, 0, 0, 0, time.UTC)
+
+	start1 := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	start2 := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)
+	end2 := time.Date(2026, 3, 16, 11, 30, 0, 0, time.UTC)
+
+	err := storage.Save([]models.TimeEntry{
+		{Start: start1, End: &start2, Project: "Checkout", Title: "Build"},
+		{Start: start2, End: &end2, Project: "Website", Title: "Review"},
+	})
+	if err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	err = storage.SaveProjects([]models.Project{
+		{Name: "Acme", Code: "ACM"},
+		{Name: "Website", Parent: "Acme"},
+		{Name: "Checkout", Parent: "Website"},
+	})
+	if err != nil {
+		t.Fatalf("SaveProjects returned error: %v", err)
+	}
+
+	exported, err := buildExportData(storage, exportOptions{Format: "daily-projects", Days: 7, Depth: 1}, now)
+	if err != nil {
+		t.Fatalf("buildExportData returned error: %v", err)
+	}
+
+	records := parseExportTSV(t, exported)
+	if len(records) != 2 || !strings.Contains(exported, "Acme\tACM") || !strings.Contains(exported, "Build, Review") {
+		t.Fatalf("expected one Acme row with both tasks, got:\n%s", exported)
+	}
+
+	if _, err := buildExportData(storage, exportOptions{Format: "daily-projects", Days: 7, Depth: -1}, now); err == nil {
+		t.Fatal("expected error for a negative depth")
+	}
+}
+
 func TestBuildExportData_RejectsWhitespaceCategory(t *testing.T) {
 	storage := utils.NewMemoryStorage()
 	now := time.Date(2026, 3, 17, 12, 0, 0, 0, time.UTC)
 
-	_, err := buildExportData(storage, "daily-projects", "   ", true, 7, now)
+	_, err := buildExportData(storage, exportOptions{Format: "daily-projects", Category: "   ", CategoryProvided: true, Days: 7}, now)
 	if err == nil {
 		t.Fatal("expected error for whitespace-only category")
 	}
@@ -100,7 +139,7 @@ func TestBuildExportData_FiltersToPastDays(t *testing.T) {
 		t.Fatalf("Save returned error: %v", err)
 	}
 
-	exported, err := buildExportData(storage, "raw", "", false, 7, now)
+	exported, err := buildExportData(storage, exportOptions{Format: "raw", Days: 7}, now)
 	if err != nil {
 		t.Fatalf("buildExportData returned error: %v", err)
 	}
@@ -132,7 +171,7 @@ func TestBuildExportData_UsesCustomDaysWindow(t *testing.T) {
 		t.Fatalf("Save returned error: %v", err)
 	}
 
-	exported, err := buildExportData(storage, "raw", "", false, 30, now)
+	exported, err := buildExportData(storage, exportOptions{Format: "raw", Days: 30}, now)
 	if err != nil {
 		t.Fatalf("buildExportData returned error: %v", err)
 	}
@@ -147,7 +186,7 @@ func TestBuildExportData_RejectsNonPositiveDays(t *testing.T) {
 	storage := utils.NewMemoryStorage()
 	now := time.Date(2026, 3, 17, 12, 0, 0, 0, time.UTC)
 
-	_, err := buildExportData(storage, "raw", "", false, 0, now)
+	_, err := buildExportData(storage, exportOptions{Format: "raw", Days: 0}, now)
 	if err == nil {
 		t.Fatal("expected error for non-positive days")
 	}
@@ -179,3 +218,33 @@ func TestFilterEntriesByPastDays_UsesDayBoundary(t *testing.T) {
 		t.Fatalf("expected entry %q to be included, got %q", "Include", filtered[0].Title)
 	}
 }
+
+func TestBuildExportData_RawFiltersByTag(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	now := time.Date(2026, 3, 17, 12, 0, 0, 0, time.UTC)
+
+	start1 := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	start2 := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)
+	end2 := time.Date(2026, 3, 16, 11, 0, 0, 0, time.UTC)
+
+	err := storage.Save([]models.TimeEntry{
+		{Start: start1, End: &start2, Project: "Alpha", Title: "Standup", Tags: []string{"meeting"}},
+		{Start: start2, End: &end2, Project: "Alpha", Title: "Build"},
+	})
+	if err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	exported, err := buildExportData(storage, exportOptions{Format: "raw", Days: 7, Tags: []string{"Meeting"}}, now)
+	if err != nil {
+		t.Fatalf("buildExportData returned error: %v", err)
+	}
+
+	records := parseExportTSV(t, exported)
+	if len(records) != 2 {
+		t.Fatalf("expected 2 rows (header + 1 data), got %d", len(records))
+	}
+	if records[1][1] != "Standup" || records[1][5] != "meeting" {
+		t.Fatalf("expected only tagged entry, got %v", records[1])
+	}
+}
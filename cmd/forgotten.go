@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"time-tracker/config"
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+type forgottenStopper interface {
+	StopEntryAt(stopTime time.Time) (*models.TimeEntry, error)
+}
+
+// newActivityStore opens the last activity time shared by the CLI and the TUI
+func newActivityStore() utils.ActivityStore {
+	return utils.NewFileActivityStore(config.ActivityFilePath())
+}
+
+// interactiveInput returns stdin when it is a terminal, so scripts are never prompted
+func interactiveInput() io.Reader {
+	info, err := os.Stdin.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return nil
+	}
+	return os.Stdin
+}
+
+// checkForgottenEntry warns about a forgotten running entry and, with in set, asks when it ended
+func checkForgottenEntry(stopper forgottenStopper, activity utils.ActivityStore, entries []models.TimeEntry, threshold time.Duration, now time.Time, in io.Reader, out io.Writer) (bool, error) {
+	forgotten := utils.ForgottenEntry(entries, now, threshold)
+	if forgotten == nil {
+		if in != nil {
+			// Remembering the activity is best effort and never fails the command
+			_ = utils.RecordActivity(activity, entries, now, threshold)
+		}
+		return false, nil
+	}
+
+	fmt.Fprintf(out, "Warning: \"%s\" in project \"%s\" has been running for %s, since %s\n", forgotten.Title, forgotten.Project, utils.FormatDuration(now.Sub(forgotten.Start)), utils.FormatDateTime(forgotten.Start))
+	suggested, hasSuggestion := utils.SuggestedEnd(activity, *forgotten, now)
+
+	if in == nil {
+		hint := `Run "time-tracker stop --at <time>" to end it when you actually stopped`
+		if hasSuggestion {
+			hint += fmt.Sprintf(" (last activity: %s)", utils.FormatDateTime(suggested))
+		}
+		fmt.Fprintln(out, hint)
+		return false, nil
+	}
+
+	if hasSuggestion {
+		fmt.Fprintf(out, "When did it end? [Enter for the last activity at %s, a time like \"18:00\", or \"keep\"] ", utils.FormatDateTime(suggested))
+	} else {
+		fmt.Fprint(out, "When did it end? [a time like \"18:00\", or Enter to keep it] ")
+	}
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read end time: %w", err)
+	}
+	answer = strings.TrimSpace(answer)
+
+	var end time.Time
+	switch {
+	case strings.EqualFold(answer, "keep") || (answer == "" && !hasSuggestion):
+		return false, nil
+	case answer == "":
+		end = suggested
+	default:
+		end, err = utils.ParseTimeExpression(answer, now)
+		if err != nil {
+			return false, err
+		}
+		if end.After(now) {
+			return false, fmt.Errorf("time %s is in the future", utils.FormatDateTime(end))
+		}
+	}
+
+	var stopped *models.TimeEntry
+	err = retryOnConflict(func() error {
+		var err error
+		stopped, err = stopper.StopEntryAt(end)
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to end forgotten entry: %w", err)
+	}
+
+	fmt.Fprintf(out, "Ended \"%s\" in project \"%s\" at %s (duration: %s)\n", stopped.Title, stopped.Project, utils.FormatDateTime(end), utils.FormatDuration(stopped.Duration()))
+	return true, nil
+}
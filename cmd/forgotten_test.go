@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+func seedForgottenEntry(t *testing.T, storage *utils.MemoryStorage) time.Time {
+	t.Helper()
+
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	if err := storage.Save([]models.TimeEntry{{Start: start, Project: "Acme", Title: "Build"}}); err != nil {
+		t.Fatalf("failed to seed entries: %v", err)
+	}
+	return start
+}
+
+func TestCheckForgottenEntry_EndsAtLastActivityByDefault(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	start := seedForgottenEntry(t, storage)
+	activity := utils.NewMemoryActivityStore()
+	lastActivity := start.Add(8*time.Hour + 12*time.Minute)
+	if err := activity.SaveActivity(lastActivity); err != nil {
+		t.Fatal(err)
+	}
+	entries, _ := storage.Load()
+
+	var out bytes.Buffer
+	ended, err := checkForgottenEntry(tm, activity, entries, 10*time.Hour, start.Add(23*time.Hour), strings.NewReader("\n"), &out)
+	if err != nil || !ended {
+		t.Fatalf("expected the entry to be ended, got %v (%v)", ended, err)
+	}
+
+	entries, _ = storage.Load()
+	if len(entries) != 2 || entries[0].End == nil || !entries[0].End.Equal(lastActivity) || !entries[1].IsBlank() {
+		t.Fatalf("expected the entry to end at the last activity followed by a gap, got %+v", entries)
+	}
+	if !strings.Contains(out.String(), "has been running for 23h") || !strings.Contains(out.String(), "(duration: 8h 12m)") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestCheckForgottenEntry_EndsAtTypedTime(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	start := seedForgottenEntry(t, storage)
+	entries, _ := storage.Load()
+
+	now := start.Add(23 * time.Hour)
+	ended, err := checkForgottenEntry(tm, utils.NewMemoryActivityStore(), entries, 10*time.Hour, now, strings.NewReader("yesterday 17:30\n"), &bytes.Buffer{})
+	if err != nil || !ended {
+		t.Fatalf("expected the entry to be ended, got %v (%v)", ended, err)
+	}
+
+	entries, _ = storage.Load()
+	want := time.Date(2026, 3, 16, 17, 30, 0, 0, time.UTC)
+	if entries[0].End == nil || !entries[0].End.Equal(want) {
+		t.Fatalf("expected the entry to end at %v, got %+v", want, entries[0])
+	}
+}
+
+func TestCheckForgottenEntry_KeepLeavesEntryRunning(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	start := seedForgottenEntry(t, storage)
+	entries, _ := storage.Load()
+
+	for _, answer := range []string{"keep\n", "\n"} {
+		ended, err := checkForgottenEntry(tm, utils.NewMemoryActivityStore(), entries, 10*time.Hour, start.Add(23*time.Hour), strings.NewReader(answer), &bytes.Buffer{})
+		if err != nil || ended {
+			t.Fatalf("answer %q: expected the entry to be kept, got %v (%v)", answer, ended, err)
+		}
+	}
+
+	entries, _ = storage.Load()
+	if len(entries) != 1 || !entries[0].IsRunning() {
+		t.Fatalf("expected the entry to keep running, got %+v", entries)
+	}
+}
+
+func TestCheckForgottenEntry_NonInteractiveOnlyWarns(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	start := seedForgottenEntry(t, storage)
+	entries, _ := storage.Load()
+
+	var out bytes.Buffer
+	ended, err := checkForgottenEntry(tm, utils.NewMemoryActivityStore(), entries, 10*time.Hour, start.Add(23*time.Hour), nil, &out)
+	if err != nil || ended {
+		t.Fatalf("expected only a warning, got %v (%v)", ended, err)
+	}
+	if !strings.Contains(out.String(), "Warning:") || !strings.Contains(out.String(), "stop --at") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestCheckForgottenEntry_RecordsInteractiveActivity(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	start := seedForgottenEntry(t, storage)
+	entries, _ := storage.Load()
+	activity := utils.NewMemoryActivityStore()
+
+	now := start.Add(2 * time.Hour)
+	var out bytes.Buffer
+	if _, err := checkForgottenEntry(tm, activity, entries, 10*time.Hour, now, strings.NewReader(""), &out); err != nil {
+		t.Fatalf("checkForgottenEntry returned error: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("expected no output for a recent entry, got %q", out.String())
+	}
+	if got, _ := activity.LoadActivity(); !got.Equal(now) {
+		t.Fatalf("expected activity at %v, got %v", now, got)
+	}
+}
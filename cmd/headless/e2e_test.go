@@ -451,3 +451,32 @@ func TestE2E_StatusBarShowsAllShortcuts(t *testing.T) {
 		}
 	}
 }
+
+// TestE2E_UndoRedoShortcuts tests that 'u' and 'ctrl+r' undo and redo a stop
+func TestE2E_UndoRedoShortcuts(t *testing.T) {
+	server := setupTestServerWithData(t)
+	server.model.TaskManager.SetHistory(utils.NewMemoryHistoryStore())
+
+	sendKey(t, server, "s")
+	if last := server.model.Entries[len(server.model.Entries)-1]; !last.IsBlank() {
+		t.Fatalf("Expected a blank entry after stopping, got %+v", last)
+	}
+
+	state := sendKey(t, server, "u")
+	if state.Mode != "list" {
+		t.Errorf("Expected to stay in 'list' mode, got %q", state.Mode)
+	}
+	last := server.model.Entries[len(server.model.Entries)-1]
+	if !last.IsRunning() || last.Title != "task-3" {
+		t.Errorf("Expected 'task-3' to be running again after undo, got %+v", last)
+	}
+	if !strings.Contains(server.model.Status, "Undid: stop tracking") {
+		t.Errorf("Expected undo status, got %q", server.model.Status)
+	}
+
+	sendKey(t, server, "ctrl+r")
+	last = server.model.Entries[len(server.model.Entries)-1]
+	if !last.IsBlank() {
+		t.Errorf("Expected the stop to be redone, got %+v", last)
+	}
+}
@@ -58,11 +58,21 @@ func (s *Server) Initialize() error {
 	lipgloss.SetColorProfile(termenv.ANSI)
 
 	// Create storage and task manager
-	storage, err := utils.NewFileStorage(config.DataFilePath())
+	maxBackups, err := config.MaxBackups()
+	if err != nil {
+		return err
+	}
+	storage, err := utils.OpenStorage(config.StorageBackend(), utils.StorageOptions{
+		DataFile:     config.DataFilePath(),
+		DatabaseFile: config.DatabaseFilePath(),
+		JournalFile:  config.JournalFilePath(),
+		MaxBackups:   maxBackups,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to initialize storage: %w", err)
 	}
 	taskManager := utils.NewTaskManager(storage)
+	taskManager.SetHistory(utils.NewFileHistoryStore(config.HistoryFilePath()))
 
 	// Create TUI model
 	s.model = tui.NewModel(storage, taskManager)
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/spf13/cobra"
+	"time-tracker/config"
+	"time-tracker/utils"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import [data.json]",
+	Short: "Import a JSON data file into the SQLite database or event journal",
+	Long: fmt.Sprintf(`Import all time entries, projects, and tasks from a JSON data file into the storage of another
+backend: the SQLite database (--backend sqlite, the default) or the event journal (--backend journal).
+Without an argument the default data.json is imported.
+
+The import refuses to overwrite existing data unless --force is given.
+After importing, set %s to the backend name to use it.`, config.StorageEnvVar),
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		backend, err := cmd.Flags().GetString("backend")
+		if err != nil {
+			return fmt.Errorf("failed to parse backend flag: %w", err)
+		}
+		if backend != utils.StorageBackendSQLite && backend != utils.StorageBackendJournal {
+			return fmt.Errorf("invalid backend %q. Must be %q or %q", backend, utils.StorageBackendSQLite, utils.StorageBackendJournal)
+		}
+
+		force, err := cmd.Flags().GetBool("force")
+		if err != nil {
+			return fmt.Errorf("failed to parse force flag: %w", err)
+		}
+
+		sourcePath := config.DataFilePath()
+		if len(args) > 0 {
+			sourcePath = args[0]
+		}
+
+		// NewFileStorage creates missing files, so check first to avoid importing an empty file
+		if _, err := os.Stat(sourcePath); errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("data file %q does not exist", sourcePath)
+		}
+
+		source, err := utils.NewFileStorage(sourcePath)
+		if err != nil {
+			return fmt.Errorf("failed to open data file: %w", err)
+		}
+
+		options, err := storageOptions()
+		if err != nil {
+			return err
+		}
+
+		destination, err := utils.OpenStorage(backend, options)
+		if err != nil {
+			return fmt.Errorf("failed to open %s storage: %w", backend, err)
+		}
+		if closer, ok := destination.(io.Closer); ok {
+			defer closer.Close()
+		}
+
+		return importStorage(source, destination, force, os.Stdout)
+	},
+}
+
+func importStorage(source, destination utils.Storage, force bool, out io.Writer) error {
+	result, err := utils.CopyStorage(source, destination, force)
+	if errors.Is(err, utils.ErrStorageNotEmpty) {
+		return fmt.Errorf("failed to import data: %w (use --force to overwrite)", err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to import data: %w", err)
+	}
+
+	fmt.Fprintf(out, "Imported %d entries and %d projects", result.Entries, result.Projects)
+	if result.Tasks > 0 {
+		fmt.Fprintf(out, " with %d tasks", result.Tasks)
+	}
+	fmt.Fprintln(out)
+	return nil
+}
+
+func init() {
+	importCmd.Flags().String("backend", utils.StorageBackendSQLite, "Backend to import into: \"sqlite\" or \"journal\"")
+	importCmd.Flags().Bool("force", false, "overwrite existing data in the destination")
+	rootCmd.AddCommand(importCmd)
+}
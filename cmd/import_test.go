@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+func TestImportStorage_CopiesDataAndPrintsSummary(t *testing.T) {
+	source := utils.NewMemoryStorage()
+	destination := utils.NewMemoryStorage()
+
+	if err := source.Save([]models.TimeEntry{{Start: time.Now(), Project: "Alpha", Title: "Build"}}); err != nil {
+		t.Fatalf("failed to seed entries: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := importStorage(source, destination, false, &out); err != nil {
+		t.Fatalf("importStorage returned error: %v", err)
+	}
+
+	if !strings.Contains(out.String(), "Imported 1 entries and 1 projects") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestImportStorage_RefusesNonEmptyDestinationWithoutForce(t *testing.T) {
+	source := utils.NewMemoryStorage()
+	destination := utils.NewMemoryStorage()
+
+	if err := destination.SaveProjects([]models.Project{{Name: "Existing"}}); err != nil {
+		t.Fatalf("failed to seed projects: %v", err)
+	}
+
+	var out bytes.Buffer
+	err := importStorage(source, destination, false, &out)
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("expected error suggesting --force, got: %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"time-tracker/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var invoiceCmd = &cobra.Command{
+	Use:   "invoice",
+	Short: "Generate an invoice for billable projects",
+	Long: `Generate a line-itemized invoice with totals for billable projects.
+
+Each line is one project on one day, priced with the project's hourly rate. Only projects marked
+billable (see 'project edit --billable --rate') are included. Totals are grouped by currency.
+
+Use --category (or its alias --client) to restrict the invoice to projects of one category, and
+--from/--to (YYYY-MM-DD, inclusive) to choose the period. The period defaults to the current month.
+
+Supports two output formats:
+- markdown (default)
+- html`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return fmt.Errorf("failed to parse format flag: %w", err)
+		}
+
+		output, err := cmd.Flags().GetString("output")
+		if err != nil {
+			return fmt.Errorf("failed to parse output flag: %w", err)
+		}
+
+		category, err := cmd.Flags().GetString("category")
+		if err != nil {
+			return fmt.Errorf("failed to parse category flag: %w", err)
+		}
+		client, err := cmd.Flags().GetString("client")
+		if err != nil {
+			return fmt.Errorf("failed to parse client flag: %w", err)
+		}
+		if cmd.Flags().Changed("category") && cmd.Flags().Changed("client") {
+			return fmt.Errorf("--client is an alias for --category; use only one of them")
+		}
+		if cmd.Flags().Changed("client") {
+			category = client
+		}
+
+		fromStr, err := cmd.Flags().GetString("from")
+		if err != nil {
+			return fmt.Errorf("failed to parse from flag: %w", err)
+		}
+
+		toStr, err := cmd.Flags().GetString("to")
+		if err != nil {
+			return fmt.Errorf("failed to parse to flag: %w", err)
+		}
+
+		from, to, err := parseInvoicePeriod(fromStr, toStr, time.Now())
+		if err != nil {
+			return err
+		}
+
+		storage, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		invoice, err := buildInvoiceData(storage, format, category, from, to)
+		if err != nil {
+			return err
+		}
+
+		if output == "" {
+			_, err := os.Stdout.WriteString(invoice)
+			if err != nil {
+				return fmt.Errorf("failed to write to stdout: %w", err)
+			}
+			return nil
+		}
+
+		// Restricted permissions, same as export, since invoices contain rates
+		if err := os.WriteFile(output, []byte(invoice), 0600); err != nil {
+			return fmt.Errorf("failed to write to file %q: %w", output, err)
+		}
+		fmt.Fprintf(os.Stderr, "Invoice written to %s\n", output)
+		return nil
+	},
+}
+
+func init() {
+	invoiceCmd.Flags().StringP("format", "f", "markdown", "Invoice format: \"markdown\" or \"html\"")
+	invoiceCmd.Flags().StringP("output", "o", "", "Output file path (default: stdout)")
+	invoiceCmd.Flags().String("category", "", "Only invoice projects in this category (case-insensitive)")
+	invoiceCmd.Flags().String("client", "", "Alias for --category")
+	invoiceCmd.Flags().String("from", "", "First day of the period, YYYY-MM-DD (default: first day of this month)")
+	invoiceCmd.Flags().String("to", "", "Last day of the period, YYYY-MM-DD (default: today)")
+
+	rootCmd.AddCommand(invoiceCmd)
+}
+
+// parseInvoicePeriod parses the --from/--to flags, defaulting to the current month up to today
+func parseInvoicePeriod(fromStr, toStr string, now time.Time) (time.Time, time.Time, error) {
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if strings.TrimSpace(fromStr) != "" {
+		parsed, err := time.Parse("2006-01-02", strings.TrimSpace(fromStr))
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --from date %q: expected YYYY-MM-DD", fromStr)
+		}
+		from = parsed
+	}
+
+	if strings.TrimSpace(toStr) != "" {
+		parsed, err := time.Parse("2006-01-02", strings.TrimSpace(toStr))
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --to date %q: expected YYYY-MM-DD", toStr)
+		}
+		to = parsed
+	}
+
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("--to date must not be before --from date")
+	}
+
+	return from, to, nil
+}
+
+func buildInvoiceData(storage exportStorage, format, category string, from, to time.Time) (string, error) {
+	if format != "markdown" && format != "html" {
+		return "", fmt.Errorf("invalid format %q. Must be 'markdown' or 'html'", format)
+	}
+
+	entries, err := storage.Load()
+	if err != nil {
+		return "", fmt.Errorf("failed to load entries: %w", err)
+	}
+
+	projects, err := storage.LoadProjects()
+	if err != nil {
+		return "", fmt.Errorf("failed to load projects: %w", err)
+	}
+
+	aggregated := utils.ApplyProjectMetadata(utils.AggregateByProjectDate(entries), projects)
+
+	trimmedCategory := strings.TrimSpace(category)
+	if trimmedCategory != "" {
+		aggregated = filterAggregatedByCategory(aggregated, trimmedCategory)
+	}
+
+	invoice := utils.BuildInvoice(aggregated, trimmedCategory, from, to)
+
+	if format == "html" {
+		return utils.RenderInvoiceHTML(invoice)
+	}
+	return utils.RenderInvoiceMarkdown(invoice), nil
+}
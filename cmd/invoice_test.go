@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+func TestParseInvoicePeriod_DefaultsToCurrentMonth(t *testing.T) {
+	now := time.Date(2026, 3, 17, 15, 30, 0, 0, time.UTC)
+
+	from, to, err := parseInvoicePeriod("", "", now)
+	if err != nil {
+		t.Fatalf("parseInvoicePeriod returned error: %v", err)
+	}
+
+	if !from.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected first day of month, got %v", from)
+	}
+	if !to.Equal(time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected today, got %v", to)
+	}
+}
+
+func TestParseInvoicePeriod_RejectsInvalidRanges(t *testing.T) {
+	now := time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC)
+
+	if _, _, err := parseInvoicePeriod("03/01/2026", "", now); err == nil {
+		t.Fatal("expected error for malformed --from")
+	}
+	if _, _, err := parseInvoicePeriod("2026-03-10", "2026-03-01", now); err == nil {
+		t.Fatal("expected error when --to is before --from")
+	}
+}
+
+func TestBuildInvoiceData_FiltersByCategoryAndBillable(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+
+	start1 := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	end1 := time.Date(2026, 3, 16, 11, 0, 0, 0, time.UTC)
+	end2 := time.Date(2026, 3, 16, 12, 0, 0, 0, time.UTC)
+	end3 := time.Date(2026, 3, 16, 13, 0, 0, 0, time.UTC)
+
+	if err := storage.Save([]models.TimeEntry{
+		{Start: start1, End: &end1, Project: "Alpha", Title: "Build"},
+		{Start: end1, End: &end2, Project: "Beta", Title: "Docs"},
+		{Start: end2, End: &end3, Project: "Gamma", Title: "Support"},
+	}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	if err := storage.SaveProjects([]models.Project{
+		{Name: "Alpha", Code: "A1", Category: "Client", HourlyRateCents: 10000, Currency: "USD", Billable: true},
+		{Name: "Beta", Category: "Client", HourlyRateCents: 8000, Currency: "USD"},
+		{Name: "Gamma", Category: "Other", HourlyRateCents: 6000, Currency: "USD", Billable: true},
+	}); err != nil {
+		t.Fatalf("SaveProjects returned error: %v", err)
+	}
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	invoice, err := buildInvoiceData(storage, "markdown", " client ", from, to)
+	if err != nil {
+		t.Fatalf("buildInvoiceData returned error: %v", err)
+	}
+
+	if !strings.Contains(invoice, "| 2026-03-16 | Alpha | A1 | Build | 2.00 | 100.00 USD | 200.00 USD |") {
+		t.Fatalf("expected Alpha line, got:\n%s", invoice)
+	}
+	if strings.Contains(invoice, "Beta") {
+		t.Fatalf("did not expect non-billable project, got:\n%s", invoice)
+	}
+	if strings.Contains(invoice, "Gamma") {
+		t.Fatalf("did not expect project from another category, got:\n%s", invoice)
+	}
+}
+
+func TestBuildInvoiceData_RejectsUnknownFormat(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+
+	_, err := buildInvoiceData(storage, "pdf", "", time.Now(), time.Now())
+	if err == nil || !strings.Contains(err.Error(), "invalid format") {
+		t.Fatalf("expected invalid format error, got: %v", err)
+	}
+}
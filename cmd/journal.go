@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"time-tracker/config"
+	"time-tracker/utils"
+)
+
+type journalCompactor interface {
+	Compact() (int, int, error)
+}
+
+var journalCmd = &cobra.Command{
+	Use:   "journal",
+	Short: "Manage the event journal of the journal storage backend",
+}
+
+var journalCompactCmd = &cobra.Command{
+	Use:   "compact",
+	Short: "Rewrite the journal as a snapshot of the current data",
+	Long: `Rewrite the event journal as a snapshot of the current entries and projects.
+
+The journal storage backend only ever appends events, so the file keeps the full edit history
+and grows over time. Compacting drops superseded events; the current data is unchanged.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		journal, err := utils.NewJournalStorage(config.JournalFilePath())
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		return compactJournal(journal, os.Stdout)
+	},
+}
+
+func compactJournal(journal journalCompactor, out io.Writer) error {
+	before, after, err := journal.Compact()
+	if err != nil {
+		return fmt.Errorf("failed to compact journal: %w", err)
+	}
+
+	fmt.Fprintf(out, "Compacted journal from %d to %d events\n", before, after)
+	return nil
+}
+
+func init() {
+	journalCmd.AddCommand(journalCompactCmd)
+	rootCmd.AddCommand(journalCmd)
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type fakeJournalCompactor struct {
+	before int
+	after  int
+	err    error
+}
+
+func (f fakeJournalCompactor) Compact() (int, int, error) {
+	return f.before, f.after, f.err
+}
+
+func TestCompactJournal_PrintsEventCounts(t *testing.T) {
+	var out bytes.Buffer
+	if err := compactJournal(fakeJournalCompactor{before: 12, after: 4}, &out); err != nil {
+		t.Fatalf("compactJournal returned error: %v", err)
+	}
+
+	if !strings.Contains(out.String(), "Compacted journal from 12 to 4 events") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestCompactJournal_WrapsErrors(t *testing.T) {
+	var out bytes.Buffer
+	err := compactJournal(fakeJournalCompactor{err: errors.New("disk full")}, &out)
+	if err == nil || !strings.Contains(err.Error(), "failed to compact journal: disk full") {
+		t.Fatalf("expected wrapped error, got: %v", err)
+	}
+}
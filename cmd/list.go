@@ -7,7 +7,6 @@ import (
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
-	"time-tracker/config"
 	"time-tracker/models"
 	"time-tracker/utils"
 )
@@ -16,7 +15,10 @@ var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List time entries",
 	Long: `List time entries from data.json in chronological order (oldest first).
-By default, only the entries from the current day will be shown. Use --all to view all entries`,
+By default, only the entries from the current day will be shown. Use --all to view all entries
+and --notes to include each entry's notes.
+
+The ID column identifies an entry for edit, delete, and resume; IDs never change.`,
 	Aliases: []string{"l", "ls"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		displayAll, err := cmd.Flags().GetBool("all")
@@ -24,11 +26,16 @@ By default, only the entries from the current day will be shown. Use --all to vi
 			return fmt.Errorf("failed to parse all flag")
 		}
 
-		storage, err := utils.NewFileStorage(config.DataFilePath())
+		showNotes, err := cmd.Flags().GetBool("notes")
+		if err != nil {
+			return fmt.Errorf("failed to parse notes flag")
+		}
+
+		storage, err := openStorage()
 		if err != nil {
 			return fmt.Errorf("failed to initialize storage: %w", err)
 		}
-		taskManager := utils.NewTaskManager(storage)
+		taskManager := newTaskManager(storage)
 
 		allEntries, err := taskManager.ListEntries()
 		if err != nil {
@@ -63,34 +70,43 @@ By default, only the entries from the current day will be shown. Use --all to vi
 			return nil
 		}
 
-		displayEntriesTable(displayEntries)
+		displayEntriesTable(displayEntries, showNotes)
 		return nil
 	},
 }
 
-func displayEntriesTable(entries []models.TimeEntry) {
+func displayEntriesTable(entries []models.TimeEntry, showNotes bool) {
+	headers := []string{"ID", "Start", "End", "Project", "Title", "Duration"}
+	if showNotes {
+		headers = append(headers, "Notes")
+	}
+
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Start", "End", "Project", "Title", "Duration"})
+	table.SetHeader(headers)
 	table.SetBorder(true)
 	table.SetRowLine(true)
 	table.SetAutoWrapText(false)
 
 	for _, entry := range entries {
-		startTime := entry.Start.Format("2006-01-02 15:04")
+		startTime := utils.FormatDateTime(entry.Start)
 		endTime := "\033[32mrunning\033[0m"
 		if entry.End != nil {
-			endTime = entry.End.Format("2006-01-02 15:04")
+			endTime = utils.FormatDateTime(*entry.End)
 		}
 
 		duration := utils.FormatDuration(entry.Duration())
 
 		row := []string{
+			entry.ID,
 			startTime,
 			endTime,
 			entry.Project,
 			entry.Title,
 			duration,
 		}
+		if showNotes {
+			row = append(row, entry.Notes)
+		}
 
 		table.Append(row)
 	}
@@ -100,5 +116,6 @@ func displayEntriesTable(entries []models.TimeEntry) {
 
 func init() {
 	listCmd.Flags().BoolP("all", "a", false, "display all time entries")
+	listCmd.Flags().BoolP("notes", "n", false, "display entry notes")
 	rootCmd.AddCommand(listCmd)
 }
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+type noteManager interface {
+	ListEntries() ([]models.TimeEntry, error)
+	SetEntryNotes(idx int, notes string) (*models.TimeEntry, error)
+}
+
+var noteCmd = &cobra.Command{
+	Use:   "note [text]",
+	Short: "Show or set notes on the most recent entry",
+	Long: `Show or set the notes of the most recent non-blank time entry.
+
+Without arguments the current notes are printed. With a text argument the notes are replaced,
+or extended with a new line when --append is given. Use --clear to remove the notes.`,
+	Aliases: []string{"n"},
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appendNote, err := cmd.Flags().GetBool("append")
+		if err != nil {
+			return fmt.Errorf("failed to parse append flag: %w", err)
+		}
+
+		clearNote, err := cmd.Flags().GetBool("clear")
+		if err != nil {
+			return fmt.Errorf("failed to parse clear flag: %w", err)
+		}
+
+		storage, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		taskManager := newTaskManager(storage)
+		return retryOnConflict(func() error {
+			return noteLatestEntry(taskManager, args, appendNote, clearNote, os.Stdout)
+		})
+	},
+}
+
+func noteLatestEntry(taskManager noteManager, args []string, appendNote, clearNote bool, out io.Writer) error {
+	if clearNote && len(args) > 0 {
+		return fmt.Errorf("--clear does not accept a text argument")
+	}
+	if appendNote && len(args) == 0 {
+		return fmt.Errorf("--append requires a text argument")
+	}
+
+	entries, err := taskManager.ListEntries()
+	if err != nil {
+		return fmt.Errorf("failed to load entries: %w", err)
+	}
+
+	idx := utils.LastNonBlankIndex(entries)
+	if idx < 0 {
+		return fmt.Errorf("no time entries to annotate")
+	}
+	entry := entries[idx]
+
+	if len(args) == 0 && !clearNote {
+		if entry.Notes == "" {
+			fmt.Fprintf(out, "No notes for \"%s\" in project \"%s\"\n", entry.Title, entry.Project)
+			return nil
+		}
+		fmt.Fprintln(out, entry.Notes)
+		return nil
+	}
+
+	notes := ""
+	if len(args) > 0 {
+		notes = strings.TrimSpace(args[0])
+		if appendNote && entry.Notes != "" {
+			notes = entry.Notes + "\n" + notes
+		}
+	}
+
+	updated, err := taskManager.SetEntryNotes(idx, notes)
+	if err != nil {
+		return fmt.Errorf("failed to update notes: %w", err)
+	}
+
+	if updated.Notes == "" {
+		fmt.Fprintf(out, "Cleared notes for \"%s\" in project \"%s\"\n", updated.Title, updated.Project)
+		return nil
+	}
+
+	fmt.Fprintf(out, "Updated notes for \"%s\" in project \"%s\"\n", updated.Title, updated.Project)
+	return nil
+}
+
+func init() {
+	noteCmd.Flags().BoolP("append", "a", false, "append the text as a new line instead of replacing the notes")
+	noteCmd.Flags().Bool("clear", false, "remove the notes")
+	rootCmd.AddCommand(noteCmd)
+}
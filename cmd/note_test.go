@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+func seedNoteEntries(t *testing.T, storage *utils.MemoryStorage) {
+	t.Helper()
+
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	err := storage.Save([]models.TimeEntry{
+		{Start: start, End: &end, Project: "Acme", Title: "Build"},
+		{Start: end, Project: "", Title: ""},
+	})
+	if err != nil {
+		t.Fatalf("failed to seed entries: %v", err)
+	}
+}
+
+func TestNoteLatestEntry_SetsAndAppendsNotesOnLastNonBlankEntry(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	seedNoteEntries(t, storage)
+
+	var out bytes.Buffer
+	if err := noteLatestEntry(tm, []string{"Wired up the parser"}, false, false, &out); err != nil {
+		t.Fatalf("noteLatestEntry returned error: %v", err)
+	}
+	if err := noteLatestEntry(tm, []string{"Fixed tests"}, true, false, &out); err != nil {
+		t.Fatalf("noteLatestEntry returned error: %v", err)
+	}
+
+	entries, err := storage.Load()
+	if err != nil {
+		t.Fatalf("failed to load entries: %v", err)
+	}
+	if entries[0].Notes != "Wired up the parser\nFixed tests" {
+		t.Fatalf("unexpected notes: %q", entries[0].Notes)
+	}
+	if entries[1].Notes != "" {
+		t.Fatalf("expected blank entry to stay without notes, got %q", entries[1].Notes)
+	}
+
+	out.Reset()
+	if err := noteLatestEntry(tm, nil, false, false, &out); err != nil {
+		t.Fatalf("noteLatestEntry returned error: %v", err)
+	}
+	if out.String() != "Wired up the parser\nFixed tests\n" {
+		t.Fatalf("unexpected show output: %q", out.String())
+	}
+}
+
+func TestNoteLatestEntry_ClearRemovesNotes(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	seedNoteEntries(t, storage)
+
+	var out bytes.Buffer
+	if err := noteLatestEntry(tm, []string{"Temporary"}, false, false, &out); err != nil {
+		t.Fatalf("noteLatestEntry returned error: %v", err)
+	}
+	out.Reset()
+	if err := noteLatestEntry(tm, nil, false, true, &out); err != nil {
+		t.Fatalf("noteLatestEntry returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Cleared notes") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+
+	entries, err := storage.Load()
+	if err != nil {
+		t.Fatalf("failed to load entries: %v", err)
+	}
+	if entries[0].Notes != "" {
+		t.Fatalf("expected notes to be cleared, got %q", entries[0].Notes)
+	}
+}
+
+func TestNoteLatestEntry_ErrorsWithoutEntries(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+
+	var out bytes.Buffer
+	err := noteLatestEntry(tm, []string{"text"}, false, false, &out)
+	if err == nil || !strings.Contains(err.Error(), "no time entries") {
+		t.Fatalf("expected missing entries error, got %v", err)
+	}
+}
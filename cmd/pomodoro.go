@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"time-tracker/config"
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+type timeboxExpirer interface {
+	ExpireTimebox(store utils.TimeboxStore, now time.Time) ([]utils.TimeboxChange, error)
+}
+
+type pomodoroManager interface {
+	timeboxExpirer
+	ResolveProject(input string) (string, error)
+	StartPomodoro(store utils.TimeboxStore, cycle utils.PomodoroCycle, startTime time.Time) (*models.TimeEntry, *utils.Timebox, error)
+}
+
+var pomodoroCmd = &cobra.Command{
+	Use:   "pomodoro <project> <title>",
+	Short: "Track a cycle of pomodoros with breaks in between",
+	Long: `Start a pomodoro cycle: the entry is stopped after each pomodoro for a break, and started
+again when the break is over, until the long break after the last pomodoro ends the cycle.
+
+Breaks are recorded as gaps, so they are not counted as tracked time. The lengths default to
+the pomodoro-work, pomodoro-break, pomodoro-long-break, and pomodoro-rounds settings (25, 5,
+and 15 minutes, 4 pomodoros). Stopping or starting another entry by hand ends the cycle.
+
+The cycle moves on by itself in the TUI, and otherwise the next time any command runs, so the
+entries end up the same either way.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cycle := utils.PomodoroCycle{
+			Project:   args[0],
+			Title:     args[1],
+			Work:      config.PomodoroWork(),
+			Break:     config.PomodoroBreak(),
+			LongBreak: config.PomodoroLongBreak(),
+			Rounds:    config.PomodoroRounds(),
+		}
+
+		var err error
+		for flag, value := range map[string]*time.Duration{"work": &cycle.Work, "break": &cycle.Break, "long-break": &cycle.LongBreak} {
+			if cmd.Flags().Changed(flag) {
+				if *value, err = cmd.Flags().GetDuration(flag); err != nil {
+					return fmt.Errorf("failed to parse %s flag: %w", flag, err)
+				}
+			}
+		}
+		if cmd.Flags().Changed("rounds") {
+			if cycle.Rounds, err = cmd.Flags().GetInt("rounds"); err != nil {
+				return fmt.Errorf("failed to parse rounds flag: %w", err)
+			}
+		}
+		if cycle.Tags, err = cmd.Flags().GetStringSlice("tag"); err != nil {
+			return fmt.Errorf("failed to parse tag flag: %w", err)
+		}
+
+		at, err := cmd.Flags().GetString("at")
+		if err != nil {
+			return fmt.Errorf("failed to parse at flag: %w", err)
+		}
+		ago, err := cmd.Flags().GetString("ago")
+		if err != nil {
+			return fmt.Errorf("failed to parse ago flag: %w", err)
+		}
+		now := time.Now()
+		when, err := parseTrackTime(at, ago, now)
+		if err != nil {
+			return err
+		}
+
+		storage, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		return startPomodoro(newTaskManager(storage), newTimeboxStore(), cycle, when, now, os.Stdout)
+	},
+}
+
+// newTimeboxStore opens the timebox of the running entry shared by the CLI and the TUI
+func newTimeboxStore() utils.TimeboxStore {
+	return utils.NewFileTimeboxStore(config.TimeboxFilePath())
+}
+
+// startPomodoro starts a pomodoro cycle at `when`, applying the phases that are already over
+func startPomodoro(taskManager pomodoroManager, store utils.TimeboxStore, cycle utils.PomodoroCycle, when, now time.Time, out io.Writer) error {
+	project, err := taskManager.ResolveProject(cycle.Project)
+	if err != nil {
+		return fmt.Errorf("failed to resolve project: %w", err)
+	}
+	cycle.Project = project
+
+	var box *utils.Timebox
+	err = retryOnConflict(func() error {
+		var err error
+		_, box, err = taskManager.StartPomodoro(store, cycle, when)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start pomodoro: %w", err)
+	}
+
+	fmt.Fprintf(out, "Started %s on \"%s\" in project \"%s\" until %s\n", box.Label(), cycle.Title, cycle.Project, utils.FormatDateTime(box.Until))
+	return applyExpiredTimebox(taskManager, store, now, out)
+}
+
+// applyExpiredTimebox applies the expiry of the running entry's timebox, reporting each change
+func applyExpiredTimebox(taskManager timeboxExpirer, store utils.TimeboxStore, now time.Time, out io.Writer) error {
+	var changes []utils.TimeboxChange
+	err := retryOnConflict(func() error {
+		applied, err := taskManager.ExpireTimebox(store, now)
+		changes = append(changes, applied...)
+		return err
+	})
+	for _, change := range changes {
+		fmt.Fprintln(out, change)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply timebox: %w", err)
+	}
+	return nil
+}
+
+// expireTimeboxBeforeCommand applies an expired timebox before the command runs, best effort
+func expireTimeboxBeforeCommand(cmd *cobra.Command) {
+	if skipsTimeboxExpiry(cmd) {
+		return
+	}
+
+	storage, err := openStorage()
+	if err != nil {
+		return
+	}
+	if closer, ok := storage.(io.Closer); ok {
+		defer closer.Close()
+	}
+	if err := applyExpiredTimebox(utils.NewTaskManager(storage), newTimeboxStore(), time.Now(), os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// skipsTimeboxExpiry reports whether the command works on the history, backups, or storage
+func skipsTimeboxExpiry(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		switch c {
+		case configCmd, undoCmd, redoCmd, backupCmd, importCmd, doctorCmd, journalCmd:
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	pomodoroCmd.Flags().Duration("work", 0, "length of each pomodoro (default from the pomodoro-work setting)")
+	pomodoroCmd.Flags().Duration("break", 0, "length of the break after each pomodoro (default from the pomodoro-break setting)")
+	pomodoroCmd.Flags().Duration("long-break", 0, "length of the break after the last pomodoro, 0 for none (default from the pomodoro-long-break setting)")
+	pomodoroCmd.Flags().Int("rounds", 0, "number of pomodoros in the cycle (default from the pomodoro-rounds setting)")
+	pomodoroCmd.Flags().String("at", "", `start the cycle at this time instead of now, e.g. "09:15"`)
+	pomodoroCmd.Flags().String("ago", "", `start the cycle this long ago instead of now, e.g. "10m"`)
+	pomodoroCmd.Flags().StringSliceP("tag", "t", nil, "tag to attach to the pomodoro entries (repeatable or comma-separated)")
+	rootCmd.AddCommand(pomodoroCmd)
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+func TestStartPomodoro_AppliesPhasesAlreadyOver(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	store := utils.NewMemoryTimeboxStore()
+	now := time.Now().Truncate(time.Minute)
+	cycle := utils.PomodoroCycle{Project: "Acme", Title: "Build", Work: 25 * time.Minute, Break: 5 * time.Minute, LongBreak: 15 * time.Minute, Rounds: 4}
+
+	// Started 40 minutes ago: the first pomodoro and its break are over
+	var out bytes.Buffer
+	if err := startPomodoro(tm, store, cycle, now.Add(-40*time.Minute), now, &out); err != nil {
+		t.Fatalf("startPomodoro returned error: %v", err)
+	}
+
+	for _, want := range []string{`Started pomodoro 1/4 on "Build" in project "Acme"`, "; break until", "; started pomodoro 2/4"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected output to contain %q, got %q", want, out.String())
+		}
+	}
+
+	entries, _ := storage.Load()
+	if len(entries) != 3 || !entries[1].IsBlank() || !entries[2].IsRunning() || entries[2].Title != "Build" {
+		t.Fatalf("expected a pomodoro, a break, and the running second pomodoro, got %+v", entries)
+	}
+}
+
+func TestStartTimeboxedEntry_ReportsEndOfBox(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	store := utils.NewMemoryTimeboxStore()
+	now := time.Now()
+
+	var out bytes.Buffer
+	if err := startTimeboxedEntry(tm, store, "Acme", "Build", nil, 25*time.Minute, now, now, "", &out); err != nil {
+		t.Fatalf("startTimeboxedEntry returned error: %v", err)
+	}
+
+	want := `Started tracking time for "Build" in project "Acme" until ` + utils.FormatDateTime(now.Add(25*time.Minute))
+	if !strings.Contains(out.String(), want) {
+		t.Fatalf("expected output to contain %q, got %q", want, out.String())
+	}
+	if box, _ := store.LoadTimebox(); box == nil || box.Pomodoro != nil {
+		t.Fatalf("expected a plain timebox to be saved, got %+v", box)
+	}
+}
+
+func TestStartTimeboxedEntry_StopsBoxAlreadyOver(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	store := utils.NewMemoryTimeboxStore()
+	now := time.Now().Truncate(time.Minute)
+
+	// Started an hour ago with --ago: the 25 minute box ended long before now
+	var out bytes.Buffer
+	if err := startTimeboxedEntry(tm, store, "Acme", "Build", nil, 25*time.Minute, now.Add(-time.Hour), now, "", &out); err != nil {
+		t.Fatalf("startTimeboxedEntry returned error: %v", err)
+	}
+
+	if !strings.Contains(out.String(), `Timebox of "Build" in project "Acme" ended`) {
+		t.Fatalf("expected the box to be applied right away, got %q", out.String())
+	}
+	entries, _ := storage.Load()
+	if len(entries) != 2 || !entries[1].IsBlank() || !entries[1].Start.Equal(now.Add(-35*time.Minute)) {
+		t.Fatalf("expected the entry to be stopped at the end of its box, got %+v", entries)
+	}
+}
+
+func TestSkipsTimeboxExpiry(t *testing.T) {
+	for _, cmd := range []*cobra.Command{undoCmd, redoCmd, backupRestoreCmd, importCmd, doctorCmd, journalCompactCmd, configSetCmd} {
+		if !skipsTimeboxExpiry(cmd) {
+			t.Errorf("expected %q to skip the timebox expiry", cmd.CommandPath())
+		}
+	}
+	for _, cmd := range []*cobra.Command{trackCmd, pomodoroCmd, rootCmd} {
+		if skipsTimeboxExpiry(cmd) {
+			t.Errorf("expected %q to apply the timebox expiry", cmd.CommandPath())
+		}
+	}
+}
+
+func TestApplyExpiredTimebox_StopsEntry(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	store := utils.NewMemoryTimeboxStore()
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	if _, _, err := tm.StartTimebox(store, "Acme", "Build", start, 25*time.Minute); err != nil {
+		t.Fatalf("StartTimebox failed: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := applyExpiredTimebox(tm, store, start.Add(time.Hour), &out); err != nil {
+		t.Fatalf("applyExpiredTimebox returned error: %v", err)
+	}
+
+	if !strings.Contains(out.String(), `Timebox of "Build" in project "Acme" ended`) {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+	entries, _ := storage.Load()
+	if len(entries) != 2 || entries[0].End == nil || !entries[0].End.Equal(start.Add(25*time.Minute)) {
+		t.Fatalf("expected the entry to end with its timebox, got %+v", entries)
+	}
+}
+
+func TestStartPomodoro_ResolvesProjectCode(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	if err := storage.SaveProjects([]models.Project{{Name: "Acme Website", Code: "ABC"}}); err != nil {
+		t.Fatalf("failed to seed projects: %v", err)
+	}
+	now := time.Now()
+
+	var out bytes.Buffer
+	cycle := utils.PomodoroCycle{Project: "abc", Title: "Focus", Work: 25 * time.Minute, Break: 5 * time.Minute, Rounds: 2}
+	if err := startPomodoro(tm, utils.NewMemoryTimeboxStore(), cycle, now, now, &out); err != nil {
+		t.Fatalf("startPomodoro returned error: %v", err)
+	}
+	if entries, _ := storage.Load(); entries[0].Project != "Acme Website" || !strings.Contains(out.String(), `project "Acme Website"`) {
+		t.Fatalf("expected the code to resolve, got %+v, %q", entries, out.String())
+	}
+}
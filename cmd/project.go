@@ -6,10 +6,10 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
-	"time-tracker/config"
 	"time-tracker/models"
 	"time-tracker/utils"
 )
@@ -19,17 +19,123 @@ type projectListStorage interface {
 }
 
 type projectAddManager interface {
-	AddProject(name, code, category string) (*models.Project, error)
+	ResolveProject(input string) (string, error)
+	CreateProject(name string, changes utils.ProjectChanges) (*models.Project, error)
 }
 
 type projectEditManager interface {
-	EditProject(name, newName, code, category string) (*utils.ProjectMutationResult, error)
+	ResolveProject(input string) (string, error)
+	UpdateProject(name string, changes utils.ProjectChanges) (*utils.ProjectMutationResult, error)
 }
 
 type projectRemoveManager interface {
+	ResolveProject(input string) (string, error)
 	RemoveProject(name string) error
 }
 
+type projectArchiveManager interface {
+	ResolveProject(input string) (string, error)
+	SetProjectArchived(name string, archived bool) (*models.Project, error)
+}
+
+// parseProjectChanges reads the metadata flags of project add and edit that were set
+func parseProjectChanges(cmd *cobra.Command) (utils.ProjectChanges, error) {
+	var changes utils.ProjectChanges
+	flags := cmd.Flags()
+
+	for _, field := range []struct {
+		flag  string
+		value **string
+	}{
+		{"name", &changes.Name},
+		{"code", &changes.Code},
+		{"category", &changes.Category},
+		{"parent", &changes.Parent},
+		{"currency", &changes.Currency},
+	} {
+		if !flags.Changed(field.flag) {
+			continue
+		}
+		value, err := flags.GetString(field.flag)
+		if err != nil {
+			return changes, fmt.Errorf("failed to parse %s flag: %w", field.flag, err)
+		}
+		*field.value = &value
+	}
+
+	if flags.Changed("rate") {
+		value, err := flags.GetString("rate")
+		if err != nil {
+			return changes, fmt.Errorf("failed to parse rate flag: %w", err)
+		}
+		rate, err := utils.ParseMoney(value)
+		if err != nil {
+			return changes, fmt.Errorf("invalid --rate: %w", err)
+		}
+		changes.HourlyRate = &rate
+	}
+
+	if flags.Changed("budget") {
+		budget, err := flags.GetFloat64("budget")
+		if err != nil {
+			return changes, fmt.Errorf("failed to parse budget flag: %w", err)
+		}
+		changes.Budget = &budget
+	}
+
+	if flags.Changed("billable") {
+		billable, err := flags.GetBool("billable")
+		if err != nil {
+			return changes, fmt.Errorf("failed to parse billable flag: %w", err)
+		}
+		changes.Billable = &billable
+	}
+
+	if flags.Changed("alias") {
+		aliases, err := flags.GetStringSlice("alias")
+		if err != nil {
+			return changes, fmt.Errorf("failed to parse alias flag: %w", err)
+		}
+		changes.Aliases, changes.SetAliases = aliases, true
+	}
+
+	for _, field := range []struct {
+		flag  string
+		value **time.Time
+		set   *bool
+	}{
+		{"budget-from", &changes.BudgetFrom, &changes.SetBudgetFrom},
+		{"budget-to", &changes.BudgetTo, &changes.SetBudgetTo},
+	} {
+		if !flags.Changed(field.flag) {
+			continue
+		}
+		value, err := flags.GetString(field.flag)
+		if err != nil {
+			return changes, fmt.Errorf("failed to parse %s flag: %w", field.flag, err)
+		}
+		if *field.value, err = parseBudgetDate(value); err != nil {
+			return changes, fmt.Errorf("invalid --%s date %q: expected YYYY-MM-DD", field.flag, value)
+		}
+		*field.set = true
+	}
+
+	return changes, nil
+}
+
+// parseBudgetDate parses a YYYY-MM-DD date, or returns nil for an empty value
+func parseBudgetDate(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
+
 var projectCmd = &cobra.Command{
 	Use:   "project",
 	Short: "Manage projects",
@@ -38,75 +144,70 @@ var projectCmd = &cobra.Command{
 var projectListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List projects",
-	Long:  "List all projects with metadata.",
+	Long:  "List all projects with metadata. Archived projects are only listed with --all.",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		storage, err := utils.NewFileStorage(config.DataFilePath())
+		all, err := cmd.Flags().GetBool("all")
+		if err != nil {
+			return fmt.Errorf("failed to parse all flag: %w", err)
+		}
+
+		storage, err := openStorage()
 		if err != nil {
 			return fmt.Errorf("failed to initialize storage: %w", err)
 		}
 
-		return listProjects(storage, os.Stdout)
+		return listProjects(storage, all, os.Stdout)
 	},
 }
 
 var projectAddCmd = &cobra.Command{
 	Use:   "add <name>",
 	Short: "Add a project",
-	Long:  "Add a project with optional code and category metadata.",
-	Args:  cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		code, err := cmd.Flags().GetString("code")
-		if err != nil {
-			return fmt.Errorf("failed to parse code flag: %w", err)
-		}
+	Long: `Add a project with optional code, category, aliases, parent project, budget, and billing metadata.
 
-		category, err := cmd.Flags().GetString("category")
+Commands that take a project accept its code or an alias in place of its name.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		changes, err := parseProjectChanges(cmd)
 		if err != nil {
-			return fmt.Errorf("failed to parse category flag: %w", err)
+			return err
 		}
 
-		storage, err := utils.NewFileStorage(config.DataFilePath())
+		storage, err := openStorage()
 		if err != nil {
 			return fmt.Errorf("failed to initialize storage: %w", err)
 		}
 
-		taskManager := utils.NewTaskManager(storage)
-		return addProject(taskManager, args[0], code, category, os.Stdout)
+		taskManager := newTaskManager(storage)
+		return retryOnConflict(func() error {
+			return addProject(taskManager, args[0], changes, os.Stdout)
+		})
 	},
 }
 
 var projectEditCmd = &cobra.Command{
 	Use:   "edit <name>",
 	Short: "Edit a project",
-	Long:  "Edit a project's name, code, or category.",
-	Args:  cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		newName, err := cmd.Flags().GetString("name")
-		if err != nil {
-			return fmt.Errorf("failed to parse name flag: %w", err)
-		}
-
-		code, err := cmd.Flags().GetString("code")
-		if err != nil {
-			return fmt.Errorf("failed to parse code flag: %w", err)
-		}
+	Long: `Edit a project's name, code, category, aliases, parent project, budget, or billing metadata.
 
-		category, err := cmd.Flags().GetString("category")
+All the changes are saved at once, so an invalid flag changes nothing and one undo reverts
+the whole edit. Renaming a project to the name of another one merges it into that project.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		changes, err := parseProjectChanges(cmd)
 		if err != nil {
-			return fmt.Errorf("failed to parse category flag: %w", err)
+			return err
 		}
 
-		nameChanged := cmd.Flags().Changed("name")
-		codeChanged := cmd.Flags().Changed("code")
-		categoryChanged := cmd.Flags().Changed("category")
-
-		storage, err := utils.NewFileStorage(config.DataFilePath())
+		storage, err := openStorage()
 		if err != nil {
 			return fmt.Errorf("failed to initialize storage: %w", err)
 		}
 
-		taskManager := utils.NewTaskManager(storage)
-		return editProject(storage, taskManager, args[0], newName, code, category, nameChanged, codeChanged, categoryChanged, os.Stdout)
+		taskManager := newTaskManager(storage)
+		return retryOnConflict(func() error {
+			return editProject(taskManager, args[0], changes, os.Stdout)
+		})
 	},
 }
 
@@ -116,26 +217,80 @@ var projectRemoveCmd = &cobra.Command{
 	Long:  "Remove a project if it is not referenced by any time entries.",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		storage, err := utils.NewFileStorage(config.DataFilePath())
+		storage, err := openStorage()
 		if err != nil {
 			return fmt.Errorf("failed to initialize storage: %w", err)
 		}
 
-		taskManager := utils.NewTaskManager(storage)
-		return removeProject(taskManager, args[0], os.Stdout)
+		taskManager := newTaskManager(storage)
+		return retryOnConflict(func() error {
+			return removeProject(taskManager, args[0], os.Stdout)
+		})
 	},
 }
 
-func addProject(taskManager projectAddManager, name, code, category string, out io.Writer) error {
-	project, err := taskManager.AddProject(name, code, category)
+var projectArchiveCmd = &cobra.Command{
+	Use:   "archive <name>",
+	Short: "Archive a project",
+	Long: `Archive a finished project: it is no longer suggested when starting entries and is
+hidden from the project list, but its entries still show up in list, stats, and export.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSetProjectArchived(args[0], true)
+	},
+}
+
+var projectUnarchiveCmd = &cobra.Command{
+	Use:   "unarchive <name>",
+	Short: "Unarchive a project",
+	Long:  "Unarchive a project so that it is suggested and listed again.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSetProjectArchived(args[0], false)
+	},
+}
+
+func runSetProjectArchived(name string, archived bool) error {
+	storage, err := openStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	taskManager := newTaskManager(storage)
+	return retryOnConflict(func() error {
+		return setProjectArchived(taskManager, name, archived, os.Stdout)
+	})
+}
+
+// addProject adds the project with all its metadata in one save
+func addProject(taskManager projectAddManager, name string, changes utils.ProjectChanges, out io.Writer) error {
+	if err := resolveProjectParent(taskManager, &changes); err != nil {
+		return fmt.Errorf("failed to add project: %w", err)
+	}
+
+	project, err := taskManager.CreateProject(name, changes)
 	if err != nil {
 		return fmt.Errorf("failed to add project: %w", err)
 	}
 
 	fmt.Fprintf(out, "Added project %q\n", project.Name)
+	printProjectChanges(*project, changes, out)
 	return nil
 }
 
+// lookupProject finds the project the input refers to by name, code, or alias
+func lookupProject(projects []models.Project, input string) (models.Project, error) {
+	name, err := utils.ResolveProject(projects, input)
+	if err != nil {
+		return models.Project{}, err
+	}
+	project, found := findProjectByName(projects, name)
+	if !found {
+		return models.Project{}, fmt.Errorf("project %q not found", strings.TrimSpace(input))
+	}
+	return project, nil
+}
+
 func findProjectByName(projects []models.Project, name string) (models.Project, bool) {
 	lookupName := strings.TrimSpace(name)
 	for _, project := range projects {
@@ -147,63 +302,137 @@ func findProjectByName(projects []models.Project, name string) (models.Project,
 	return models.Project{}, false
 }
 
-func editProject(
-	storage projectListStorage,
-	taskManager projectEditManager,
-	name, newName, code, category string,
-	nameChanged, codeChanged, categoryChanged bool,
-	out io.Writer,
-) error {
-	if !nameChanged && !codeChanged && !categoryChanged {
-		return fmt.Errorf("at least one flag must be provided: --name, --code, or --category")
+// editProject applies all the changes to the project in one save
+func editProject(taskManager projectEditManager, name string, changes utils.ProjectChanges, out io.Writer) error {
+	if changes.IsEmpty() {
+		return fmt.Errorf("at least one flag must be provided: --name, --code, --category, --alias, --parent, --budget, --budget-from, --budget-to, --rate, --currency, or --billable")
 	}
-
-	if nameChanged && strings.TrimSpace(newName) == "" {
+	if changes.Name != nil && strings.TrimSpace(*changes.Name) == "" {
 		return fmt.Errorf("project name cannot be empty")
 	}
 
-	if !codeChanged || !categoryChanged {
-		projects, err := storage.LoadProjects()
-		if err != nil {
-			return fmt.Errorf("failed to load projects: %w", err)
-		}
-
-		source, found := findProjectByName(projects, name)
-		if found {
-			if !codeChanged {
-				code = source.Code
-			}
-			if !categoryChanged {
-				category = source.Category
-			}
-		}
+	name, err := taskManager.ResolveProject(name)
+	if err != nil {
+		return fmt.Errorf("failed to resolve project: %w", err)
 	}
-
-	if !nameChanged {
-		newName = ""
+	if err := resolveProjectParent(taskManager, &changes); err != nil {
+		return fmt.Errorf("failed to edit project: %w", err)
 	}
 
-	result, err := taskManager.EditProject(name, newName, code, category)
+	result, err := taskManager.UpdateProject(name, changes)
 	if err != nil {
 		return fmt.Errorf("failed to edit project: %w", err)
 	}
 
-	if result.Merged {
+	switch {
+	case result.Merged:
 		fmt.Fprintf(out, "Merged project %q into %q (%d entries rewritten)\n", result.SourceName, result.TargetName, result.RewrittenEntries)
-		return nil
+	case result.Renamed:
+		fmt.Fprintf(out, "Renamed project %q to %q (%d entries rewritten)\n", result.SourceName, result.TargetName, result.RewrittenEntries)
+	default:
+		fmt.Fprintf(out, "Updated project %q\n", result.SourceName)
 	}
+	printProjectChanges(result.Project, changes, out)
+	return nil
+}
 
-	if result.Renamed {
-		fmt.Fprintf(out, "Renamed project %q to %q (%d entries rewritten)\n", result.SourceName, result.TargetName, result.RewrittenEntries)
+// resolveProjectParent replaces a parent given by its code or an alias with its name
+func resolveProjectParent(taskManager interface{ ResolveProject(string) (string, error) }, changes *utils.ProjectChanges) error {
+	if changes.Parent == nil {
 		return nil
 	}
+	parent, err := taskManager.ResolveProject(*changes.Parent)
+	if err != nil {
+		return err
+	}
+	changes.Parent = &parent
+	return nil
+}
+
+// printProjectChanges describes the aliases, parent, budget, and billing the changes set
+func printProjectChanges(project models.Project, changes utils.ProjectChanges, out io.Writer) {
+	if changes.SetAliases {
+		if len(project.Aliases) == 0 {
+			fmt.Fprintf(out, "Removed aliases of project %q\n", project.Name)
+		} else {
+			fmt.Fprintf(out, "Project %q can now be started as %s\n", project.Name, strings.Join(project.Aliases, ", "))
+		}
+	}
 
-	fmt.Fprintf(out, "Updated project %q\n", result.SourceName)
+	if changes.Parent != nil {
+		if project.Parent == "" {
+			fmt.Fprintf(out, "Project %q is now a top-level project\n", project.Name)
+		} else {
+			fmt.Fprintf(out, "Project %q is now a sub-project of %q\n", project.Name, project.Parent)
+		}
+	}
+
+	if changes.Budget != nil || changes.SetBudgetFrom || changes.SetBudgetTo {
+		if project.Budget == 0 {
+			fmt.Fprintf(out, "Removed budget of project %q\n", project.Name)
+		} else {
+			fmt.Fprintf(out, "Updated budget for project %q (%s)\n", project.Name, formatProjectBudget(project))
+		}
+	}
+
+	if changes.HourlyRate != nil || changes.Currency != nil || changes.Billable != nil {
+		fmt.Fprintf(out, "Updated billing for project %q (%s)\n", project.Name, formatProjectBilling(project))
+	}
+}
+
+// formatProjectBudget summarizes a budget, e.g. "40h, 2026-03-01 to 2026-03-31"
+func formatProjectBudget(project models.Project) string {
+	text := utils.FormatDuration(time.Duration(project.Budget * float64(time.Hour)))
+	if window := utils.FormatBudgetWindow(project.BudgetFrom, project.BudgetTo); window != "" {
+		text += ", " + window
+	}
+	return text
+}
+
+// formatProjectBilling summarizes billing metadata, e.g. "billable, 120.00 USD/h"
+func formatProjectBilling(project models.Project) string {
+	status := "non-billable"
+	if project.Billable {
+		status = "billable"
+	}
+	if project.HourlyRateCents == 0 && project.Currency == "" {
+		return status
+	}
+
+	rate := utils.FormatMoney(project.HourlyRateCents)
+	if project.Currency != "" {
+		rate += " " + project.Currency
+	}
+	return status + ", " + rate + "/h"
+}
+
+func setProjectArchived(taskManager projectArchiveManager, name string, archived bool, out io.Writer) error {
+	name, err := taskManager.ResolveProject(name)
+	if err != nil {
+		return fmt.Errorf("failed to resolve project: %w", err)
+	}
+
+	project, err := taskManager.SetProjectArchived(name, archived)
+	if err != nil {
+		if archived {
+			return fmt.Errorf("failed to archive project: %w", err)
+		}
+		return fmt.Errorf("failed to unarchive project: %w", err)
+	}
+
+	if project.Archived {
+		fmt.Fprintf(out, "Archived project %q\n", project.Name)
+	} else {
+		fmt.Fprintf(out, "Unarchived project %q\n", project.Name)
+	}
 	return nil
 }
 
 func removeProject(taskManager projectRemoveManager, name string, out io.Writer) error {
-	trimmedName := strings.TrimSpace(name)
+	trimmedName, err := taskManager.ResolveProject(strings.TrimSpace(name))
+	if err != nil {
+		return fmt.Errorf("failed to resolve project: %w", err)
+	}
 	if err := taskManager.RemoveProject(trimmedName); err != nil {
 		return fmt.Errorf("failed to remove project: %w", err)
 	}
@@ -212,12 +441,20 @@ func removeProject(taskManager projectRemoveManager, name string, out io.Writer)
 	return nil
 }
 
-func listProjects(storage projectListStorage, out io.Writer) error {
-	projects, err := storage.LoadProjects()
+// listProjects prints the projects, including the archived ones only when all is set
+func listProjects(storage projectListStorage, all bool, out io.Writer) error {
+	loaded, err := storage.LoadProjects()
 	if err != nil {
 		return fmt.Errorf("failed to load projects: %w", err)
 	}
 
+	projects := make([]models.Project, 0, len(loaded))
+	for _, project := range loaded {
+		if all || !project.Archived {
+			projects = append(projects, project)
+		}
+	}
+
 	sort.Slice(projects, func(i, j int) bool {
 		leftName := strings.ToLower(projects[i].Name)
 		rightName := strings.ToLower(projects[j].Name)
@@ -230,14 +467,34 @@ func listProjects(storage projectListStorage, out io.Writer) error {
 	})
 
 	table := tablewriter.NewWriter(out)
-	table.SetHeader([]string{"Name", "Code", "Category"})
+	header := []string{"Name", "Code", "Category", "Parent", "Billable", "Rate"}
+	if all {
+		header = append(header, "Archived")
+	}
+	table.SetHeader(header)
 	table.SetAutoFormatHeaders(false)
 	table.SetBorder(true)
 	table.SetRowLine(true)
 	table.SetAutoWrapText(false)
 
 	for _, project := range projects {
-		table.Append([]string{project.Name, project.Code, project.Category})
+		billable := ""
+		if project.Billable {
+			billable = "yes"
+		}
+		rate := ""
+		if project.HourlyRateCents != 0 || project.Currency != "" {
+			rate = strings.TrimSpace(utils.FormatMoney(project.HourlyRateCents) + " " + project.Currency)
+		}
+		row := []string{project.Name, project.Code, project.Category, project.Parent, billable, rate}
+		if all {
+			archived := ""
+			if project.Archived {
+				archived = "yes"
+			}
+			row = append(row, archived)
+		}
+		table.Append(row)
 	}
 
 	table.Render()
@@ -250,10 +507,24 @@ func init() {
 	projectEditCmd.Flags().String("name", "", "new project name")
 	projectEditCmd.Flags().String("code", "", "external project code")
 	projectEditCmd.Flags().String("category", "", "project category")
+	for _, c := range []*cobra.Command{projectAddCmd, projectEditCmd} {
+		c.Flags().StringSlice("alias", nil, `alternative name to start the project by (repeatable or comma-separated; use --alias "" to clear)`)
+		c.Flags().String("parent", "", `parent project, making this a sub-project (use --parent "" to clear)`)
+		c.Flags().Float64("budget", 0, "hour budget of the project and its sub-projects (0 removes it)")
+		c.Flags().String("budget-from", "", "first day counted towards the budget, as YYYY-MM-DD")
+		c.Flags().String("budget-to", "", "last day counted towards the budget, as YYYY-MM-DD")
+		c.Flags().String("rate", "", "hourly rate used for invoices, with at most two decimals")
+		c.Flags().String("currency", "", "currency code for the hourly rate (e.g. USD)")
+		c.Flags().Bool("billable", false, "whether time on the project is billable (use --billable=false to clear)")
+	}
+
+	projectListCmd.Flags().BoolP("all", "a", false, "also list archived projects")
 
 	projectCmd.AddCommand(projectAddCmd)
 	projectCmd.AddCommand(projectEditCmd)
 	projectCmd.AddCommand(projectRemoveCmd)
+	projectCmd.AddCommand(projectArchiveCmd)
+	projectCmd.AddCommand(projectUnarchiveCmd)
 	projectCmd.AddCommand(projectListCmd)
 	rootCmd.AddCommand(projectCmd)
 }
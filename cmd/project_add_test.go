@@ -13,7 +13,8 @@ func TestAddProject_TrimsInputAndPersistsProject(t *testing.T) {
 	tm := utils.NewTaskManager(storage)
 
 	var out bytes.Buffer
-	err := addProject(tm, "  Auth Refactor  ", " 12572 ", "  Infrastructure ", &out)
+	code, category := " 12572 ", "  Infrastructure "
+	err := addProject(tm, "  Auth Refactor  ", utils.ProjectChanges{Code: &code, Category: &category}, &out)
 	if err != nil {
 		t.Fatalf("addProject returned error: %v", err)
 	}
@@ -47,7 +48,7 @@ func TestAddProject_RejectsWhitespaceOnlyName(t *testing.T) {
 	tm := utils.NewTaskManager(storage)
 
 	var out bytes.Buffer
-	err := addProject(tm, "   ", "", "", &out)
+	err := addProject(tm, "   ", utils.ProjectChanges{}, &out)
 	if err == nil {
 		t.Fatal("expected error for empty project name")
 	}
@@ -62,11 +63,11 @@ func TestAddProject_RejectsCaseInsensitiveDuplicateName(t *testing.T) {
 	tm := utils.NewTaskManager(storage)
 
 	var out bytes.Buffer
-	if err := addProject(tm, "Auth Refactor", "", "", &out); err != nil {
+	if err := addProject(tm, "Auth Refactor", utils.ProjectChanges{}, &out); err != nil {
 		t.Fatalf("first addProject failed: %v", err)
 	}
 
-	err := addProject(tm, "auth refactor", "", "", &out)
+	err := addProject(tm, "auth refactor", utils.ProjectChanges{}, &out)
 	if err == nil {
 		t.Fatal("expected duplicate project name error")
 	}
@@ -75,3 +76,37 @@ func TestAddProject_RejectsCaseInsensitiveDuplicateName(t *testing.T) {
 		t.Fatalf("expected duplicate name error, got: %v", err)
 	}
 }
+
+func TestAddProject_InvalidFlagAddsNothing(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	tm.SetHistory(utils.NewMemoryHistoryStore())
+
+	parent, budget := "Missing", 10.0
+	err := addProject(tm, "Foo", utils.ProjectChanges{Parent: &parent, Budget: &budget}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "parent project \"Missing\" not found") {
+		t.Fatalf("expected the missing parent to fail, got %v", err)
+	}
+	if projects, _ := storage.LoadProjects(); len(projects) != 0 {
+		t.Fatalf("expected no project to be saved, got %+v", projects)
+	}
+
+	var out bytes.Buffer
+	parent, aliases := "", []string{"foo"}
+	if err := addProject(tm, "Foo", utils.ProjectChanges{Parent: &parent, Budget: &budget, Aliases: aliases, SetAliases: true}, &out); err != nil {
+		t.Fatalf("addProject returned error: %v", err)
+	}
+	for _, want := range []string{`Added project "Foo"`, `Project "Foo" can now be started as foo`, `Updated budget for project "Foo" (10h)`} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+
+	// One undo removes the project with all its metadata
+	if _, err := tm.Undo(); err != nil {
+		t.Fatalf("Undo returned error: %v", err)
+	}
+	if projects, _ := storage.LoadProjects(); len(projects) != 0 {
+		t.Fatalf("expected the add to be undone, got %+v", projects)
+	}
+}
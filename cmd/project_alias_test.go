@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+func TestEditProject_SetsAndClearsAliases(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	if err := storage.SaveProjects([]models.Project{{Name: "Acme Website", Code: "ABC-12572"}}); err != nil {
+		t.Fatalf("failed to seed projects: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := editProject(tm, "acme website", utils.ProjectChanges{Aliases: []string{"web", "site"}, SetAliases: true}, &out); err != nil {
+		t.Fatalf("editProject returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), `Project "Acme Website" can now be started as web, site`) {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+
+	out.Reset()
+	if err := showProject(storage, "Acme Website", time.Now(), &out); err != nil {
+		t.Fatalf("showProject returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Aliases:  web, site") {
+		t.Fatalf("expected the aliases in the project details, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := editProject(tm, "web", utils.ProjectChanges{SetAliases: true}, &out); err != nil {
+		t.Fatalf("editProject returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), `Removed aliases of project "Acme Website"`) {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestCommandsResolveProjectCodesAndAliases(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	if err := storage.SaveProjects([]models.Project{{Name: "Acme Website", Code: "ABC-12572", Aliases: []string{"web"}}, {Name: "Beta"}}); err != nil {
+		t.Fatalf("failed to seed projects: %v", err)
+	}
+	start := seedSplitEntries(t, storage)
+
+	if err := splitEntryAt(tm, start.Add(time.Hour), "web", "Review", &bytes.Buffer{}); err != nil {
+		t.Fatalf("splitEntryAt returned error: %v", err)
+	}
+	entries, _ := storage.Load()
+	if entries[1].Project != "Acme Website" {
+		t.Fatalf("expected split to resolve the alias, got %q", entries[1].Project)
+	}
+
+	project := "abc-12572"
+	if err := editEntryByID(tm, entries[0].ID, entryChanges{Project: &project}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("editEntryByID returned error: %v", err)
+	}
+	if entries, _ = storage.Load(); entries[0].Project != "Acme Website" {
+		t.Fatalf("expected edit to resolve the code, got %q", entries[0].Project)
+	}
+
+	if err := addTask(tm, "WEB", "Checkout", &bytes.Buffer{}); err != nil {
+		t.Fatalf("addTask returned error: %v", err)
+	}
+	var out bytes.Buffer
+	if err := listTasks(tm, "web", false, &out); err != nil || !strings.Contains(out.String(), "Acme Website") {
+		t.Fatalf("expected the task under the resolved project, got %v:\n%s", err, out.String())
+	}
+
+	if err := setProjectArchived(tm, "ABC-12572", true, &bytes.Buffer{}); err != nil {
+		t.Fatalf("setProjectArchived returned error: %v", err)
+	}
+	if err := showProject(storage, "web", start.Add(4*time.Hour), &bytes.Buffer{}); err != nil {
+		t.Fatalf("showProject returned error: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+func TestSetProjectArchived_ArchivesAndUnarchives(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+
+	if err := storage.SaveProjects([]models.Project{{Name: "Acme", Code: "A", Category: "Client"}}); err != nil {
+		t.Fatalf("failed to seed projects: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := setProjectArchived(tm, " acme ", true, &out); err != nil {
+		t.Fatalf("setProjectArchived returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), `Archived project "Acme"`) {
+		t.Fatalf("expected archive output, got %q", out.String())
+	}
+	if projects, _ := storage.LoadProjects(); len(projects) != 1 || !projects[0].Archived {
+		t.Fatalf("expected the project to be archived, got %+v", projects)
+	}
+
+	out.Reset()
+	if err := setProjectArchived(tm, "Acme", false, &out); err != nil {
+		t.Fatalf("setProjectArchived returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), `Unarchived project "Acme"`) {
+		t.Fatalf("expected unarchive output, got %q", out.String())
+	}
+
+	if err := setProjectArchived(tm, "Missing", true, &out); err == nil || !strings.Contains(err.Error(), "failed to archive project") {
+		t.Fatalf("expected archive error for a missing project, got %v", err)
+	}
+}
+
+func TestListProjects_HidesArchivedUnlessAll(t *testing.T) {
+	storage := projectListTestStorage{
+		projects: []models.Project{
+			{Name: "Active", Code: "A"},
+			{Name: "Legacy", Code: "L", Archived: true},
+		},
+	}
+
+	var out bytes.Buffer
+	if err := listProjects(storage, false, &out); err != nil {
+		t.Fatalf("listProjects returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Active") || strings.Contains(out.String(), "Legacy") || strings.Contains(out.String(), "Archived") {
+		t.Fatalf("expected only the active project without an Archived column, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := listProjects(storage, true, &out); err != nil {
+		t.Fatalf("listProjects returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Archived") || !strings.Contains(out.String(), "| Legacy | L    |          |        |          |      | yes      |") {
+		t.Fatalf("expected the archived project with an Archived column, got:\n%s", out.String())
+	}
+}
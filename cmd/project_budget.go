@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+type projectReportStorage interface {
+	Load() ([]models.TimeEntry, error)
+	LoadProjects() ([]models.Project, error)
+}
+
+var projectBudgetCmd = &cobra.Command{
+	Use:   "budget [name]",
+	Short: "Show how much of the project budgets is used",
+	Long: `Show the consumed and remaining hours of every project with a budget, or of the named one.
+
+Time on sub-projects counts towards the budget of their parent. The weekly burn rate is the
+average of the last 4 weeks, and the budget is projected to run out when the remaining hours
+are used up at that rate. Set a budget with "project edit <name> --budget 40".`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		storage, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+		return printProjectBudgets(storage, name, time.Now(), os.Stdout)
+	},
+}
+
+// printProjectBudgets prints a table of the budgets of all projects, or only the named one
+func printProjectBudgets(storage projectReportStorage, name string, now time.Time, out io.Writer) error {
+	projects, err := storage.LoadProjects()
+	if err != nil {
+		return fmt.Errorf("failed to load projects: %w", err)
+	}
+	entries, err := storage.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load entries: %w", err)
+	}
+
+	var budgeted []models.Project
+	if name != "" {
+		project, err := lookupProject(projects, name)
+		if err != nil {
+			return err
+		}
+		if project.Budget == 0 {
+			return fmt.Errorf("project %q has no budget: set one with \"time-tracker project edit %q --budget 40\"", project.Name, project.Name)
+		}
+		budgeted = append(budgeted, project)
+	} else {
+		for _, project := range projects {
+			if project.Budget > 0 {
+				budgeted = append(budgeted, project)
+			}
+		}
+	}
+	if len(budgeted) == 0 {
+		fmt.Fprintln(out, "No project has a budget")
+		return nil
+	}
+	sort.Slice(budgeted, func(i, j int) bool {
+		return strings.ToLower(budgeted[i].Name) < strings.ToLower(budgeted[j].Name)
+	})
+
+	table := tablewriter.NewWriter(out)
+	table.SetHeader([]string{"Project", "Window", "Budget", "Used", "Remaining", "Per Week", "Runs Out"})
+	table.SetAutoFormatHeaders(false)
+	table.SetBorder(true)
+	table.SetAutoWrapText(false)
+	for _, project := range budgeted {
+		report := utils.CalculateBudget(project, projects, entries, now)
+		table.Append([]string{
+			report.Project,
+			utils.FormatBudgetWindow(report.From, report.To),
+			formatTimeHHMM(report.Budget),
+			fmt.Sprintf("%s (%d%%)", formatTimeHHMM(report.Consumed), report.Percent()),
+			formatRemainingHHMM(report.Remaining()),
+			formatTimeHHMM(report.WeeklyBurn),
+			describeBudgetExhaustion(report),
+		})
+	}
+	table.Render()
+	return nil
+}
+
+// formatRemainingHHMM formats the time left in a budget, with a minus sign once it is overrun
+func formatRemainingHHMM(d time.Duration) string {
+	if d < 0 {
+		return formatDeltaHHMM(d)
+	}
+	return formatTimeHHMM(d)
+}
+
+// describeBudgetExhaustion tells when the budget runs out at the weekly burn rate
+func describeBudgetExhaustion(report utils.BudgetReport) string {
+	switch {
+	case report.OverBudget():
+		return "over budget"
+	case report.Exhausted.IsZero():
+		return "-"
+	case report.To != nil && report.Exhausted.After(report.To.AddDate(0, 0, 1)):
+		return report.Exhausted.Format("2006-01-02") + " (after the window)"
+	}
+	return report.Exhausted.Format("2006-01-02")
+}
+
+func init() {
+	projectCmd.AddCommand(projectBudgetCmd)
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+func newBudgetTestStorage(t *testing.T, now time.Time) *utils.MemoryStorage {
+	t.Helper()
+
+	storage := utils.NewMemoryStorage()
+	start := now.AddDate(0, 0, -7)
+	end := start.Add(10 * time.Hour)
+	if err := storage.Save([]models.TimeEntry{
+		{Start: start, End: &end, Project: "Checkout", Title: "Build"},
+		{Start: end},
+	}); err != nil {
+		t.Fatalf("failed to seed entries: %v", err)
+	}
+	if err := storage.SaveProjects([]models.Project{
+		{Name: "Acme", Code: "ACM", Budget: 40},
+		{Name: "Checkout", Parent: "Acme"},
+		{Name: "Internal"},
+	}); err != nil {
+		t.Fatalf("failed to seed projects: %v", err)
+	}
+	return storage
+}
+
+func TestPrintProjectBudgets_ListsBudgetedProjects(t *testing.T) {
+	now := time.Date(2026, 3, 29, 0, 0, 0, 0, time.UTC)
+	storage := newBudgetTestStorage(t, now)
+
+	var out bytes.Buffer
+	if err := printProjectBudgets(storage, "", now, &out); err != nil {
+		t.Fatalf("printProjectBudgets returned error: %v", err)
+	}
+
+	// 10h of 40h in the first week since the first entry: 30h left at 10h per week
+	want := "| Acme    |        | 40:00  | 10:00 (25%) | 30:00     | 10:00    | 2026-04-19 |"
+	if !strings.Contains(out.String(), want) || strings.Contains(out.String(), "Internal") {
+		t.Fatalf("expected only the Acme budget row %q, got:\n%s", want, out.String())
+	}
+
+	if err := printProjectBudgets(storage, "Internal", now, &out); err == nil || !strings.Contains(err.Error(), "has no budget") {
+		t.Fatalf("expected an error for a project without budget, got %v", err)
+	}
+}
+
+func TestEditProject_BudgetKeepsUnchangedFields(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	if err := storage.SaveProjects([]models.Project{{Name: "Acme", Budget: 40, BudgetFrom: &from}}); err != nil {
+		t.Fatalf("failed to seed projects: %v", err)
+	}
+
+	var out bytes.Buffer
+	hours := 60.0
+	if err := editProject(tm, "acme", utils.ProjectChanges{Budget: &hours}, &out); err != nil {
+		t.Fatalf("editProject returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), `Updated budget for project "Acme" (60h, from 2026-03-01)`) {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+
+	out.Reset()
+	hours = 0
+	if err := editProject(tm, "Acme", utils.ProjectChanges{Budget: &hours}, &out); err != nil {
+		t.Fatalf("editProject returned error: %v", err)
+	}
+	if projects, _ := storage.LoadProjects(); projects[0].Budget != 0 || projects[0].BudgetFrom != nil {
+		t.Fatalf("expected the budget to be removed, got %+v", projects[0])
+	}
+}
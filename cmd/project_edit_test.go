@@ -14,7 +14,7 @@ func TestEditProject_RequiresAtLeastOneFlag(t *testing.T) {
 	tm := utils.NewTaskManager(storage)
 
 	var out bytes.Buffer
-	err := editProject(storage, tm, "Acme", "", "", "", false, false, false, &out)
+	err := editProject(tm, "Acme", utils.ProjectChanges{}, &out)
 	if err == nil {
 		t.Fatal("expected error when no edit flags are provided")
 	}
@@ -33,7 +33,8 @@ func TestEditProject_RejectsWhitespaceOnlyNewName(t *testing.T) {
 	}
 
 	var out bytes.Buffer
-	err := editProject(storage, tm, "Acme", "   ", "", "", true, false, false, &out)
+	name := "   "
+	err := editProject(tm, "Acme", utils.ProjectChanges{Name: &name}, &out)
 	if err == nil {
 		t.Fatal("expected error for whitespace-only --name")
 	}
@@ -52,7 +53,8 @@ func TestEditProject_MetadataOnlyPreservesOmittedFieldsAndPrintsSimpleMessage(t
 	}
 
 	var out bytes.Buffer
-	err := editProject(storage, tm, "Acme", "", "", "Internal", false, false, true, &out)
+	category := "Internal"
+	err := editProject(tm, "Acme", utils.ProjectChanges{Category: &category}, &out)
 	if err != nil {
 		t.Fatalf("editProject returned error: %v", err)
 	}
@@ -98,7 +100,8 @@ func TestEditProject_RenameReportsRewrittenCount(t *testing.T) {
 	}
 
 	var out bytes.Buffer
-	err := editProject(storage, tm, "Legacy", "Current", "", "", true, false, false, &out)
+	name := "Current"
+	err := editProject(tm, "Legacy", utils.ProjectChanges{Name: &name}, &out)
 	if err != nil {
 		t.Fatalf("editProject returned error: %v", err)
 	}
@@ -131,7 +134,8 @@ func TestEditProject_MergeReportsRewrittenCount(t *testing.T) {
 	}
 
 	var out bytes.Buffer
-	err := editProject(storage, tm, "Legacy", "Current", "", "", true, false, false, &out)
+	name := "Current"
+	err := editProject(tm, "Legacy", utils.ProjectChanges{Name: &name}, &out)
 	if err != nil {
 		t.Fatalf("editProject returned error: %v", err)
 	}
@@ -144,3 +148,93 @@ func TestEditProject_MergeReportsRewrittenCount(t *testing.T) {
 		t.Fatalf("expected rewrite count in output, got %q", output)
 	}
 }
+
+func TestEditProject_BillingPreservesOmittedFields(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+
+	if err := storage.SaveProjects([]models.Project{{Name: "Acme", HourlyRateCents: 9000, Currency: "EUR"}}); err != nil {
+		t.Fatalf("failed to seed projects: %v", err)
+	}
+
+	var out bytes.Buffer
+	billable := true
+	err := editProject(tm, "acme", utils.ProjectChanges{Billable: &billable}, &out)
+	if err != nil {
+		t.Fatalf("editProject returned error: %v", err)
+	}
+
+	projects, err := storage.LoadProjects()
+	if err != nil {
+		t.Fatalf("failed to load projects: %v", err)
+	}
+	if projects[0].HourlyRateCents != 9000 || projects[0].Currency != "EUR" || !projects[0].Billable {
+		t.Fatalf("expected rate and currency preserved and billable set, got %+v", projects[0])
+	}
+
+	if !strings.Contains(out.String(), "Updated billing for project \"Acme\" (billable, 90.00 EUR/h)") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestEditProject_SetsAndClearsParent(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+
+	if err := storage.SaveProjects([]models.Project{{Name: "Acme", Code: "ACM"}, {Name: "Website"}}); err != nil {
+		t.Fatalf("failed to seed projects: %v", err)
+	}
+
+	// The parent may be given by its code
+	var out bytes.Buffer
+	parent := "acm"
+	if err := editProject(tm, "website", utils.ProjectChanges{Parent: &parent}, &out); err != nil {
+		t.Fatalf("editProject returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), `Project "Website" is now a sub-project of "Acme"`) {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+
+	out.Reset()
+	parent = ""
+	if err := editProject(tm, "Website", utils.ProjectChanges{Parent: &parent}, &out); err != nil {
+		t.Fatalf("editProject returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), `Project "Website" is now a top-level project`) {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+
+	parent = "Acme"
+	if err := editProject(tm, "Acme", utils.ProjectChanges{Parent: &parent}, &out); err == nil || !strings.Contains(err.Error(), "own parent") {
+		t.Fatalf("expected error for a self-parent, got %v", err)
+	}
+}
+
+func TestEditProject_InvalidFlagChangesNothing(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	tm.SetHistory(utils.NewMemoryHistoryStore())
+
+	if err := storage.SaveProjects([]models.Project{{Name: "Acme", Code: "A1"}}); err != nil {
+		t.Fatalf("failed to seed projects: %v", err)
+	}
+
+	name, code, rate := "Acme Corp", "A2", int64(-500)
+	if err := editProject(tm, "Acme", utils.ProjectChanges{Name: &name, Code: &code, HourlyRate: &rate}, &bytes.Buffer{}); err == nil {
+		t.Fatal("expected a negative rate to fail")
+	}
+	if projects, _ := storage.LoadProjects(); projects[0].Name != "Acme" || projects[0].Code != "A1" {
+		t.Fatalf("expected the project unchanged, got %+v", projects[0])
+	}
+
+	rate = 5000
+	if err := editProject(tm, "Acme", utils.ProjectChanges{Name: &name, Code: &code, HourlyRate: &rate}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("editProject returned error: %v", err)
+	}
+	if _, err := tm.Undo(); err != nil {
+		t.Fatalf("Undo returned error: %v", err)
+	}
+	if projects, _ := storage.LoadProjects(); projects[0].Name != "Acme" || projects[0].Code != "A1" || projects[0].HourlyRateCents != 0 {
+		t.Fatalf("expected one undo to revert the whole edit, got %+v", projects[0])
+	}
+}
@@ -28,7 +28,7 @@ func TestListProjects_SortsCaseInsensitiveAndShowsColumns(t *testing.T) {
 	}
 
 	var out bytes.Buffer
-	if err := listProjects(storage, &out); err != nil {
+	if err := listProjects(storage, false, &out); err != nil {
 		t.Fatalf("listProjects returned error: %v", err)
 	}
 
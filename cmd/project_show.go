@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+var projectShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show the details of a project",
+	Long: `Show a project's metadata, how much of its budget is used, and how it was used over its
+lifetime: the total tracked time, when it was first and last used, the time per title
+category and top titles, and the trend of the last 8 weeks. Sub-projects count towards
+their parent.
+
+The category of a title is the text before its first colon, e.g. "Review" for
+"Review: checkout PR", or else its first word.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		storage, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		return showProject(storage, args[0], time.Now(), os.Stdout)
+	},
+}
+
+// projectShowLimit is the number of title categories and top titles listed
+const projectShowLimit = 5
+
+// projectTrendBarWidth is the number of cells of the bar of the busiest week in the trend
+const projectTrendBarWidth = 20
+
+// showProject prints the metadata, budget, and lifetime statistics of the named project
+func showProject(storage projectReportStorage, name string, now time.Time, out io.Writer) error {
+	projects, err := storage.LoadProjects()
+	if err != nil {
+		return fmt.Errorf("failed to load projects: %w", err)
+	}
+	project, err := lookupProject(projects, name)
+	if err != nil {
+		return err
+	}
+	entries, err := storage.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load entries: %w", err)
+	}
+
+	fmt.Fprintf(out, "Project:  %s\n", project.Name)
+	if path := utils.NewProjectTree(projects).Path(project.Name); len(path) > 1 {
+		fmt.Fprintf(out, "Path:     %s\n", strings.Join(path, utils.ProjectPathSeparator))
+	}
+	fmt.Fprintf(out, "Code:     %s\n", valueOrDash(project.Code))
+	if len(project.Aliases) > 0 {
+		fmt.Fprintf(out, "Aliases:  %s\n", strings.Join(project.Aliases, ", "))
+	}
+	fmt.Fprintf(out, "Category: %s\n", valueOrDash(project.Category))
+	fmt.Fprintf(out, "Billing:  %s\n", formatProjectBilling(project))
+	if project.Archived {
+		fmt.Fprintln(out, "Archived: yes")
+	}
+
+	printProjectBudgetDetails(project, projects, entries, now, out)
+	printProjectUsage(utils.SummarizeProject(project, projects, entries, now), out)
+	return nil
+}
+
+func printProjectBudgetDetails(project models.Project, projects []models.Project, entries []models.TimeEntry, now time.Time, out io.Writer) {
+	if project.Budget == 0 {
+		fmt.Fprintln(out, "Budget:   -")
+		return
+	}
+	report := utils.CalculateBudget(project, projects, entries, now)
+	fmt.Fprintf(out, "Budget:   %s\n", formatProjectBudget(project))
+	fmt.Fprintf(out, "  Used:      %s (%d%%)\n", utils.FormatDuration(report.Consumed), report.Percent())
+	if report.OverBudget() {
+		fmt.Fprintf(out, "  Remaining: none, %s over budget\n", utils.FormatDuration(-report.Remaining()))
+	} else {
+		fmt.Fprintf(out, "  Remaining: %s\n", utils.FormatDuration(report.Remaining()))
+	}
+	fmt.Fprintf(out, "  Per week:  %s (last %d weeks)\n", utils.FormatDuration(report.WeeklyBurn), utils.BudgetBurnWeeks)
+	fmt.Fprintf(out, "  Runs out:  %s\n", describeBudgetExhaustion(report))
+}
+
+// printProjectUsage prints the lifetime statistics of a project summary
+func printProjectUsage(summary utils.ProjectSummary, out io.Writer) {
+	fmt.Fprintln(out)
+	if summary.Entries == 0 {
+		fmt.Fprintln(out, "Tracked:  nothing yet")
+		return
+	}
+	noun := "entries"
+	if summary.Entries == 1 {
+		noun = "entry"
+	}
+	fmt.Fprintf(out, "Tracked:  %s in %d %s\n", utils.FormatDuration(summary.Total), summary.Entries, noun)
+	fmt.Fprintf(out, "First:    %s\n", summary.FirstUsed.Format("2006-01-02"))
+	fmt.Fprintf(out, "Last:     %s\n", summary.LastUsed.Format("2006-01-02"))
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Categories:")
+	printTitleTotals(summary.Categories, summary.Total, out)
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Top titles:")
+	printTitleTotals(summary.Titles, summary.Total, out)
+
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "Weekly trend (last %d weeks):\n", len(summary.Weeks))
+	var busiest time.Duration
+	for _, week := range summary.Weeks {
+		busiest = max(busiest, week.Total)
+	}
+	for _, week := range summary.Weeks {
+		fmt.Fprintf(out, "  %s  %s  %s\n", week.WeekStart.Format("2006-01-02"), trendBar(week.Total, busiest), utils.FormatDuration(week.Total))
+	}
+}
+
+// printTitleTotals lists the first projectShowLimit totals with their share of the total time
+func printTitleTotals(totals []utils.TitleTotal, total time.Duration, out io.Writer) {
+	shown := totals[:min(len(totals), projectShowLimit)]
+	width := 0
+	for _, t := range shown {
+		width = max(width, len(t.Name))
+	}
+	for _, t := range shown {
+		// Entries started just now have not tracked any time yet
+		share := 0
+		if total > 0 {
+			share = int(t.Total * 100 / total)
+		}
+		fmt.Fprintf(out, "  %-*s  %7s  %3d%%\n", width, t.Name, utils.FormatDuration(t.Total), share)
+	}
+	if hidden := len(totals) - len(shown); hidden > 0 {
+		fmt.Fprintf(out, "  and %d more\n", hidden)
+	}
+}
+
+// trendBar renders a week's time as a bar scaled to the busiest week
+func trendBar(d, busiest time.Duration) string {
+	if busiest <= 0 {
+		return strings.Repeat("·", projectTrendBarWidth)
+	}
+	filled := int(float64(projectTrendBarWidth) * float64(d) / float64(busiest))
+	return strings.Repeat("█", filled) + strings.Repeat("·", projectTrendBarWidth-filled)
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func init() {
+	projectCmd.AddCommand(projectShowCmd)
+}
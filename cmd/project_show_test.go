@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+func TestShowProject_PrintsMetadataAndBudget(t *testing.T) {
+	now := time.Date(2026, 3, 29, 0, 0, 0, 0, time.UTC)
+	storage := newBudgetTestStorage(t, now)
+
+	var out bytes.Buffer
+	if err := showProject(storage, "acme", now, &out); err != nil {
+		t.Fatalf("showProject returned error: %v", err)
+	}
+	for _, want := range []string{"Project:  Acme", "Code:     ACM", "Budget:   40h", "Used:      10h (25%)", "Remaining: 30h", "Runs out:  2026-04-19"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := showProject(storage, "Checkout", now, &out); err != nil {
+		t.Fatalf("showProject returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Path:     Acme/Checkout") || !strings.Contains(out.String(), "Budget:   -") {
+		t.Fatalf("expected the sub-project path without budget, got:\n%s", out.String())
+	}
+
+	if err := showProject(storage, "Missing", now, &out); err == nil {
+		t.Fatal("expected an error for a missing project")
+	}
+}
+
+func TestShowProject_PrintsLifetimeStatistics(t *testing.T) {
+	now := time.Date(2026, 3, 29, 0, 0, 0, 0, time.UTC)
+	storage := newBudgetTestStorage(t, now)
+
+	var out bytes.Buffer
+	if err := showProject(storage, "Internal", now, &out); err != nil {
+		t.Fatalf("showProject returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Tracked:  nothing yet") {
+		t.Fatalf("expected an unused project to say so, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := showProject(storage, "Acme", now, &out); err != nil {
+		t.Fatalf("showProject returned error: %v", err)
+	}
+	for _, want := range []string{
+		"Tracked:  10h in 1 entry",
+		"First:    2026-03-22",
+		"Last:     2026-03-22",
+		"Categories:\n  Build      10h  100%",
+		"Top titles:\n  Build      10h  100%",
+		"Weekly trend (last 8 weeks):",
+		"  2026-03-16  ████████████████████  10h",
+		"  2026-03-23  ····················  0m",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestShowProject_EntryStartedJustNow(t *testing.T) {
+	now := time.Date(2026, 3, 29, 9, 0, 0, 0, time.UTC)
+	storage := utils.NewMemoryStorage()
+	if err := storage.Save([]models.TimeEntry{{Start: now, Project: "Acme", Title: "Build"}}); err != nil {
+		t.Fatalf("failed to seed entries: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := showProject(storage, "Acme", now, &out); err != nil {
+		t.Fatalf("showProject returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Build") || !strings.Contains(out.String(), "0%") {
+		t.Fatalf("expected the title with no share of the time yet, got:\n%s", out.String())
+	}
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+// resumePickLimit is how many recent project/title pairs --pick offers
+const resumePickLimit = 10
+
+type resumeManager interface {
+	ListEntries() ([]models.TimeEntry, error)
+	FindEntry(id string) (int, *models.TimeEntry, error)
+	StartEntryAt(project, title string, startTime time.Time, tags ...string) (*models.TimeEntry, error)
+}
+
+var resumeCmd = &cobra.Command{
+	Use:   "resume [id]",
+	Short: "Start tracking a recent project and title again",
+	Long: `Start a new entry with the project, title, and tags of a recent entry, like resuming
+an entry in the TUI.
+
+Without flags the most recent project/title pair is resumed; -n 2 resumes the second most
+recent, and so on. With --pick the recent pairs are listed to choose from. Entries that are
+running or blank are skipped. With an entry ID, as shown by "time-tracker list", that entry
+is resumed.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		n, err := cmd.Flags().GetInt("number")
+		if err != nil {
+			return fmt.Errorf("failed to parse number flag: %w", err)
+		}
+		pick, err := cmd.Flags().GetBool("pick")
+		if err != nil {
+			return fmt.Errorf("failed to parse pick flag: %w", err)
+		}
+		if pick && cmd.Flags().Changed("number") {
+			return fmt.Errorf("-n and --pick cannot be used together")
+		}
+		if len(args) == 1 && (pick || cmd.Flags().Changed("number")) {
+			return fmt.Errorf("an entry ID cannot be combined with -n or --pick")
+		}
+
+		at, err := cmd.Flags().GetString("at")
+		if err != nil {
+			return fmt.Errorf("failed to parse at flag: %w", err)
+		}
+		ago, err := cmd.Flags().GetString("ago")
+		if err != nil {
+			return fmt.Errorf("failed to parse ago flag: %w", err)
+		}
+		when, err := parseTrackTime(at, ago, time.Now())
+		if err != nil {
+			return err
+		}
+
+		storage, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		if len(args) == 1 {
+			return resumeEntryByID(newTaskManager(storage), args[0], when, os.Stdout)
+		}
+		return resumeRecentEntry(newTaskManager(storage), n, pick, when, os.Stdin, os.Stdout)
+	},
+}
+
+// resumeEntryByID starts the project, title, and tags of the entry with the given ID again
+func resumeEntryByID(taskManager resumeManager, id string, when time.Time, out io.Writer) error {
+	var started *models.TimeEntry
+	err := retryOnConflict(func() error {
+		_, entry, err := taskManager.FindEntry(id)
+		if err != nil {
+			return err
+		}
+		if entry.IsBlank() {
+			return fmt.Errorf("entry %s is a gap and cannot be resumed", entry.ID)
+		}
+
+		started, err = taskManager.StartEntryAt(entry.Project, entry.Title, when, entry.Tags...)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resume time entry: %w", err)
+	}
+
+	fmt.Fprintf(out, "Resumed tracking time for \"%s\" in project \"%s\"\n", started.Title, started.Project)
+	return nil
+}
+
+// resumeRecentEntry starts the n-th most recent project/title pair again, or the one picked from a list
+func resumeRecentEntry(taskManager resumeManager, n int, pick bool, when time.Time, in io.Reader, out io.Writer) error {
+	if n < 1 {
+		return fmt.Errorf("-n must be a positive number")
+	}
+
+	entries, err := taskManager.ListEntries()
+	if err != nil {
+		return fmt.Errorf("failed to load entries: %w", err)
+	}
+
+	limit := n
+	if pick {
+		limit = resumePickLimit
+	}
+	recent := utils.RecentEntries(entries, limit)
+	if len(recent) == 0 {
+		return fmt.Errorf("no entries to resume")
+	}
+
+	if pick {
+		n, err = pickRecentEntry(recent, in, out)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			fmt.Fprintln(out, "Resume cancelled")
+			return nil
+		}
+	} else if n > len(recent) {
+		return fmt.Errorf("only %d recent entries can be resumed", len(recent))
+	}
+
+	// Only the start is retried on a conflict, so the user is not asked to pick again
+	entry := recent[n-1]
+	var started *models.TimeEntry
+	err = retryOnConflict(func() error {
+		var err error
+		started, err = taskManager.StartEntryAt(entry.Project, entry.Title, when, entry.Tags...)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resume time entry: %w", err)
+	}
+
+	fmt.Fprintf(out, "Resumed tracking time for \"%s\" in project \"%s\"\n", started.Title, started.Project)
+	return nil
+}
+
+// pickRecentEntry lists the entries and reads a 1-based choice; 0 means cancelled
+func pickRecentEntry(recent []models.TimeEntry, in io.Reader, out io.Writer) (int, error) {
+	for i, entry := range recent {
+		line := fmt.Sprintf("%2d) %s: %s", i+1, entry.Project, entry.Title)
+		if len(entry.Tags) > 0 {
+			line += " [" + utils.FormatTags(entry.Tags) + "]"
+		}
+		fmt.Fprintf(out, "%s  (last %s)\n", line, utils.FormatDateTime(entry.Start))
+	}
+	fmt.Fprintf(out, "Resume which entry? [1-%d, empty to cancel] ", len(recent))
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return 0, fmt.Errorf("failed to read choice: %w", err)
+	}
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return 0, nil
+	}
+
+	choice, err := strconv.Atoi(answer)
+	if err != nil || choice < 1 || choice > len(recent) {
+		return 0, fmt.Errorf("invalid choice %q: enter a number from 1 to %d", answer, len(recent))
+	}
+	return choice, nil
+}
+
+func init() {
+	resumeCmd.Flags().IntP("number", "n", 1, "resume the n-th most recent project/title pair")
+	resumeCmd.Flags().BoolP("pick", "p", false, fmt.Sprintf("choose from the %d most recent project/title pairs", resumePickLimit))
+	resumeCmd.Flags().String("at", "", `start at this time instead of now: "09:15", "yesterday 17:30", "2025-03-10 09:15"`)
+	resumeCmd.Flags().String("ago", "", `start this long ago instead of now, e.g. "10m" or "1h30m"`)
+	rootCmd.AddCommand(resumeCmd)
+}
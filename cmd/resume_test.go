@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+func seedResumeEntries(t *testing.T, storage *utils.MemoryStorage) time.Time {
+	t.Helper()
+
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time { return start.Add(time.Duration(hours) * time.Hour) }
+	end := func(hours int) *time.Time { e := at(hours); return &e }
+	err := storage.Save([]models.TimeEntry{
+		{Start: at(0), End: end(1), Project: "Acme", Title: "Build", Tags: []string{"dev"}},
+		{Start: at(1), End: end(2), Project: "Beta", Title: "Review"},
+		{Start: at(2), End: end(3), Project: "Acme", Title: "Build", Tags: []string{"dev"}},
+		{Start: at(3), End: end(4), Project: "Gamma", Title: "Plan"},
+		{Start: at(4), Project: "", Title: ""},
+	})
+	if err != nil {
+		t.Fatalf("failed to seed entries: %v", err)
+	}
+	return at(5)
+}
+
+func TestResumeRecentEntry_ResumesMostRecentByDefault(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	when := seedResumeEntries(t, storage)
+
+	var out bytes.Buffer
+	if err := resumeRecentEntry(tm, 1, false, when, strings.NewReader(""), &out); err != nil {
+		t.Fatalf("resumeRecentEntry returned error: %v", err)
+	}
+
+	entries, _ := storage.Load()
+	last := entries[len(entries)-1]
+	if last.Project != "Gamma" || last.Title != "Plan" || !last.IsRunning() || !last.Start.Equal(when) {
+		t.Fatalf("expected running Gamma: Plan at %v, got %+v", when, last)
+	}
+	if !strings.Contains(out.String(), `Resumed tracking time for "Plan" in project "Gamma"`) {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestResumeRecentEntry_NthSkipsDuplicatePairsAndCopiesTags(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	when := seedResumeEntries(t, storage)
+
+	var out bytes.Buffer
+	if err := resumeRecentEntry(tm, 2, false, when, strings.NewReader(""), &out); err != nil {
+		t.Fatalf("resumeRecentEntry returned error: %v", err)
+	}
+
+	entries, _ := storage.Load()
+	last := entries[len(entries)-1]
+	if last.Project != "Acme" || last.Title != "Build" || len(last.Tags) != 1 || last.Tags[0] != "dev" {
+		t.Fatalf("expected Acme: Build with tag dev, got %+v", last)
+	}
+
+	if err := resumeRecentEntry(tm, 4, false, when.Add(time.Hour), strings.NewReader(""), &out); err == nil || !strings.Contains(err.Error(), "only 2 recent entries") {
+		t.Fatalf("expected too few entries error, got %v", err)
+	}
+}
+
+func TestResumeRecentEntry_Pick(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	when := seedResumeEntries(t, storage)
+
+	var out bytes.Buffer
+	if err := resumeRecentEntry(tm, 1, true, when, strings.NewReader("3\n"), &out); err != nil {
+		t.Fatalf("resumeRecentEntry returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), " 1) Gamma: Plan") || !strings.Contains(out.String(), " 2) Acme: Build [dev]") {
+		t.Fatalf("expected pick list, got %q", out.String())
+	}
+
+	entries, _ := storage.Load()
+	last := entries[len(entries)-1]
+	if last.Project != "Beta" || last.Title != "Review" {
+		t.Fatalf("expected Beta: Review, got %+v", last)
+	}
+}
+
+func TestResumeRecentEntry_PickCancelAndInvalid(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	when := seedResumeEntries(t, storage)
+
+	var out bytes.Buffer
+	if err := resumeRecentEntry(tm, 1, true, when, strings.NewReader("\n"), &out); err != nil {
+		t.Fatalf("resumeRecentEntry returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Resume cancelled") {
+		t.Fatalf("expected cancel message, got %q", out.String())
+	}
+
+	if err := resumeRecentEntry(tm, 1, true, when, strings.NewReader("9\n"), &out); err == nil || !strings.Contains(err.Error(), "invalid choice") {
+		t.Fatalf("expected invalid choice error, got %v", err)
+	}
+
+	entries, _ := storage.Load()
+	if len(entries) != 5 {
+		t.Fatalf("expected no new entries, got %d", len(entries))
+	}
+}
+
+func TestResumeRecentEntry_NoEntries(t *testing.T) {
+	tm := utils.NewTaskManager(utils.NewMemoryStorage())
+
+	var out bytes.Buffer
+	err := resumeRecentEntry(tm, 1, false, time.Now(), strings.NewReader(""), &out)
+	if err == nil || !strings.Contains(err.Error(), "no entries to resume") {
+		t.Fatalf("expected no entries error, got %v", err)
+	}
+}
+
+func TestResumeEntryByID_ResumesThatEntry(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	when := seedResumeEntries(t, storage)
+	ids := storedIDs(t, storage)
+
+	var out bytes.Buffer
+	if err := resumeEntryByID(tm, ids[1], when, &out); err != nil {
+		t.Fatalf("resumeEntryByID returned error: %v", err)
+	}
+
+	entries, _ := storage.Load()
+	last := entries[len(entries)-1]
+	if last.Project != "Beta" || last.Title != "Review" || !last.Start.Equal(when) {
+		t.Fatalf("expected the second entry to be resumed, got %+v", last)
+	}
+
+	if err := resumeEntryByID(tm, ids[4], when.Add(time.Hour), &out); err == nil || !strings.Contains(err.Error(), "gap") {
+		t.Fatalf("expected resuming a gap to fail, got %v", err)
+	}
+	if err := resumeEntryByID(tm, "zzzzz", when.Add(time.Hour), &out); err == nil || !strings.Contains(err.Error(), "no entry with ID zzzzz") {
+		t.Fatalf("expected an unknown ID to fail, got %v", err)
+	}
+}
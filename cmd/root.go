@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -17,16 +18,28 @@ var rootCmd = &cobra.Command{
 	Short: "A simple time tracker",
 	Long: `A simple time tracker TUI application.
 See https://github.com/mrs-electronics-inc/time-tracker for more details.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyConfig(cmd); err != nil {
+			return err
+		}
+		expireTimeboxBeforeCommand(cmd)
+		return nil
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// If no subcommand provided, launch the TUI
 		if len(args) == 0 {
-			storage, err := utils.NewFileStorage(config.DataFilePath())
+			storage, err := openStorage()
 			if err != nil {
 				return fmt.Errorf("failed to initialize storage: %w", err)
 			}
-			taskManager := utils.NewTaskManager(storage)
+			taskManager := newTaskManager(storage)
 
 			model := tui.NewModel(storage, taskManager)
+			model.ForgottenAfter = config.ForgottenAfter()
+			model.Activity = newActivityStore()
+			model.CompactThreshold = config.CompactThreshold()
+			model.Timeboxes = newTimeboxStore()
+			model.Schedule = configuredSchedule()
 			if err := model.LoadEntries(); err != nil {
 				return fmt.Errorf("failed to load entries: %w", err)
 			}
@@ -46,6 +59,29 @@ func Execute() {
 	}
 }
 
+// applyConfig validates the configuration and applies the display and grouping settings
+func applyConfig(cmd *cobra.Command) error {
+	if dataFile, err := cmd.Flags().GetString("data-file"); err == nil && dataFile != "" {
+		if err := config.SetOverride("data-file", dataFile); err != nil {
+			return err
+		}
+	}
+
+	if cmd.HasParent() && cmd.Parent() == configCmd {
+		return nil
+	}
+
+	if _, err := config.List(); err != nil {
+		return err
+	}
+
+	time.Local = config.Location()
+	utils.SetWeekStart(config.WeekStart())
+	utils.SetUse12HourClock(config.Use12HourClock())
+	return nil
+}
+
 func init() {
+	rootCmd.PersistentFlags().String("data-file", "", "path of the data file (overrides the data-file setting)")
 	rootCmd.AddCommand(headless.HeadlessCmd)
 }
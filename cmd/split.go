@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+type splitManager interface {
+	ResolveProject(input string) (string, error)
+	ListEntries() ([]models.TimeEntry, error)
+	SplitEntry(idx int, at time.Time, project, title string) (*models.TimeEntry, error)
+}
+
+var splitCmd = &cobra.Command{
+	Use:   "split <time> [project title]",
+	Short: "Split an entry in two at a point in time",
+	Long: `Split the entry that was running at the given time in two. The second half starts at that
+time with the same project, title, and tags; with a project and title it gets those instead,
+e.g. when you switched tasks at 11:00 but did not record it:
+
+  time-tracker split 11:00 my-project "Code review"
+
+The time accepts the same forms as --at: "11:00", "yesterday 17:30", "2025-03-10 11:00".`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 && len(args) != 3 {
+			return fmt.Errorf("accepts a time, optionally followed by project and title, received %d arguments", len(args))
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		now := time.Now()
+		at, err := utils.ParseTimeExpression(args[0], now)
+		if err != nil {
+			return err
+		}
+		if at.After(now) {
+			return fmt.Errorf("time %s is in the future", utils.FormatDateTime(at))
+		}
+
+		project, title := "", ""
+		if len(args) == 3 {
+			project, title = args[1], args[2]
+		}
+
+		storage, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		return splitEntryAt(newTaskManager(storage), at, project, title, os.Stdout)
+	},
+}
+
+// splitEntryAt splits the entry running at `at`, moving the second half to project and title if given
+func splitEntryAt(taskManager splitManager, at time.Time, project, title string, out io.Writer) error {
+	project, err := taskManager.ResolveProject(project)
+	if err != nil {
+		return fmt.Errorf("failed to resolve project: %w", err)
+	}
+
+	var first models.TimeEntry
+	var second *models.TimeEntry
+	err = retryOnConflict(func() error {
+		entries, err := taskManager.ListEntries()
+		if err != nil {
+			return fmt.Errorf("failed to load entries: %w", err)
+		}
+
+		idx := utils.EntryIndexAt(entries, at)
+		if idx < 0 {
+			return fmt.Errorf("no entry was running at %s", utils.FormatDateTime(at))
+		}
+
+		first = entries[idx]
+		second, err = taskManager.SplitEntry(idx, at, project, title)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to split entry: %w", err)
+	}
+
+	fmt.Fprintf(out, "Split \"%s\" in project \"%s\" at %s\n", first.Title, first.Project, utils.FormatDateTime(at))
+	if project != "" {
+		fmt.Fprintf(out, "Tracking \"%s\" in project \"%s\" from %s\n", second.Title, second.Project, utils.FormatDateTime(at))
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(splitCmd)
+}
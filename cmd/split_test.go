@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+func seedSplitEntries(t *testing.T, storage *utils.MemoryStorage) time.Time {
+	t.Helper()
+
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Hour)
+	err := storage.Save([]models.TimeEntry{
+		{Start: start, End: &end, Project: "Acme", Title: "Build"},
+		{Start: end, Project: ""},
+	})
+	if err != nil {
+		t.Fatalf("failed to seed entries: %v", err)
+	}
+	return start
+}
+
+func TestSplitEntryAt_MovesSecondHalfToNewProject(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	start := seedSplitEntries(t, storage)
+	at := start.Add(2 * time.Hour)
+
+	var out bytes.Buffer
+	if err := splitEntryAt(tm, at, "Beta", "Review", &out); err != nil {
+		t.Fatalf("splitEntryAt returned error: %v", err)
+	}
+
+	entries, _ := storage.Load()
+	if len(entries) != 3 || entries[0].Project != "Acme" || entries[1].Project != "Beta" || entries[1].Title != "Review" || !entries[1].Start.Equal(at) {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+	if !strings.Contains(out.String(), `Split "Build" in project "Acme"`) || !strings.Contains(out.String(), `Tracking "Review" in project "Beta"`) {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestSplitEntryAt_KeepsProjectWithoutArguments(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	start := seedSplitEntries(t, storage)
+
+	if err := splitEntryAt(tm, start.Add(time.Hour), "", "", &bytes.Buffer{}); err != nil {
+		t.Fatalf("splitEntryAt returned error: %v", err)
+	}
+
+	entries, _ := storage.Load()
+	if len(entries) != 3 || entries[1].Project != "Acme" || entries[1].Title != "Build" {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+}
+
+func TestSplitEntryAt_RequiresRunningEntry(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	start := seedSplitEntries(t, storage)
+
+	err := splitEntryAt(tm, start.Add(4*time.Hour), "", "", &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "no entry was running") {
+		t.Fatalf("expected no entry error, got %v", err)
+	}
+}
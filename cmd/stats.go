@@ -18,6 +18,14 @@ func formatTimeHHMM(d time.Duration) string {
 	return fmt.Sprintf("%02d:%02d", hours, minutes)
 }
 
+// formatDeltaHHMM formats an overtime or undertime as a signed HH:MM
+func formatDeltaHHMM(d time.Duration) string {
+	if d < 0 {
+		return "-" + formatTimeHHMM(-d)
+	}
+	return "+" + formatTimeHHMM(d)
+}
+
 // collectProjects gathers all non-empty project names from stats totals and returns them sorted
 func collectProjects(projectMaps []map[string]time.Duration) []string {
 	projectSet := make(map[string]bool)
@@ -39,7 +47,13 @@ func collectProjects(projectMaps []map[string]time.Duration) []string {
 var statsCmd = &cobra.Command{
 	Use:   "stats",
 	Short: "Display time tracking statistics",
-	Long:  `Display various statistics about tracked time, including daily totals, weekly totals, and project breakdowns.`,
+	Long: `Display various statistics about tracked time, including daily totals, weekly totals, and project breakdowns.
+
+When the target-hours setting is configured, the expected time and the difference to it are
+shown next to each total; holidays and vacation days expect no time.
+
+Use --depth to roll sub-projects up into their ancestors, e.g. --depth 1 shows one column per
+top-level project.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		weeklyFlag, err := cmd.Flags().GetBool("weekly")
 		if err != nil {
@@ -51,9 +65,24 @@ var statsCmd = &cobra.Command{
 			return fmt.Errorf("failed to parse rows flag: %w", err)
 		}
 
+		tags, err := cmd.Flags().GetStringSlice("tag")
+		if err != nil {
+			return fmt.Errorf("failed to parse tag flag: %w", err)
+		}
+
+		depth, err := cmd.Flags().GetInt("depth")
+		if err != nil {
+			return fmt.Errorf("failed to parse depth flag: %w", err)
+		}
+		if depth < 0 {
+			return fmt.Errorf("depth cannot be negative")
+		}
+
 		// Set default to 4 weeks for weekly view if user didn't specify rows
 		if weeklyFlag && !cmd.Flags().Changed("rows") {
 			rows = 4
+		} else if !cmd.Flags().Changed("rows") {
+			rows = config.StatsRows()
 		}
 
 		// Validate rows value
@@ -61,15 +90,33 @@ var statsCmd = &cobra.Command{
 			return fmt.Errorf("rows must be a positive integer")
 		}
 
-		storage, err := utils.NewFileStorage(config.DataFilePath())
+		storage, err := openStorage()
 		if err != nil {
 			return fmt.Errorf("failed to initialize storage: %w", err)
 		}
 
-		entries, err := storage.Load()
+		// Only the entries of the rows shown are loaded, with a day to spare on either side;
+		// the totals pick the entries of each row themselves
+		days := rows
+		if weeklyFlag {
+			days = rows * 7
+		}
+		now := time.Now()
+		entries, err := utils.LoadEntriesBetween(storage, now.AddDate(0, 0, -days-1), now.AddDate(0, 0, 2))
 		if err != nil {
 			return fmt.Errorf("failed to load entries: %w", err)
 		}
+		entries = utils.FilterEntriesByTags(entries, tags)
+		schedule := configuredSchedule()
+
+		var tree *utils.ProjectTree
+		if depth > 0 {
+			projects, err := storage.LoadProjects()
+			if err != nil {
+				return fmt.Errorf("failed to load projects: %w", err)
+			}
+			tree = utils.NewProjectTree(projects)
+		}
 
 		// Time-based stats
 		if weeklyFlag {
@@ -81,11 +128,15 @@ var statsCmd = &cobra.Command{
 			// Collect all projects (excluding empty project name)
 			projectMaps := make([]map[string]time.Duration, len(weeklyTotals))
 			for i, total := range weeklyTotals {
-				projectMaps[i] = total.Projects
+				weeklyTotals[i].Projects = utils.RollUpTotals(total.Projects, tree, depth)
+				projectMaps[i] = weeklyTotals[i].Projects
 			}
 			projects := collectProjects(projectMaps)
 
 			headers := []string{"Week Starting", "Total"}
+			if schedule != nil {
+				headers = append(headers, "Target", "Delta")
+			}
 			headers = append(headers, projects...)
 
 			table := tablewriter.NewWriter(os.Stdout)
@@ -98,6 +149,10 @@ var statsCmd = &cobra.Command{
 					total.WeekStart.Format("2006-01-02"),
 					formatTimeHHMM(total.Total),
 				}
+				if schedule != nil {
+					target := schedule.TargetForDays(total.WeekStart, 7)
+					row = append(row, formatTimeHHMM(target), formatDeltaHHMM(total.Total-target))
+				}
 				for _, p := range projects {
 					dur := total.Projects[p]
 					if dur == 0 {
@@ -118,11 +173,15 @@ var statsCmd = &cobra.Command{
 			// Collect all projects (excluding empty project name)
 			projectMaps := make([]map[string]time.Duration, len(dailyTotals))
 			for i, total := range dailyTotals {
-				projectMaps[i] = total.Projects
+				dailyTotals[i].Projects = utils.RollUpTotals(total.Projects, tree, depth)
+				projectMaps[i] = dailyTotals[i].Projects
 			}
 			projects := collectProjects(projectMaps)
 
 			headers := []string{"Date", "Total"}
+			if schedule != nil {
+				headers = append(headers, "Target", "Delta")
+			}
 			headers = append(headers, projects...)
 
 			table := tablewriter.NewWriter(os.Stdout)
@@ -135,6 +194,10 @@ var statsCmd = &cobra.Command{
 					total.Date.Format("2006-01-02"),
 					formatTimeHHMM(total.Total),
 				}
+				if schedule != nil {
+					target := schedule.Target(total.Date)
+					row = append(row, formatTimeHHMM(target), formatDeltaHHMM(total.Total-target))
+				}
 				for _, p := range projects {
 					dur := total.Projects[p]
 					if dur == 0 {
@@ -154,7 +217,9 @@ var statsCmd = &cobra.Command{
 
 func init() {
 	statsCmd.Flags().BoolP("weekly", "w", false, "Show weekly totals")
-	statsCmd.Flags().IntP("rows", "r", 14, "Number of rows to display (days for daily, weeks for weekly)")
+	statsCmd.Flags().IntP("rows", "r", 14, "Number of rows to display (days for daily, weeks for weekly; default days from the stats-rows setting)")
+	statsCmd.Flags().Int("depth", 0, "Roll sub-projects up to their ancestor project at this depth (0 keeps them separate)")
+	statsCmd.Flags().StringSliceP("tag", "t", nil, "Only count entries carrying this tag (repeatable; all given tags must match)")
 
 	rootCmd.AddCommand(statsCmd)
 }
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"time-tracker/config"
+	"time-tracker/utils"
+)
+
+// conflictRetries is how often a CLI mutation is attempted when another process saves in between
+const conflictRetries = 3
+
+// openStorage opens the storage backend selected by the configuration
+func openStorage() (utils.Storage, error) {
+	options, err := storageOptions()
+	if err != nil {
+		return nil, err
+	}
+	return utils.OpenStorage(config.StorageBackend(), options)
+}
+
+// newTaskManager creates a task manager that records its mutations for undo/redo
+func newTaskManager(storage utils.Storage) *utils.TaskManager {
+	taskManager := utils.NewTaskManager(storage)
+	taskManager.SetHistory(utils.NewFileHistoryStore(config.HistoryFilePath()))
+	return taskManager
+}
+
+func storageOptions() (utils.StorageOptions, error) {
+	maxBackups, err := config.MaxBackups()
+	if err != nil {
+		return utils.StorageOptions{}, err
+	}
+
+	return utils.StorageOptions{
+		DataFile:     config.DataFilePath(),
+		DatabaseFile: config.DatabaseFilePath(),
+		JournalFile:  config.JournalFilePath(),
+		MaxBackups:   maxBackups,
+	}, nil
+}
+
+// retryOnConflict runs fn again with freshly loaded data when it fails with a storage conflict
+func retryOnConflict(fn func() error) error {
+	var err error
+	for attempt := 0; attempt < conflictRetries; attempt++ {
+		err = fn()
+		if !utils.IsConflict(err) {
+			return err
+		}
+	}
+	return err
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"time-tracker/utils"
+)
+
+func TestRetryOnConflict_RetriesConflictsOnly(t *testing.T) {
+	attempts := 0
+	err := retryOnConflict(func() error {
+		attempts++
+		if attempts < 2 {
+			return fmt.Errorf("failed to save: %w", &utils.ConflictError{FilePath: "data.json"})
+		}
+		return nil
+	})
+	if err != nil || attempts != 2 {
+		t.Fatalf("expected success on second attempt, got err=%v attempts=%d", err, attempts)
+	}
+
+	attempts = 0
+	err = retryOnConflict(func() error {
+		attempts++
+		return errors.New("boom")
+	})
+	if err == nil || attempts != 1 {
+		t.Fatalf("expected non-conflict error without retry, got err=%v attempts=%d", err, attempts)
+	}
+
+	attempts = 0
+	err = retryOnConflict(func() error {
+		attempts++
+		return &utils.ConflictError{FilePath: "data.json"}
+	})
+	if !utils.IsConflict(err) || attempts != conflictRetries {
+		t.Fatalf("expected conflict after %d attempts, got err=%v attempts=%d", conflictRetries, err, attempts)
+	}
+}
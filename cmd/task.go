@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+type taskListManager interface {
+	ResolveProject(input string) (string, error)
+	ListTasks() ([]models.Task, error)
+}
+
+type taskAddManager interface {
+	ResolveProject(input string) (string, error)
+	AddTask(project, name string) (*models.Task, error)
+}
+
+type taskDoneManager interface {
+	CompleteTask(id string) (*models.Task, error)
+}
+
+type taskStartManager interface {
+	StartTaskAt(id string, startTime time.Time) (*models.TimeEntry, error)
+}
+
+var taskCmd = &cobra.Command{
+	Use:   "task",
+	Short: "Manage tasks",
+	Long: `Manage the task list. A task is a named piece of work in a project; entries started with a
+task's project and name are tracked against it, so the list shows the time spent on each task.`,
+}
+
+var taskListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tasks",
+	Long:  "List open tasks with their status and accumulated time. Use --all to include done tasks.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, err := cmd.Flags().GetBool("all")
+		if err != nil {
+			return fmt.Errorf("failed to parse all flag: %w", err)
+		}
+		project, err := cmd.Flags().GetString("project")
+		if err != nil {
+			return fmt.Errorf("failed to parse project flag: %w", err)
+		}
+
+		storage, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		return listTasks(newTaskManager(storage), project, all, os.Stdout)
+	},
+}
+
+var taskAddCmd = &cobra.Command{
+	Use:   "add <project> <name>",
+	Short: "Add a task to a project",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		storage, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		taskManager := newTaskManager(storage)
+		return retryOnConflict(func() error {
+			return addTask(taskManager, args[0], args[1], os.Stdout)
+		})
+	},
+}
+
+var taskDoneCmd = &cobra.Command{
+	Use:   "done <id>",
+	Short: "Mark a task as done",
+	Long:  "Mark a task as done. Its tracked time is kept; starting the task again reopens it.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		storage, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		taskManager := newTaskManager(storage)
+		return retryOnConflict(func() error {
+			return completeTask(taskManager, args[0], os.Stdout)
+		})
+	},
+}
+
+var taskStartCmd = &cobra.Command{
+	Use:   "start <id>",
+	Short: "Start tracking time on a task",
+	Long:  "Start a new entry with the task's project and name, stopping the running entry.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		at, err := cmd.Flags().GetString("at")
+		if err != nil {
+			return fmt.Errorf("failed to parse at flag: %w", err)
+		}
+		ago, err := cmd.Flags().GetString("ago")
+		if err != nil {
+			return fmt.Errorf("failed to parse ago flag: %w", err)
+		}
+		when, err := parseTrackTime(at, ago, time.Now())
+		if err != nil {
+			return err
+		}
+
+		storage, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		taskManager := newTaskManager(storage)
+		return retryOnConflict(func() error {
+			return startTask(taskManager, args[0], when, os.Stdout)
+		})
+	},
+}
+
+func listTasks(taskManager taskListManager, project string, all bool, out io.Writer) error {
+	tasks, err := taskManager.ListTasks()
+	if err != nil {
+		return fmt.Errorf("failed to load tasks: %w", err)
+	}
+
+	project, err = taskManager.ResolveProject(strings.TrimSpace(project))
+	if err != nil {
+		return fmt.Errorf("failed to resolve project: %w", err)
+	}
+	var shown []models.Task
+	for _, task := range tasks {
+		if task.IsCompleted() && !all {
+			continue
+		}
+		if project != "" && !strings.EqualFold(task.Project, project) {
+			continue
+		}
+		shown = append(shown, task)
+	}
+
+	if len(shown) == 0 {
+		fmt.Fprintln(out, "No tasks found")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(out)
+	table.SetHeader([]string{"ID", "Project", "Task", "Status", "Time"})
+	table.SetAutoFormatHeaders(false)
+	table.SetBorder(true)
+	table.SetRowLine(true)
+	table.SetAutoWrapText(false)
+
+	for _, task := range shown {
+		table.Append([]string{task.ID, task.Project, task.Name, utils.FormatTaskStatus(task.Status), utils.FormatDuration(task.AccumulatedTime)})
+	}
+
+	table.Render()
+	return nil
+}
+
+func addTask(taskManager taskAddManager, project, name string, out io.Writer) error {
+	project, err := taskManager.ResolveProject(project)
+	if err != nil {
+		return fmt.Errorf("failed to resolve project: %w", err)
+	}
+
+	task, err := taskManager.AddTask(project, name)
+	if err != nil {
+		return fmt.Errorf("failed to add task: %w", err)
+	}
+
+	fmt.Fprintf(out, "Added task %s %q to project %q\n", task.ID, task.Name, task.Project)
+	return nil
+}
+
+func completeTask(taskManager taskDoneManager, id string, out io.Writer) error {
+	task, err := taskManager.CompleteTask(id)
+	if err != nil {
+		return fmt.Errorf("failed to complete task: %w", err)
+	}
+
+	fmt.Fprintf(out, "Completed task %s %q in project %q (%s tracked)\n", task.ID, task.Name, task.Project, utils.FormatDuration(task.AccumulatedTime))
+	return nil
+}
+
+func startTask(taskManager taskStartManager, id string, when time.Time, out io.Writer) error {
+	entry, err := taskManager.StartTaskAt(id, when)
+	if err != nil {
+		return fmt.Errorf("failed to start task: %w", err)
+	}
+
+	fmt.Fprintf(out, "Started tracking time for \"%s\" in project \"%s\"\n", entry.Title, entry.Project)
+	return nil
+}
+
+func init() {
+	taskListCmd.Flags().BoolP("all", "a", false, "include done tasks")
+	taskListCmd.Flags().StringP("project", "p", "", "only list tasks of this project")
+	taskStartCmd.Flags().String("at", "", `start at this time instead of now: "09:15", "yesterday 17:30", "2025-03-10 09:15"`)
+	taskStartCmd.Flags().String("ago", "", `start this long ago instead of now, e.g. "10m" or "1h30m"`)
+
+	taskCmd.AddCommand(taskListCmd)
+	taskCmd.AddCommand(taskAddCmd)
+	taskCmd.AddCommand(taskDoneCmd)
+	taskCmd.AddCommand(taskStartCmd)
+	rootCmd.AddCommand(taskCmd)
+}
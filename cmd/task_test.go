@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+func newTaskCommandManager(t *testing.T) (*utils.TaskManager, *utils.MemoryStorage) {
+	t.Helper()
+
+	storage := utils.NewMemoryStorage()
+	if err := storage.SaveProjects([]models.Project{{Name: "Acme"}, {Name: "Beta"}}); err != nil {
+		t.Fatalf("failed to seed projects: %v", err)
+	}
+	return utils.NewTaskManager(storage), storage
+}
+
+func TestAddAndListTasks(t *testing.T) {
+	tm, _ := newTaskCommandManager(t)
+
+	var out bytes.Buffer
+	if err := addTask(tm, "acme", "Build", &out); err != nil {
+		t.Fatalf("addTask returned error: %v", err)
+	}
+	if err := addTask(tm, "Beta", "Review", &out); err != nil {
+		t.Fatalf("addTask returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), `Added task 1 "Build" to project "Acme"`) {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+
+	out.Reset()
+	if err := listTasks(tm, "acme", false, &out); err != nil {
+		t.Fatalf("listTasks returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Build") || !strings.Contains(out.String(), "not started") || strings.Contains(out.String(), "Review") {
+		t.Fatalf("expected only the Acme task, got %q", out.String())
+	}
+}
+
+func TestStartAndCompleteTask(t *testing.T) {
+	tm, storage := newTaskCommandManager(t)
+	if _, err := tm.AddTask("Acme", "Build"); err != nil {
+		t.Fatalf("AddTask returned error: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := startTask(tm, "1", time.Now().Add(-90*time.Minute), &out); err != nil {
+		t.Fatalf("startTask returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), `Started tracking time for "Build" in project "Acme"`) {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+
+	out.Reset()
+	if err := listTasks(tm, "", false, &out); err != nil {
+		t.Fatalf("listTasks returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), "active") || !strings.Contains(out.String(), "1h 30m") {
+		t.Fatalf("expected an active task with 1h 30m, got %q", out.String())
+	}
+
+	out.Reset()
+	if err := completeTask(tm, "1", &out); err != nil {
+		t.Fatalf("completeTask returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), `Completed task 1 "Build" in project "Acme" (1h 30m tracked)`) {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+
+	out.Reset()
+	if err := listTasks(tm, "", false, &out); err != nil {
+		t.Fatalf("listTasks returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), "No tasks found") {
+		t.Fatalf("expected done tasks to be hidden, got %q", out.String())
+	}
+	out.Reset()
+	if err := listTasks(tm, "", true, &out); err != nil {
+		t.Fatalf("listTasks returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), "done") {
+		t.Fatalf("expected the done task with --all, got %q", out.String())
+	}
+
+	entries, _ := storage.Load()
+	if len(entries) != 1 || entries[0].Task != "1" {
+		t.Fatalf("expected one entry for task 1, got %+v", entries)
+	}
+}
+
+func TestCompleteTask_Unknown(t *testing.T) {
+	tm, _ := newTaskCommandManager(t)
+
+	var out bytes.Buffer
+	if err := completeTask(tm, "7", &out); err == nil || !strings.Contains(err.Error(), "task 7 not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
@@ -2,16 +2,31 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"time-tracker/config"
+	"time-tracker/models"
 	"time-tracker/utils"
 )
 
 var trackCmd = &cobra.Command{
-	Use:     "s [project title]",
-	Short:   "Start (or stop) time tracking",
-	Long:    `Start a new time entry with project and title, or stop current entry. Can be called as 'start', 'stop', or 's'.`,
+	Use:   "s [project title]",
+	Short: "Start (or stop) time tracking",
+	Long: `Start a new time entry with project and title, or stop current entry. Can be called as 'start', 'stop', or 's'.
+
+Use --at or --ago to start or stop in the past, e.g. --at 09:15, --at "yesterday 17:30", or --ago 10m.
+
+The project can be given by its name in any case, its code, or one of its aliases, e.g.
+"time-tracker s ABC-12572 Planning".
+
+Use --for to timebox the new entry, e.g. --for 25m: it is stopped once it has run that long, by
+the TUI or the next time any command runs.
+
+When the running entry has been running for longer than the forgotten-after setting, a warning
+is printed and, in a terminal, you are asked when it really ended before stopping or starting.`,
 	Aliases: []string{"start", "stop"},
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) != 0 && len(args) != 2 {
@@ -22,11 +37,36 @@ var trackCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		calledAs := cmd.CalledAs()
 
-		storage, err := utils.NewFileStorage(config.DataFilePath())
+		at, err := cmd.Flags().GetString("at")
+		if err != nil {
+			return fmt.Errorf("failed to parse at flag: %w", err)
+		}
+		ago, err := cmd.Flags().GetString("ago")
+		if err != nil {
+			return fmt.Errorf("failed to parse ago flag: %w", err)
+		}
+		when, err := parseTrackTime(at, ago, time.Now())
+		if err != nil {
+			return err
+		}
+
+		storage, err := openStorage()
 		if err != nil {
 			return fmt.Errorf("failed to initialize storage: %w", err)
 		}
-		taskManager := utils.NewTaskManager(storage)
+		taskManager := newTaskManager(storage)
+
+		// A forgotten running entry is only checked when acting now; --at and --ago are explicit
+		endForgotten := func() (bool, error) {
+			if at != "" || ago != "" {
+				return false, nil
+			}
+			entries, err := taskManager.ListEntries()
+			if err != nil {
+				return false, fmt.Errorf("failed to load entries: %w", err)
+			}
+			return checkForgottenEntry(taskManager, newActivityStore(), entries, config.ForgottenAfter(), when, interactiveInput(), os.Stderr)
+		}
 
 		// Determine if this is a start or stop operation
 		isStop := calledAs == "stop" || (calledAs == "s" && len(args) == 0)
@@ -37,8 +77,22 @@ var trackCmd = &cobra.Command{
 			if len(args) > 0 {
 				return fmt.Errorf("'stop' command does not accept arguments. Use 'start' to begin tracking")
 			}
+			if cmd.Flags().Changed("tag") {
+				return fmt.Errorf("'stop' command does not accept --tag")
+			}
+			if cmd.Flags().Changed("for") {
+				return fmt.Errorf("'stop' command does not accept --for")
+			}
+			if ended, err := endForgotten(); err != nil || ended {
+				return err
+			}
 
-			entry, err := taskManager.StopEntry()
+			var entry *models.TimeEntry
+			err := retryOnConflict(func() error {
+				var err error
+				entry, err = taskManager.StopEntryAt(when)
+				return err
+			})
 			if err != nil {
 				return fmt.Errorf("failed to stop time entry: %w", err)
 			}
@@ -51,7 +105,7 @@ var trackCmd = &cobra.Command{
 				durationStr = fmt.Sprintf("%dm", minutes)
 			}
 
-			fmt.Printf("Stopped tracking time for \"%s\" in project \"%s\" (duration: %s)\n", entry.Title, entry.Project, durationStr)
+			fmt.Printf("Stopped tracking time for \"%s\" in project \"%s\"%s (duration: %s)\n", entry.Title, entry.Project, backdatedSuffix(at, ago, when), durationStr)
 			return nil
 
 		} else if isStart {
@@ -60,15 +114,43 @@ var trackCmd = &cobra.Command{
 				return fmt.Errorf("'start' requires project and title arguments")
 			}
 
-			project := args[0]
+			// The project may be given by its code or an alias
+			project, err := taskManager.ResolveProject(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to resolve project: %w", err)
+			}
 			title := args[1]
 
-			entry, err := taskManager.StartEntry(project, title)
+			tags, err := cmd.Flags().GetStringSlice("tag")
+			if err != nil {
+				return fmt.Errorf("failed to parse tag flag: %w", err)
+			}
+			length, err := cmd.Flags().GetDuration("for")
+			if err != nil {
+				return fmt.Errorf("failed to parse for flag: %w", err)
+			}
+			if cmd.Flags().Changed("for") && length <= 0 {
+				return fmt.Errorf("--for must be a positive duration, e.g. 25m")
+			}
+			if _, err := endForgotten(); err != nil {
+				return err
+			}
+
+			if length > 0 {
+				return startTimeboxedEntry(taskManager, newTimeboxStore(), project, title, tags, length, when, time.Now(), backdatedSuffix(at, ago, when), os.Stdout)
+			}
+
+			var entry *models.TimeEntry
+			err = retryOnConflict(func() error {
+				var err error
+				entry, err = taskManager.StartEntryAt(project, title, when, tags...)
+				return err
+			})
 			if err != nil {
 				return fmt.Errorf("failed to start time entry: %w", err)
 			}
 
-			fmt.Printf("Started tracking time for \"%s\" in project \"%s\"\n", entry.Title, entry.Project)
+			fmt.Printf("Started tracking time for \"%s\" in project \"%s\"%s\n", entry.Title, entry.Project, backdatedSuffix(at, ago, when))
 			return nil
 
 		} else {
@@ -77,6 +159,64 @@ var trackCmd = &cobra.Command{
 	},
 }
 
+// parseTrackTime returns when to start or stop: now, or the time given by --at or --ago
+func parseTrackTime(at, ago string, now time.Time) (time.Time, error) {
+	if at != "" && ago != "" {
+		return time.Time{}, fmt.Errorf("--at and --ago cannot be used together")
+	}
+
+	when := now
+	var err error
+	switch {
+	case at != "":
+		when, err = utils.ParseTimeExpression(at, now)
+	case ago != "":
+		when, err = utils.ParseAgo(ago, now)
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if when.After(now) {
+		return time.Time{}, fmt.Errorf("time %s is in the future", utils.FormatDateTime(when))
+	}
+	return when, nil
+}
+
+type timeboxStarter interface {
+	timeboxExpirer
+	StartTimebox(store utils.TimeboxStore, project, title string, startTime time.Time, length time.Duration, tags ...string) (*models.TimeEntry, *utils.Timebox, error)
+}
+
+// startTimeboxedEntry starts an entry that is stopped once it has run for length
+func startTimeboxedEntry(taskManager timeboxStarter, store utils.TimeboxStore, project, title string, tags []string, length time.Duration, when, now time.Time, suffix string, out io.Writer) error {
+	var entry *models.TimeEntry
+	var box *utils.Timebox
+	err := retryOnConflict(func() error {
+		var err error
+		entry, box, err = taskManager.StartTimebox(store, project, title, when, length, tags...)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start time entry: %w", err)
+	}
+
+	fmt.Fprintf(out, "Started tracking time for \"%s\" in project \"%s\"%s until %s\n", entry.Title, entry.Project, suffix, utils.FormatDateTime(box.Until))
+	return applyExpiredTimebox(taskManager, store, now, out)
+}
+
+// backdatedSuffix mentions the time in the confirmation when it was given with --at or --ago
+func backdatedSuffix(at, ago string, when time.Time) string {
+	if at == "" && ago == "" {
+		return ""
+	}
+	return " at " + utils.FormatDateTime(when)
+}
+
 func init() {
+	trackCmd.Flags().String("at", "", `start or stop at this time instead of now: "09:15", "yesterday 17:30", "2025-03-10 09:15"`)
+	trackCmd.Flags().String("ago", "", `start or stop this long ago instead of now, e.g. "10m" or "1h30m"`)
+	trackCmd.Flags().Duration("for", 0, `stop the new entry once it has run this long, e.g. "25m" or "1h30m"`)
+	trackCmd.Flags().StringSliceP("tag", "t", nil, "tag to attach to the new entry (repeatable or comma-separated)")
 	rootCmd.AddCommand(trackCmd)
 }
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTrackTime(t *testing.T) {
+	now := time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)
+
+	when, err := parseTrackTime("", "", now)
+	if err != nil || !when.Equal(now) {
+		t.Fatalf("expected now, got %v (%v)", when, err)
+	}
+
+	when, err = parseTrackTime("09:15", "", now)
+	if err != nil || !when.Equal(time.Date(2025, 3, 10, 9, 15, 0, 0, time.UTC)) {
+		t.Fatalf("expected 09:15 today, got %v (%v)", when, err)
+	}
+
+	when, err = parseTrackTime("", "20m", now)
+	if err != nil || !when.Equal(now.Add(-20*time.Minute)) {
+		t.Fatalf("expected 20 minutes ago, got %v (%v)", when, err)
+	}
+}
+
+func TestParseTrackTime_Errors(t *testing.T) {
+	now := time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)
+
+	if _, err := parseTrackTime("09:15", "10m", now); err == nil || !strings.Contains(err.Error(), "cannot be used together") {
+		t.Fatalf("expected conflicting flags error, got %v", err)
+	}
+	if _, err := parseTrackTime("18:00", "", now); err == nil || !strings.Contains(err.Error(), "in the future") {
+		t.Fatalf("expected future time error, got %v", err)
+	}
+	if _, err := parseTrackTime("", "soon", now); err == nil || !strings.Contains(err.Error(), "invalid duration") {
+		t.Fatalf("expected invalid duration error, got %v", err)
+	}
+}
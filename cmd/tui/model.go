@@ -2,7 +2,9 @@ package tui
 
 import (
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -16,6 +18,9 @@ type OperationCompleteMsg struct {
 	Error error
 }
 
+// TimeboxTickMsg refreshes the timebox countdown and applies its expiry
+type TimeboxTickMsg time.Time
+
 // Model wraps the modes.Model and adds TUI-specific state
 type Model struct {
 	*modes.Model
@@ -24,7 +29,7 @@ type Model struct {
 // NewModel creates a new TUI model
 func NewModel(storage models.Storage, taskManager *utils.TaskManager) *Model {
 	// Create textinput models for start mode
-	inputs := make([]textinput.Model, modes.InputMinute+1)
+	inputs := make([]textinput.Model, modes.InputTags+1)
 
 	// Project input
 	inputs[modes.InputProject] = textinput.New()
@@ -83,7 +88,39 @@ func NewModel(storage models.Storage, taskManager *utils.TaskManager) *Model {
 	inputs[modes.InputMinute].PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 	inputs[modes.InputMinute].TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 
-	projectInputs := make([]textinput.Model, 3)
+	// Tags input
+	inputs[modes.InputTags] = textinput.New()
+	inputs[modes.InputTags].Placeholder = "tag1, tag2"
+	inputs[modes.InputTags].CharLimit = 256
+	inputs[modes.InputTags].Width = 40
+	inputs[modes.InputTags].PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	inputs[modes.InputTags].TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	// Notes input (multi-line, edit form only)
+	notesInput := textarea.New()
+	notesInput.Placeholder = "What did you actually do?"
+	notesInput.ShowLineNumbers = false
+	notesInput.CharLimit = 2000
+	notesInput.SetWidth(60)
+	notesInput.SetHeight(4)
+
+	// End time input (add form only)
+	endInput := textinput.New()
+	endInput.Placeholder = "HH:MM"
+	endInput.CharLimit = 16
+	endInput.Width = 16
+	endInput.PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	endInput.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	// Free-text start time input, parsed like --at and --ago
+	atInput := textinput.New()
+	atInput.Placeholder = "e.g. 09:15, yesterday 17:30, 10m ago"
+	atInput.CharLimit = 32
+	atInput.Width = 40
+	atInput.PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	atInput.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	projectInputs := make([]textinput.Model, modes.ProjectInputBillable+1)
 
 	projectInputs[0] = textinput.New()
 	projectInputs[0].Placeholder = "Name"
@@ -106,6 +143,27 @@ func NewModel(storage models.Storage, taskManager *utils.TaskManager) *Model {
 	projectInputs[2].PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 	projectInputs[2].TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 
+	projectInputs[modes.ProjectInputRate] = textinput.New()
+	projectInputs[modes.ProjectInputRate].Placeholder = "Hourly rate"
+	projectInputs[modes.ProjectInputRate].CharLimit = 16
+	projectInputs[modes.ProjectInputRate].Width = 16
+	projectInputs[modes.ProjectInputRate].PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	projectInputs[modes.ProjectInputRate].TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	projectInputs[modes.ProjectInputCurrency] = textinput.New()
+	projectInputs[modes.ProjectInputCurrency].Placeholder = "USD"
+	projectInputs[modes.ProjectInputCurrency].CharLimit = 8
+	projectInputs[modes.ProjectInputCurrency].Width = 8
+	projectInputs[modes.ProjectInputCurrency].PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	projectInputs[modes.ProjectInputCurrency].TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	projectInputs[modes.ProjectInputBillable] = textinput.New()
+	projectInputs[modes.ProjectInputBillable].Placeholder = "no"
+	projectInputs[modes.ProjectInputBillable].CharLimit = 5
+	projectInputs[modes.ProjectInputBillable].Width = 5
+	projectInputs[modes.ProjectInputBillable].PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	projectInputs[modes.ProjectInputBillable].TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
 	modesModel := &modes.Model{
 		Storage:            storage,
 		TaskManager:        taskManager,
@@ -114,6 +172,9 @@ func NewModel(storage models.Storage, taskManager *utils.TaskManager) *Model {
 		SelectedIdx:        0,
 		Inputs:             inputs,
 		FocusIndex:         modes.InputProject,
+		NotesInput:         &notesInput,
+		EndInput:           &endInput,
+		AtInput:            &atInput,
 		ProjectInputs:      projectInputs,
 		ProjectFocusIndex:  0,
 		Loading:            false,
@@ -142,12 +203,16 @@ func NewModel(storage models.Storage, taskManager *utils.TaskManager) *Model {
 	modesModel.HelpMode = modes.HelpMode
 	modesModel.StatsMode = modes.StatsMode
 	modesModel.ProjectsMode = modes.ProjectsMode
+	modesModel.TasksMode = modes.TasksMode
 	modesModel.NewMode = modes.NewMode
 	modesModel.EditMode = modes.EditMode
 	modesModel.ResumeMode = modes.ResumeMode
+	modesModel.AddMode = modes.AddMode
 	modesModel.ConfirmMode = modes.ConfirmMode
 	modesModel.ProjectNewMode = modes.ProjectNewMode
 	modesModel.ProjectEditMode = modes.ProjectEditMode
+	modesModel.ForgottenMode = modes.ForgottenMode
+	modesModel.SplitMode = modes.SplitMode
 	modesModel.CurrentMode = modesModel.ListMode
 
 	return &Model{Model: modesModel}
@@ -155,7 +220,18 @@ func NewModel(storage models.Storage, taskManager *utils.TaskManager) *Model {
 
 // Init initializes the model
 func (m *Model) Init() tea.Cmd {
-	return nil
+	if m.Timeboxes == nil {
+		return nil
+	}
+	m.CheckTimebox(time.Now())
+	return timeboxTick()
+}
+
+// timeboxTick schedules the next countdown refresh
+func timeboxTick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return TimeboxTickMsg(t)
+	})
 }
 
 // Update handles messages and updates the model
@@ -172,7 +248,16 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case TimeboxTickMsg:
+		// The data is only reloaded once the countdown runs out, the banner reads the clock itself
+		if m.TimeboxExpired(time.Time(msg)) {
+			m.CheckTimebox(time.Time(msg))
+		}
+		return m, timeboxTick()
+
 	case tea.KeyMsg:
+		m.RecordActivity(time.Now())
+
 		// Delegate to current mode's key handler
 		if m.CurrentMode.HandleKeyMsg != nil {
 			model, cmd := m.CurrentMode.HandleKeyMsg(m.Model, msg)
@@ -272,12 +357,22 @@ func (m *Model) renderStatusBar() string {
 	// Build left side of status bar
 	leftSide := strings.Join(parts, "")
 
-	// Add status message on the right side if present
-	if m.Status != "" {
+	// Add status message and progress towards the target hours on the right side if present
+	progress := m.Progress
+	if m.Status != "" || progress != "" {
 		statusStyle := lipgloss.NewStyle().
 			Foreground(magenta).
 			Padding(0, 1)
-		rightSide := statusStyle.Render(m.Status)
+		progressStyle := lipgloss.NewStyle().
+			Foreground(gray).
+			Padding(0, 1)
+		rightSide := ""
+		if m.Status != "" {
+			rightSide = statusStyle.Render(m.Status)
+		}
+		if progress != "" {
+			rightSide += progressStyle.Render(progress)
+		}
 
 		// Calculate padding to right-align status
 		leftWidth := lipgloss.Width(leftSide)
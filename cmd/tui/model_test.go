@@ -8,6 +8,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"time-tracker/cmd/tui/modes"
+	"time-tracker/models"
 	"time-tracker/utils"
 )
 
@@ -63,8 +64,8 @@ func TestModelInitialization(t *testing.T) {
 func TestModelInitializationCreatesDateInputs(t *testing.T) {
 	m := newTestModel()
 
-	if len(m.Inputs) != modes.InputMinute+1 {
-		t.Fatalf("len(Inputs) = %d, expected %d", len(m.Inputs), modes.InputMinute+1)
+	if len(m.Inputs) != modes.InputTags+1 {
+		t.Fatalf("len(Inputs) = %d, expected %d", len(m.Inputs), modes.InputTags+1)
 	}
 
 	if got := m.Inputs[modes.InputYear].Placeholder; got != "YYYY" {
@@ -367,6 +368,169 @@ func TestProjectsModeDeleteProject(t *testing.T) {
 	}
 }
 
+func TestProjectsModeTogglesArchivedProject(t *testing.T) {
+	m := newTestModel()
+
+	if _, err := m.TaskManager.AddProject("API Updates", "12573", "Backend"); err != nil {
+		t.Fatalf("Failed to add project: %v", err)
+	}
+
+	if err := m.LoadEntries(); err != nil {
+		t.Fatalf("Failed to load data: %v", err)
+	}
+
+	m.CurrentMode = m.ProjectsMode
+	m.Width = 80
+	m.Height = 20
+
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}}
+	updated, _ := m.Update(msg)
+	m = updated.(*Model)
+
+	projects, err := m.Storage.LoadProjects()
+	if err != nil {
+		t.Fatalf("Failed to load projects: %v", err)
+	}
+	if len(projects) != 1 || !projects[0].Archived {
+		t.Fatalf("Expected the project to be archived, got %+v", projects)
+	}
+	if m.Status != "Project archived" {
+		t.Fatalf("Expected archive status, got %q", m.Status)
+	}
+	if view := m.View(); !strings.Contains(view, "Archived") || !strings.Contains(view, "yes") {
+		t.Fatalf("Expected the archived project to stay listed with its marker, got:\n%s", view)
+	}
+
+	updated, _ = m.Update(msg)
+	m = updated.(*Model)
+	if projects, _ := m.Storage.LoadProjects(); projects[0].Archived || m.Status != "Project unarchived" {
+		t.Fatalf("Expected the project to be unarchived, got %+v (%q)", projects, m.Status)
+	}
+}
+
+func TestProjectsViewShowsBudgetAndStartingWarnsWhenOverBudget(t *testing.T) {
+	m := newTestModel()
+
+	if _, err := m.TaskManager.AddProject("Acme", "ACM", "Client"); err != nil {
+		t.Fatalf("Failed to add project: %v", err)
+	}
+	if _, err := m.TaskManager.SetProjectBudget("Acme", 1, nil, nil); err != nil {
+		t.Fatalf("Failed to set budget: %v", err)
+	}
+	start := time.Now().Add(-5 * time.Hour)
+	if _, err := m.TaskManager.InsertEntry("Acme", "Build", start, start.Add(2*time.Hour)); err != nil {
+		t.Fatalf("Failed to insert entry: %v", err)
+	}
+	if _, err := m.TaskManager.AddTask("Acme", "Review"); err != nil {
+		t.Fatalf("Failed to add task: %v", err)
+	}
+	if err := m.LoadEntries(); err != nil {
+		t.Fatalf("Failed to load data: %v", err)
+	}
+
+	m.CurrentMode = m.ProjectsMode
+	m.Width = 100
+	m.Height = 20
+	if view := m.View(); !strings.Contains(view, "Budget") || !strings.Contains(view, "[██████████] 200%") {
+		t.Fatalf("Expected an overrun budget bar, got:\n%s", view)
+	}
+
+	m.SwitchMode(m.TasksMode)
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(*Model)
+
+	if !strings.Contains(m.Status, "Started Acme: Review") || !strings.Contains(m.Status, "warning: Acme is over budget (2h of 1h)") {
+		t.Fatalf("Expected an over-budget warning, got %q", m.Status)
+	}
+}
+
+func TestProjectsModeTogglesDetailPane(t *testing.T) {
+	m := newTestModel()
+
+	if _, err := m.TaskManager.AddProject("Acme", "ACM", "Client"); err != nil {
+		t.Fatalf("Failed to add project: %v", err)
+	}
+	start := time.Now().Add(-5 * time.Hour)
+	if _, err := m.TaskManager.InsertEntry("Acme", "Review: checkout", start, start.Add(2*time.Hour)); err != nil {
+		t.Fatalf("Failed to insert entry: %v", err)
+	}
+	if err := m.LoadEntries(); err != nil {
+		t.Fatalf("Failed to load data: %v", err)
+	}
+
+	m.CurrentMode = m.ProjectsMode
+	m.Width = 100
+	m.Height = 20
+	if view := m.View(); strings.Contains(view, "Top titles") {
+		t.Fatalf("Expected the detail pane to start hidden, got:\n%s", view)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(*Model)
+
+	view := m.View()
+	for _, want := range []string{"Code: ACM · Category: Client", "Tracked 2h in 1 entry", "Categories: Review 2h", "Top titles: Review: checkout 2h", "Last 8 weeks: "} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("Expected the detail pane to contain %q, got:\n%s", want, view)
+		}
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(*Model)
+	if m.ProjectDetail || m.Status != "Project details hidden" {
+		t.Fatalf("Expected the detail pane hidden again, got %v (%q)", m.ProjectDetail, m.Status)
+	}
+}
+
+func TestTasksModeStartAndCompleteTask(t *testing.T) {
+	m := newTestModel()
+
+	if _, err := m.TaskManager.AddProject("API Updates", "12573", "Backend"); err != nil {
+		t.Fatalf("Failed to add project: %v", err)
+	}
+	for _, name := range []string{"Auth", "Billing"} {
+		if _, err := m.TaskManager.AddTask("API Updates", name); err != nil {
+			t.Fatalf("Failed to add task: %v", err)
+		}
+	}
+	if err := m.LoadEntries(); err != nil {
+		t.Fatalf("Failed to load data: %v", err)
+	}
+
+	m.SwitchMode(m.TasksMode)
+	view := m.View()
+	if !strings.Contains(view, "Auth") || !strings.Contains(view, "not started") {
+		t.Fatalf("Expected tasks in view, got %q", view)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = updated.(*Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(*Model)
+
+	if m.Status != "Started API Updates: Billing" {
+		t.Fatalf("Expected start status, got %q", m.Status)
+	}
+	entries, _ := m.Storage.Load()
+	if len(entries) != 1 || entries[0].Title != "Billing" || entries[0].Task != "2" {
+		t.Fatalf("Expected a running entry for task 2, got %+v", entries)
+	}
+	if m.Tasks[1].Status != models.StatusActive {
+		t.Fatalf("Expected task 2 to be active, got %+v", m.Tasks[1])
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m = updated.(*Model)
+
+	if m.Status != "Task done" {
+		t.Fatalf("Expected done status, got %q", m.Status)
+	}
+	tasks := m.Tasks
+	if !tasks[1].IsCompleted() || tasks[0].IsCompleted() {
+		t.Fatalf("Expected only task 2 to be done, got %+v", tasks)
+	}
+}
+
 func TestProjectsModeDeleteProjectBlockedWhenInUse(t *testing.T) {
 	m := newTestModel()
 
@@ -504,6 +668,117 @@ func TestEditShortcut(t *testing.T) {
 	}
 }
 
+// TestSplitShortcutSplitsEntryAndEditsSecondHalf verifies x splits the entry at the typed time
+func TestSplitShortcutSplitsEntryAndEditsSecondHalf(t *testing.T) {
+	m := newTestModel()
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.Local)
+	if _, err := m.TaskManager.StartEntryAt("test-project", "test-task", start); err != nil {
+		t.Fatalf("Failed to start entry: %v", err)
+	}
+	if err := m.LoadEntries(); err != nil {
+		t.Fatalf("Failed to load entries: %v", err)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	model := updated.(*Model)
+	if model.CurrentMode != model.SplitMode {
+		t.Fatalf("Expected split mode after 'x', got %s", model.CurrentMode.Name)
+	}
+	if model.SplitState.Draft != "2025-01-01 " {
+		t.Fatalf("Expected the draft to be prefilled with the entry's date, got %q", model.SplitState.Draft)
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("11:30")})
+	model = updated.(*Model)
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(*Model)
+
+	if model.CurrentMode != model.EditMode || model.FormState.EditingIdx != 1 {
+		t.Fatalf("Expected to edit the second half, got mode %s editing %d (status %q)", model.CurrentMode.Name, model.FormState.EditingIdx, model.Status)
+	}
+	if len(model.Entries) != 2 || !model.Entries[1].Start.Equal(start.Add(90*time.Minute)) {
+		t.Fatalf("Expected the entry to be split at 11:30, got %+v", model.Entries)
+	}
+}
+
+// TestAddShortcutInsertsPastEntry verifies a opens the add form and inserts a closed entry
+func TestAddShortcutInsertsPastEntry(t *testing.T) {
+	m := newTestModel()
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.Local)
+	if _, err := m.TaskManager.StartEntryAt("test-project", "test-task", start); err != nil {
+		t.Fatalf("Failed to start entry: %v", err)
+	}
+	if err := m.LoadEntries(); err != nil {
+		t.Fatalf("Failed to load entries: %v", err)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	model := updated.(*Model)
+	if model.CurrentMode != model.AddMode {
+		t.Fatalf("Expected add mode after 'a', got %s", model.CurrentMode.Name)
+	}
+
+	// Tab walks past the tags and the start time to the end time field
+	for i := 0; i <= len(model.Inputs); i++ {
+		updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyTab})
+		model = updated.(*Model)
+	}
+	if model.FocusIndex != len(model.Inputs)+1 || !model.EndInput.Focused() {
+		t.Fatalf("Expected the end field to be focused, got focus index %d", model.FocusIndex)
+	}
+
+	model.Inputs[modes.InputProject].SetValue("other-project")
+	model.Inputs[modes.InputTitle].SetValue("call")
+	model.Inputs[modes.InputYear].SetValue("2025")
+	model.Inputs[modes.InputMonth].SetValue("01")
+	model.Inputs[modes.InputDay].SetValue("01")
+	model.Inputs[modes.InputHour].SetValue("11")
+	model.Inputs[modes.InputMinute].SetValue("00")
+	model.EndInput.SetValue("")
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("11:30")})
+	model = updated.(*Model)
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(*Model)
+
+	if model.CurrentMode != model.ListMode {
+		t.Fatalf("Expected list mode after adding, got %s (status %q)", model.CurrentMode.Name, model.Status)
+	}
+	entries, err := model.Storage.Load()
+	if err != nil {
+		t.Fatalf("Failed to load entries: %v", err)
+	}
+	if len(entries) != 3 || entries[1].Project != "other-project" || !entries[2].Start.Equal(start.Add(90*time.Minute)) || !entries[2].IsRunning() {
+		t.Fatalf("Expected the entry to be inserted into the running one, got %+v", entries)
+	}
+}
+
+// TestCompactShortcutMergesRepeatedEntries verifies c compacts the entries
+func TestCompactShortcutMergesRepeatedEntries(t *testing.T) {
+	m := newTestModel()
+	m.CompactThreshold = time.Minute
+	start := mustParseTime("2025-01-01T10:00:00Z")
+	err := m.Storage.Save([]models.TimeEntry{
+		{Start: start, Project: "test-project", Title: "test-task"},
+		{Start: start.Add(time.Hour), Project: "test-project", Title: "test-task"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to save entries: %v", err)
+	}
+	if err := m.LoadEntries(); err != nil {
+		t.Fatalf("Failed to load entries: %v", err)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	model := updated.(*Model)
+
+	if len(model.Entries) != 1 || !model.Entries[0].Start.Equal(start) {
+		t.Fatalf("Expected the repeated entry to be merged, got %+v", model.Entries)
+	}
+	if !strings.Contains(model.Status, "Compacted 2 entries into 1") {
+		t.Errorf("Expected a compact summary, got %q", model.Status)
+	}
+}
+
 // TestDeleteShortcut verifies d opens confirm mode
 func TestDeleteShortcut(t *testing.T) {
 	m := newTestModel()
@@ -552,6 +827,51 @@ func TestStopShortcutOnRunningEntry(t *testing.T) {
 	}
 }
 
+func TestForgottenEntryEndsAtLastActivity(t *testing.T) {
+	m := newTestModel()
+	m.ForgottenAfter = 10 * time.Hour
+	m.Activity = utils.NewMemoryActivityStore()
+	m.Width = 120
+	m.Height = 20
+
+	now := time.Now()
+	if _, err := m.TaskManager.StartEntryAt("Acme", "Build", now.Add(-20*time.Hour)); err != nil {
+		t.Fatalf("Failed to start entry: %v", err)
+	}
+	lastActivity := now.Add(-12 * time.Hour).Truncate(time.Minute)
+	if err := m.Activity.SaveActivity(lastActivity); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.LoadEntries(); err != nil {
+		t.Fatalf("Failed to load entries: %v", err)
+	}
+
+	if !strings.Contains(m.View(), "press f to set when it ended") {
+		t.Fatal("Expected the list to warn about the forgotten entry")
+	}
+
+	// Stopping a forgotten entry asks when it ended instead of booking the whole time
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	model := updated.(*Model)
+	if model.CurrentMode != model.ForgottenMode {
+		t.Fatalf("Expected forgotten mode, got %s", model.CurrentMode.Name)
+	}
+	if model.ForgottenState.Draft != lastActivity.Format("2006-01-02 15:04") {
+		t.Fatalf("Expected the last activity as the suggested end, got %q", model.ForgottenState.Draft)
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(*Model)
+	if model.CurrentMode != model.ListMode {
+		t.Fatalf("Expected list mode after ending the entry, got %s (status %q)", model.CurrentMode.Name, model.Status)
+	}
+
+	entries, _ := model.Storage.Load()
+	if len(entries) != 2 || entries[0].End == nil || !entries[0].End.Equal(lastActivity) || !entries[1].IsBlank() {
+		t.Fatalf("Expected the entry to end at %v followed by a gap, got %+v", lastActivity, entries)
+	}
+}
+
 // TestStopShortcutOnNonRunningEntry verifies s does nothing on non-running entry
 func TestStopShortcutOnNonRunningEntry(t *testing.T) {
 	m := newTestModel()
@@ -631,6 +951,86 @@ func TestStartEntryViaUI(t *testing.T) {
 	}
 }
 
+// TestNewEntryFormStoresTags verifies tags typed into the form are saved on the entry
+func TestNewEntryFormStoresTags(t *testing.T) {
+	m := newTestModel()
+	if err := m.LoadEntries(); err != nil {
+		t.Fatalf("Failed to load entries: %v", err)
+	}
+
+	m.CurrentMode = m.NewMode
+	m.Inputs[modes.InputProject].SetValue("test-project")
+	m.Inputs[modes.InputTitle].SetValue("test-task")
+	now := time.Now()
+	m.Inputs[modes.InputYear].SetValue(fmt.Sprintf("%04d", now.Year()))
+	m.Inputs[modes.InputMonth].SetValue(fmt.Sprintf("%02d", int(now.Month())))
+	m.Inputs[modes.InputDay].SetValue(fmt.Sprintf("%02d", now.Day()))
+	m.Inputs[modes.InputHour].SetValue("00")
+	m.Inputs[modes.InputMinute].SetValue("00")
+	m.Inputs[modes.InputTags].SetValue("meeting, review")
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model := updated.(*Model)
+
+	if len(model.Entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(model.Entries))
+	}
+	tags := model.Entries[0].Tags
+	if len(tags) != 2 || tags[0] != "meeting" || tags[1] != "review" {
+		t.Fatalf("Expected tags [meeting review], got %v", tags)
+	}
+}
+
+// TestEditEntryFormUpdatesNotes verifies the notes field is pre-filled and saved in edit mode
+func TestEditEntryFormUpdatesNotes(t *testing.T) {
+	m := newTestModel()
+	if _, err := m.TaskManager.StartEntry("project1", "task1"); err != nil {
+		t.Fatalf("Failed to start entry: %v", err)
+	}
+	if _, err := m.TaskManager.SetEntryNotes(0, "first line"); err != nil {
+		t.Fatalf("Failed to set notes: %v", err)
+	}
+	if err := m.LoadEntries(); err != nil {
+		t.Fatalf("Failed to load entries: %v", err)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	model := updated.(*Model)
+	if model.CurrentMode != model.EditMode {
+		t.Fatalf("Expected edit mode, got %s", model.CurrentMode.Name)
+	}
+	if model.NotesInput.Value() != "first line" {
+		t.Fatalf("Expected notes to be pre-filled, got %q", model.NotesInput.Value())
+	}
+
+	// Tab through all single-line inputs and the start time to reach the notes field
+	for i := 0; i <= len(model.Inputs); i++ {
+		updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyTab})
+		model = updated.(*Model)
+	}
+	if !model.NotesInput.Focused() {
+		t.Fatal("Expected notes field to be focused after tabbing past all inputs")
+	}
+
+	// Enter inserts a new line while the notes field is focused
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(*Model)
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("second")})
+	model = updated.(*Model)
+	if model.CurrentMode != model.EditMode {
+		t.Fatal("Expected enter in notes field not to submit the form")
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	model = updated.(*Model)
+	if model.CurrentMode != model.ListMode {
+		t.Fatalf("Expected ctrl+s to submit the form, got mode %s", model.CurrentMode.Name)
+	}
+	if model.Entries[0].Notes != "first line\nsecond" {
+		t.Fatalf("Expected notes to be saved, got %q", model.Entries[0].Notes)
+	}
+}
+
 // TestStopEntryViaUI verifies stopping a running entry
 func TestStopEntryViaUI(t *testing.T) {
 	m := newTestModel()
@@ -811,8 +1211,8 @@ func TestInputFieldNavigation(t *testing.T) {
 	}
 }
 
-// TestTabCyclesListStatsProjects verifies tab cycles through primary modes
-func TestTabCyclesListStatsProjects(t *testing.T) {
+// TestTabCyclesListStatsProjectsTasks verifies tab cycles through primary modes
+func TestTabCyclesListStatsProjectsTasks(t *testing.T) {
 	m := newTestModel()
 
 	if m.CurrentMode != m.ListMode {
@@ -837,8 +1237,15 @@ func TestTabCyclesListStatsProjects(t *testing.T) {
 	updated, _ = model.Update(msg)
 	model = updated.(*Model)
 
+	if model.CurrentMode != model.TasksMode {
+		t.Fatalf("Expected tab in projects mode to switch to tasks mode, got %q", model.CurrentMode.Name)
+	}
+
+	updated, _ = model.Update(msg)
+	model = updated.(*Model)
+
 	if model.CurrentMode != model.ListMode {
-		t.Fatalf("Expected tab in projects mode to switch to list mode, got %q", model.CurrentMode.Name)
+		t.Fatalf("Expected tab in tasks mode to switch to list mode, got %q", model.CurrentMode.Name)
 	}
 }
 
@@ -937,3 +1344,106 @@ func TestSearchBarAnchorsAboveStatusBar(t *testing.T) {
 		t.Fatalf("Expected search bar directly above status bar, got line: %q", searchLine)
 	}
 }
+
+func TestTimeboxTickStopsExpiredEntryAndShowsCountdown(t *testing.T) {
+	m := newTestModel()
+	m.Timeboxes = utils.NewMemoryTimeboxStore()
+	m.Width = 120
+	m.Height = 20
+
+	start := time.Now().Add(-time.Hour).Truncate(time.Minute)
+	if _, _, err := m.TaskManager.StartTimebox(m.Timeboxes, "Acme", "Build", start, 2*time.Hour); err != nil {
+		t.Fatalf("StartTimebox failed: %v", err)
+	}
+	if err := m.LoadEntries(); err != nil {
+		t.Fatalf("LoadEntries failed: %v", err)
+	}
+
+	if cmd := m.Init(); cmd == nil {
+		t.Fatal("expected Init to schedule the countdown")
+	}
+	if view := m.View(); !strings.Contains(view, "timebox: 59:") && !strings.Contains(view, "timebox: 1:00:00") {
+		t.Fatalf("expected a countdown above the list, got:\n%s", view)
+	}
+
+	updated, cmd := m.Update(TimeboxTickMsg(start.Add(3 * time.Hour)))
+	m = updated.(*Model)
+	if cmd == nil {
+		t.Fatal("expected the next tick to be scheduled")
+	}
+	if len(m.Entries) != 2 || m.Entries[0].IsRunning() || !m.Entries[1].IsBlank() {
+		t.Fatalf("expected the entry to be stopped when its timebox expired, got %+v", m.Entries)
+	}
+	if !strings.Contains(m.Status, "Timebox of") || m.Timebox != nil {
+		t.Fatalf("expected the expiry in the status and no countdown, got %q (%+v)", m.Status, m.Timebox)
+	}
+}
+
+func TestTimeboxTickReloadsOnlyOnceTheCountdownRunsOut(t *testing.T) {
+	m := newTestModel()
+	m.Timeboxes = utils.NewMemoryTimeboxStore()
+
+	start := time.Now().Add(-time.Hour).Truncate(time.Minute)
+	if _, _, err := m.TaskManager.StartTimebox(m.Timeboxes, "Acme", "Build", start, 2*time.Hour); err != nil {
+		t.Fatalf("StartTimebox failed: %v", err)
+	}
+	if err := m.LoadEntries(); err != nil {
+		t.Fatalf("LoadEntries failed: %v", err)
+	}
+	if m.Timebox == nil {
+		t.Fatal("expected the reload to pick up the countdown")
+	}
+
+	// Changed behind the TUI's back, so a reload would show up in the entries
+	entries := append([]models.TimeEntry(nil), m.Entries...)
+	entries[0].Notes = "changed elsewhere"
+	if err := m.Storage.Save(entries); err != nil {
+		t.Fatalf("failed to change the entries: %v", err)
+	}
+
+	updated, _ := m.Update(TimeboxTickMsg(start.Add(time.Hour + 30*time.Minute)))
+	m = updated.(*Model)
+	if m.Entries[0].Notes != "" || m.Status != "" {
+		t.Fatalf("expected no reload before the deadline, got %+v (%q)", m.Entries, m.Status)
+	}
+
+	updated, _ = m.Update(TimeboxTickMsg(start.Add(3 * time.Hour)))
+	m = updated.(*Model)
+	if m.Entries[0].Notes != "changed elsewhere" || m.Timebox != nil {
+		t.Fatalf("expected a reload once the deadline passed, got %+v (%+v)", m.Entries, m.Timebox)
+	}
+}
+
+func TestStatusBarShowsProgressTowardsTargetHours(t *testing.T) {
+	m := newTestModel()
+	m.Width = 200
+	m.Height = 10
+
+	now := time.Now()
+	hours := map[time.Weekday]time.Duration{now.Weekday(): 8 * time.Hour}
+	m.Schedule = utils.NewSchedule(hours, nil)
+
+	start := now.Add(-4 * time.Hour)
+	if now.YearDay() != start.YearDay() {
+		t.Skip("the entry would start yesterday")
+	}
+	if err := m.Storage.Save([]models.TimeEntry{{Start: start, Project: "Acme", Title: "Build"}}); err != nil {
+		t.Fatalf("failed to seed entries: %v", err)
+	}
+	if err := m.LoadEntries(); err != nil {
+		t.Fatalf("LoadEntries failed: %v", err)
+	}
+
+	bar := m.renderStatusBar()
+	if !strings.Contains(bar, "today [█████░░░░░] 4h/8h") || !strings.Contains(bar, "week 4h/8h") {
+		t.Fatalf("expected the progress towards today's target, got %q", bar)
+	}
+
+	m.Schedule = nil
+	if err := m.LoadEntries(); err != nil {
+		t.Fatalf("LoadEntries failed: %v", err)
+	}
+	if bar := m.renderStatusBar(); strings.Contains(bar, "today") {
+		t.Fatalf("expected no progress without a schedule, got %q", bar)
+	}
+}
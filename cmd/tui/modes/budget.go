@@ -0,0 +1,58 @@
+package modes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+var overBudgetStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+
+// renderBudgetBar renders the used share of the project's budget, or "" without a budget
+func renderBudgetBar(m *Model, project models.Project, now time.Time) (string, bool) {
+	if project.Budget == 0 {
+		return "", false
+	}
+	report := utils.CalculateBudget(project, m.Projects, m.Entries, now)
+	return fmt.Sprintf("[%s] %d%%", progressBar(report.Consumed, report.Budget), report.Percent()), report.OverBudget()
+}
+
+// overBudgetWarning warns when the project or an ancestor has used up its budget
+func overBudgetWarning(m *Model, projectName string, now time.Time) string {
+	if strings.TrimSpace(projectName) == "" {
+		return ""
+	}
+
+	path := utils.NewProjectTree(m.Projects).Path(projectName)
+	for i := len(path) - 1; i >= 0; i-- {
+		project, found := findProject(m.Projects, path[i])
+		if !found || project.Budget == 0 {
+			continue
+		}
+		report := utils.CalculateBudget(project, m.Projects, m.Entries, now)
+		if report.OverBudget() {
+			return fmt.Sprintf("%s is over budget (%s of %s)", project.Name, utils.FormatDuration(report.Consumed), utils.FormatDuration(report.Budget))
+		}
+	}
+	return ""
+}
+
+// warnIfOverBudget appends an over-budget warning for the started project to the status
+func warnIfOverBudget(m *Model, projectName string) {
+	if warning := overBudgetWarning(m, projectName, time.Now()); warning != "" {
+		m.Status += " - warning: " + warning
+	}
+}
+
+func findProject(projects []models.Project, name string) (models.Project, bool) {
+	for _, project := range projects {
+		if strings.EqualFold(project.Name, name) {
+			return project, true
+		}
+	}
+	return models.Project{}, false
+}
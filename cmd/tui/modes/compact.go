@@ -0,0 +1,22 @@
+package modes
+
+import "fmt"
+
+// compactEntries compacts the entries and reports the result in the status bar
+func compactEntries(m *Model) {
+	result, err := m.TaskManager.Compact(m.CompactThreshold)
+	if err != nil {
+		m.setErrorStatus("compacting entries", err)
+		return
+	}
+	if err := m.LoadEntries(); err != nil {
+		m.Err = err
+		return
+	}
+
+	if len(result.Changes) == 0 {
+		m.Status = "Nothing to compact"
+		return
+	}
+	m.Status = fmt.Sprintf("Compacted %d entries into %d (%d merged, %d dropped)", result.Before, result.After, result.Merged(), result.Dropped())
+}
@@ -31,7 +31,7 @@ var ConfirmMode = &Mode{
 		switch msg.String() {
 		case "y", "Y":
 			if err := m.TaskManager.DeleteEntry(m.ConfirmState.DeletingIdx); err != nil {
-				m.Status = "Error deleting entry: " + err.Error()
+				m.setErrorStatus("deleting entry", err)
 			} else {
 				m.Status = "Entry deleted"
 			}
@@ -64,10 +64,10 @@ func renderBlankConfirmDialog(entry *models.TimeEntry) string {
 	var out strings.Builder
 
 	out.WriteString(titleStyle.Render("Remove Gap?") + "\n\n")
-	out.WriteString(labelStyle.Render("Start: ") + valueStyle.Render(entry.Start.Format("2006-01-02 15:04")) + "\n")
+	out.WriteString(labelStyle.Render("Start: ") + valueStyle.Render(utils.FormatDateTime(entry.Start)) + "\n")
 
 	if entry.End != nil {
-		out.WriteString(labelStyle.Render("End: ") + valueStyle.Render(entry.End.Format("2006-01-02 15:04")) + "\n")
+		out.WriteString(labelStyle.Render("End: ") + valueStyle.Render(utils.FormatDateTime(*entry.End)) + "\n")
 	}
 
 	writeConfirmFooter(&out, "This will remove the gap.")
@@ -80,10 +80,10 @@ func renderNonBlankConfirmDialog(entry *models.TimeEntry) string {
 	out.WriteString(titleStyle.Render("Delete Entry?") + "\n\n")
 	out.WriteString(labelStyle.Render("Project: ") + valueStyle.Render(entry.Project) + "\n")
 	out.WriteString(labelStyle.Render("Task: ") + valueStyle.Render(entry.Title) + "\n")
-	out.WriteString(labelStyle.Render("Start: ") + valueStyle.Render(entry.Start.Format("2006-01-02 15:04")) + "\n")
+	out.WriteString(labelStyle.Render("Start: ") + valueStyle.Render(utils.FormatDateTime(entry.Start)) + "\n")
 
 	if entry.End != nil {
-		out.WriteString(labelStyle.Render("End: ") + valueStyle.Render(entry.End.Format("2006-01-02 15:04")) + "\n")
+		out.WriteString(labelStyle.Render("End: ") + valueStyle.Render(utils.FormatDateTime(*entry.End)) + "\n")
 	} else {
 		out.WriteString(labelStyle.Render("End: ") + valueStyle.Render("running") + "\n")
 	}
@@ -0,0 +1,76 @@
+package modes
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"time-tracker/utils"
+)
+
+func TestSetErrorStatus_FormatsRegularErrors(t *testing.T) {
+	m := &Model{}
+
+	m.setErrorStatus("stopping entry", errors.New("no running entry"))
+
+	if m.Status != "Error stopping entry: no running entry" {
+		t.Fatalf("unexpected status %q", m.Status)
+	}
+}
+
+func TestSetErrorStatus_ReloadsEntriesOnConflict(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	tm := utils.NewTaskManager(storage)
+	m := &Model{Storage: storage, TaskManager: tm}
+
+	// Another process adds an entry the model has not seen yet
+	if _, err := tm.StartEntry("Other", "Shell"); err != nil {
+		t.Fatalf("StartEntry returned error: %v", err)
+	}
+
+	m.setErrorStatus("updating entry", &utils.ConflictError{FilePath: "data.json"})
+
+	if !strings.Contains(m.Status, "changed by another process") {
+		t.Fatalf("expected conflict status, got %q", m.Status)
+	}
+	if len(m.Entries) != 1 || m.Entries[0].Project != "Other" {
+		t.Fatalf("expected entries to be reloaded, got %+v", m.Entries)
+	}
+}
+
+func TestLoadEntries_ChecksChangesAgainstTheEntriesShown(t *testing.T) {
+	dataFile := filepath.Join(t.TempDir(), "data.json")
+	storage, err := utils.NewFileStorage(dataFile)
+	if err != nil {
+		t.Fatalf("failed to create file storage: %v", err)
+	}
+	other, err := utils.NewFileStorage(dataFile)
+	if err != nil {
+		t.Fatalf("failed to create file storage: %v", err)
+	}
+	tm := utils.NewTaskManager(storage)
+	m := &Model{Storage: storage, TaskManager: tm}
+
+	if _, err := tm.StartEntry("TUI", "Build"); err != nil {
+		t.Fatalf("StartEntry returned error: %v", err)
+	}
+	if err := m.LoadEntries(); err != nil {
+		t.Fatalf("LoadEntries returned error: %v", err)
+	}
+
+	// Another process starts an entry the model has not seen yet
+	if _, err := utils.NewTaskManager(other).StartEntry("Other", "Shell"); err != nil {
+		t.Fatalf("StartEntry returned error: %v", err)
+	}
+	if _, err := tm.StartEntry("TUI", "Review"); !utils.IsConflict(err) {
+		t.Fatalf("expected a conflict for the stale view, got %v", err)
+	}
+
+	if err := m.LoadEntries(); err != nil {
+		t.Fatalf("LoadEntries returned error: %v", err)
+	}
+	if _, err := tm.StartEntry("TUI", "Review"); err != nil {
+		t.Fatalf("expected changes to succeed after reloading, got %v", err)
+	}
+}
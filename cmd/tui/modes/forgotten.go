@@ -0,0 +1,123 @@
+package modes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+// activityInterval limits how often key presses are written to the activity store
+const activityInterval = time.Minute
+
+// ForgottenState holds the end time being typed for a forgotten running entry
+type ForgottenState struct {
+	Draft string
+}
+
+// ForgottenMode asks when a forgotten running entry really ended
+var ForgottenMode = &Mode{
+	Name: "forgotten",
+	KeyBindings: []KeyBinding{
+		{Keys: "Enter", Label: "END", Description: "End the entry at the typed time"},
+		{Keys: "ctrl+u", Label: "CLEAR", Description: "Clear the time"},
+		{Keys: "Esc", Label: "CANCEL", Description: "Keep the entry running"},
+	},
+	HandleKeyMsg: func(m *Model, msg tea.KeyMsg) (*Model, tea.Cmd) {
+		switch msg.String() {
+		case "esc":
+			m.SwitchMode(m.ListMode)
+			return m, nil
+
+		case "enter":
+			end, err := parseTimeDraft(m.ForgottenState.Draft)
+			if err != nil {
+				m.Status = "Error: " + err.Error()
+				return m, nil
+			}
+
+			entry, err := m.TaskManager.StopEntryAt(end)
+			if err != nil {
+				m.setErrorStatus("ending entry", err)
+				return m, nil
+			}
+			if err := m.LoadEntries(); err != nil {
+				m.Err = err
+				return m, nil
+			}
+
+			m.SwitchMode(m.ListMode)
+			m.Status = fmt.Sprintf("Ended %s: %s at %s", entry.Project, entry.Title, utils.FormatDateTime(end))
+			return m, nil
+		}
+
+		m.ForgottenState.Draft = editTimeDraft(m.ForgottenState.Draft, msg)
+		return m, nil
+	},
+	RenderContent: func(m *Model, _ int) string {
+		entry := forgottenEntry(m)
+		if entry == nil {
+			return "No forgotten entry"
+		}
+
+		var out strings.Builder
+		out.WriteString(m.Styles.Title.Render("Forgotten Entry") + "\n\n")
+		out.WriteString(labelStyle.Render("Project: ") + valueStyle.Render(entry.Project) + "\n")
+		out.WriteString(labelStyle.Render("Title: ") + valueStyle.Render(entry.Title) + "\n")
+		out.WriteString(labelStyle.Render("Start: ") + valueStyle.Render(utils.FormatDateTime(entry.Start)) + "\n")
+		out.WriteString(labelStyle.Render("Running for: ") + valueStyle.Render(utils.FormatDuration(time.Since(entry.Start))) + "\n")
+		if suggested, ok := suggestedEnd(m, *entry); ok {
+			out.WriteString(labelStyle.Render("Last activity: ") + valueStyle.Render(utils.FormatDateTime(suggested)) + "\n")
+		}
+
+		out.WriteString(renderTimeDraft(m, "End", m.ForgottenState.Draft))
+		return out.String()
+	},
+}
+
+// forgottenEntry returns the running entry when it runs longer than forgotten-after, or nil
+func forgottenEntry(m *Model) *models.TimeEntry {
+	return utils.ForgottenEntry(m.Entries, time.Now(), m.ForgottenAfter)
+}
+
+// suggestedEnd returns the last activity while the entry was running, if activity is tracked
+func suggestedEnd(m *Model, entry models.TimeEntry) (time.Time, bool) {
+	if m.Activity == nil {
+		return time.Time{}, false
+	}
+	return utils.SuggestedEnd(m.Activity, entry, time.Now())
+}
+
+// openForgottenMode asks when the forgotten entry ended, prefilled with the last activity
+func openForgottenMode(m *Model, entry models.TimeEntry) {
+	m.ForgottenState = ForgottenState{}
+	if suggested, ok := suggestedEnd(m, entry); ok {
+		m.ForgottenState.Draft = suggested.Format(timeDraftLayout)
+	}
+	m.CurrentMode = m.ForgottenMode
+	m.Status = ""
+}
+
+// renderForgottenBanner warns about a forgotten running entry above the list
+func renderForgottenBanner(m *Model, entry *models.TimeEntry) string {
+	text := fmt.Sprintf("%s: %s has been running for %s - press f to set when it ended",
+		entry.Project, entry.Title, utils.FormatDuration(time.Since(entry.Start)))
+	style := warningStyle
+	if m.Width > 0 {
+		style = style.MaxWidth(m.Width)
+	}
+	return style.Render(text) + "\n"
+}
+
+// RecordActivity remembers a key press as activity, at most once per activityInterval
+func (m *Model) RecordActivity(now time.Time) {
+	if m.Activity == nil || now.Sub(m.activityRecordedAt) < activityInterval {
+		return
+	}
+	m.activityRecordedAt = now
+	// Remembering the activity is best effort and never interrupts the TUI
+	_ = utils.RecordActivity(m.Activity, m.Entries, now, m.ForgottenAfter)
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"time-tracker/models"
+	"time-tracker/utils"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -17,6 +18,7 @@ const (
 	FormModeNew FormMode = iota
 	FormModeEdit
 	FormModeResume
+	FormModeAdd
 )
 
 // FormState holds the state for form operations
@@ -32,6 +34,14 @@ var formKeyBindings = []KeyBinding{
 	{Keys: "Esc", Label: "CANCEL", Description: "Cancel"},
 }
 
+var editFormKeyBindings = []KeyBinding{
+	{Keys: "Tab", Label: "NEXT", Description: "Next field"},
+	{Keys: "Shift+Tab", Label: "PREV", Description: "Previous field"},
+	{Keys: "Enter", Label: "SUBMIT", Description: "Submit entry (new line in notes)"},
+	{Keys: "Ctrl+S", Label: "SAVE", Description: "Submit entry from any field"},
+	{Keys: "Esc", Label: "CANCEL", Description: "Cancel"},
+}
+
 // NewMode is the new entry form mode
 var NewMode = &Mode{
 	Name:         "new",
@@ -45,10 +55,15 @@ var NewMode = &Mode{
 // EditMode is the edit entry form mode
 var EditMode = &Mode{
 	Name:         "edit",
-	KeyBindings:  formKeyBindings,
+	KeyBindings:  editFormKeyBindings,
 	HandleKeyMsg: createFormKeyHandler(FormModeEdit),
 	RenderContent: func(m *Model, availableHeight int) string {
-		return renderFormContent(m, "Edit Entry", availableHeight)
+		title := "Edit Entry"
+		if idx := m.FormState.EditingIdx; idx >= 0 && idx < len(m.Entries) && m.Entries[idx].ID != "" {
+			// The ID is what the CLI takes to edit, delete, or resume this entry
+			title += " " + m.Entries[idx].ID
+		}
+		return renderFormContent(m, title, availableHeight)
 	},
 }
 
@@ -62,6 +77,16 @@ var ResumeMode = &Mode{
 	},
 }
 
+// AddMode is the form for adding a finished entry into the past
+var AddMode = &Mode{
+	Name:         "add",
+	KeyBindings:  formKeyBindings,
+	HandleKeyMsg: createFormKeyHandler(FormModeAdd),
+	RenderContent: func(m *Model, availableHeight int) string {
+		return renderFormContent(m, "Add Past Entry", availableHeight)
+	},
+}
+
 // openNewMode opens the form in new entry mode with empty fields
 func openNewMode(m *Model) {
 	m.CurrentMode = m.NewMode
@@ -73,6 +98,7 @@ func openNewMode(m *Model) {
 	for i := range m.Inputs {
 		m.Inputs[i].SetValue("")
 	}
+	clearAtInput(m)
 
 	// Set current date/time as default
 	setCurrentDateTimeDefaults(m, time.Now())
@@ -93,6 +119,11 @@ func openEditMode(m *Model, entry models.TimeEntry, idx int) {
 	m.Inputs[InputHour].SetValue(fmt.Sprintf("%02d", entry.Start.Hour()))
 	m.Inputs[InputMinute].SetValue(fmt.Sprintf("%02d", entry.Start.Minute()))
 	setDateDefaults(m, entry.Start)
+	setTagsValue(m, entry.Tags)
+	clearAtInput(m)
+	if m.NotesInput != nil {
+		m.NotesInput.SetValue(entry.Notes)
+	}
 
 	setupFormInputs(m)
 }
@@ -107,6 +138,7 @@ func openResumeMode(m *Model, entry models.TimeEntry) {
 	// Pre-fill project and title from entry
 	m.Inputs[InputProject].SetValue(entry.Project)
 	m.Inputs[InputTitle].SetValue(entry.Title)
+	setTagsValue(m, entry.Tags)
 
 	// Set current date/time as default
 	setCurrentDateTimeDefaults(m, time.Now())
@@ -114,6 +146,27 @@ func openResumeMode(m *Model, entry models.TimeEntry) {
 	setupFormInputs(m)
 }
 
+// openAddMode opens the form for a finished entry, defaulting to the last hour
+func openAddMode(m *Model) {
+	m.CurrentMode = m.AddMode
+	m.FormState = FormState{Mode: FormModeAdd}
+	m.Status = ""
+	setProjectSuggestions(m)
+
+	for i := range m.Inputs {
+		m.Inputs[i].SetValue("")
+	}
+	clearAtInput(m)
+
+	now := time.Now()
+	setCurrentDateTimeDefaults(m, now.Add(-time.Hour))
+	if m.EndInput != nil {
+		m.EndInput.SetValue(now.Format("15:04"))
+	}
+
+	setupFormInputs(m)
+}
+
 func setProjectSuggestions(m *Model) {
 	if m.Storage == nil || len(m.Inputs) <= InputProject {
 		return
@@ -126,12 +179,48 @@ func setProjectSuggestions(m *Model) {
 
 	suggestions := make([]string, 0, len(projects))
 	for _, project := range projects {
+		// Archived projects can still be typed in full, they are just not suggested
+		if project.Archived {
+			continue
+		}
 		suggestions = append(suggestions, project.Name)
 	}
 
 	m.Inputs[InputProject].SetSuggestions(suggestions)
 }
 
+// resolveFormProject resolves the typed project by name, code, or alias, see utils.ResolveProject
+func resolveFormProject(m *Model, input string) (string, error) {
+	if m.Storage == nil {
+		return input, nil
+	}
+	projects, err := m.Storage.LoadProjects()
+	if err != nil {
+		return "", err
+	}
+	return utils.ResolveProject(projects, input)
+}
+
+// completeProjectCode replaces a typed project code or alias with the name, reporting whether it did
+func completeProjectCode(m *Model) bool {
+	if m.FocusIndex != InputProject {
+		return false
+	}
+
+	value := m.Inputs[InputProject].Value()
+	name, err := resolveFormProject(m, value)
+	if err != nil {
+		m.setErrorStatus("resolving project", err)
+		return true
+	}
+	if name == value || strings.EqualFold(name, strings.TrimSpace(value)) {
+		return false
+	}
+	m.Inputs[InputProject].SetValue(name)
+	m.Inputs[InputProject].CursorEnd()
+	return true
+}
+
 // createFormKeyHandler creates a key handler for a form mode
 func createFormKeyHandler(formMode FormMode) func(*Model, tea.KeyMsg) (*Model, tea.Cmd) {
 	return func(m *Model, msg tea.KeyMsg) (*Model, tea.Cmd) {
@@ -140,11 +229,34 @@ func createFormKeyHandler(formMode FormMode) func(*Model, tea.KeyMsg) (*Model, t
 			return model, cmd
 		}
 
+		if msg.String() == "ctrl+s" {
+			return handleFormSubmit(m, formMode)
+		}
+
+		// The notes field consumes keys itself, including enter for new lines
+		if notesFocused(m) {
+			var cmd tea.Cmd
+			*m.NotesInput, cmd = m.NotesInput.Update(msg)
+			return m, cmd
+		}
+
 		// Handle enter for submit
 		if msg.String() == "enter" {
 			return handleFormSubmit(m, formMode)
 		}
 
+		if endFocused(m) {
+			var cmd tea.Cmd
+			*m.EndInput, cmd = m.EndInput.Update(msg)
+			return m, cmd
+		}
+
+		if atFocused(m) {
+			var cmd tea.Cmd
+			*m.AtInput, cmd = m.AtInput.Update(msg)
+			return m, cmd
+		}
+
 		// Pass through to text inputs
 		cmds := make([]tea.Cmd, len(m.Inputs))
 		for i := range m.Inputs {
@@ -154,14 +266,19 @@ func createFormKeyHandler(formMode FormMode) func(*Model, tea.KeyMsg) (*Model, t
 	}
 }
 
-// handleFormSubmit handles form submission for new/edit/resume modes
+// handleFormSubmit handles form submission for new/edit/resume/add modes
 func handleFormSubmit(m *Model, formMode FormMode) (*Model, tea.Cmd) {
-	project := m.Inputs[InputProject].Value()
+	project, err := resolveFormProject(m, m.Inputs[InputProject].Value())
+	if err != nil {
+		m.setErrorStatus("resolving project", err)
+		return m, nil
+	}
 	title := m.Inputs[InputTitle].Value()
+	tags := formTags(m)
 
 	// For new/resume modes, require project and title
 	// For edit mode, allow empty values (to create blank entries/gaps)
-	if (formMode == FormModeNew || formMode == FormModeResume) && (project == "" || title == "") {
+	if formMode != FormModeEdit && (project == "" || title == "") {
 		m.Status = "Project and title are required"
 		return m, nil
 	}
@@ -174,15 +291,28 @@ func handleFormSubmit(m *Model, formMode FormMode) (*Model, tea.Cmd) {
 
 	switch formMode {
 	case FormModeNew, FormModeResume:
-		if _, err := m.TaskManager.StartEntryAt(project, title, startTime); err != nil {
-			m.Status = "Error starting entry: " + err.Error()
+		if _, err := m.TaskManager.StartEntryAt(project, title, startTime, tags...); err != nil {
+			m.setErrorStatus("starting entry", err)
 		} else {
 			m.Status = "Entry started: " + project
+			warnIfOverBudget(m, project)
+		}
+
+	case FormModeAdd:
+		endTime, err := parseFormEnd(m, startTime)
+		if err != nil {
+			m.Status = "Error: " + err.Error()
+			return m, nil
+		}
+		if _, err := m.TaskManager.InsertEntry(project, title, startTime, endTime, tags...); err != nil {
+			m.setErrorStatus("adding entry", err)
+		} else {
+			m.Status = fmt.Sprintf("Entry added: %s (%s)", project, utils.FormatDuration(endTime.Sub(startTime)))
 		}
 
 	case FormModeEdit:
-		if err := m.TaskManager.UpdateEntry(m.FormState.EditingIdx, project, title, startTime); err != nil {
-			m.Status = "Error updating entry: " + err.Error()
+		if err := m.TaskManager.UpdateEntry(m.FormState.EditingIdx, project, title, startTime, tags, formNotes(m)); err != nil {
+			m.setErrorStatus("updating entry", err)
 		} else {
 			m.Status = "Entry updated: " + project
 		}
@@ -206,18 +336,21 @@ func handleFormKeyMsg(m *Model, msg tea.KeyMsg) (*Model, tea.Cmd, bool) {
 		return m, nil, true
 
 	case "tab":
+		if completeProjectCode(m) {
+			return m, nil, true
+		}
 		if shouldPassTabToProjectSuggestions(m) {
 			m.Inputs[InputProject].SetValue(m.Inputs[InputProject].CurrentSuggestion())
 			return m, nil, true
 		}
-		m.FocusIndex = (m.FocusIndex + 1) % len(m.Inputs)
+		m.FocusIndex = (m.FocusIndex + 1) % formFieldCount(m)
 		updateInputFocus(m)
 		return m, nil, true
 
 	case "shift+tab":
 		m.FocusIndex--
 		if m.FocusIndex < 0 {
-			m.FocusIndex = len(m.Inputs) - 1
+			m.FocusIndex = formFieldCount(m) - 1
 		}
 		updateInputFocus(m)
 		return m, nil, true
@@ -240,8 +373,90 @@ func shouldPassTabToProjectSuggestions(m *Model) bool {
 	return len([]rune(projectInput.Value())) < len([]rune(currentSuggestion))
 }
 
-// parseFormTime parses date and time from form inputs.
+// formTags returns the normalized tags typed into the form, if the form has a tags input
+func formTags(m *Model) []string {
+	if len(m.Inputs) <= InputTags {
+		return nil
+	}
+	return utils.ParseTags(m.Inputs[InputTags].Value())
+}
+
+func setTagsValue(m *Model, tags []string) {
+	if len(m.Inputs) <= InputTags {
+		return
+	}
+	m.Inputs[InputTags].SetValue(utils.FormatTags(tags))
+}
+
+// notesFieldActive reports whether the current form shows the multi-line notes field
+func notesFieldActive(m *Model) bool {
+	return m.NotesInput != nil && m.FormState.Mode == FormModeEdit
+}
+
+// notesFocused reports whether keyboard focus is on the notes field
+func notesFocused(m *Model) bool {
+	return notesFieldActive(m) && m.FocusIndex == lastFieldIndex(m)
+}
+
+// endFieldActive reports whether the current form shows the end time field
+func endFieldActive(m *Model) bool {
+	return m.EndInput != nil && m.FormState.Mode == FormModeAdd
+}
+
+// endFocused reports whether keyboard focus is on the end time field
+func endFocused(m *Model) bool {
+	return endFieldActive(m) && m.FocusIndex == lastFieldIndex(m)
+}
+
+// atFocused reports whether keyboard focus is on the free-text start time field
+func atFocused(m *Model) bool {
+	return m.AtInput != nil && m.FocusIndex == len(m.Inputs)
+}
+
+// lastFieldIndex returns the focus index of the notes or end time field, after the start time
+func lastFieldIndex(m *Model) int {
+	if m.AtInput != nil {
+		return len(m.Inputs) + 1
+	}
+	return len(m.Inputs)
+}
+
+// formFieldCount returns the number of focusable fields in the current form
+func formFieldCount(m *Model) int {
+	if notesFieldActive(m) || endFieldActive(m) {
+		return lastFieldIndex(m) + 1
+	}
+	return lastFieldIndex(m)
+}
+
+func clearAtInput(m *Model) {
+	if m.AtInput != nil {
+		m.AtInput.SetValue("")
+	}
+}
+
+// formNotes returns the notes typed into the edit form
+func formNotes(m *Model) string {
+	if !notesFieldActive(m) {
+		return ""
+	}
+	return m.NotesInput.Value()
+}
+
+// parseFormEnd parses the end time of the add form, a bare "HH:MM" being on the start's day
+func parseFormEnd(m *Model, start time.Time) (time.Time, error) {
+	if !endFieldActive(m) {
+		return time.Time{}, fmt.Errorf("no end time")
+	}
+	return utils.ParseEndTime(m.EndInput.Value(), start, time.Now())
+}
+
+// parseFormTime parses the free-text start time if given, or else the date and time fields
 func parseFormTime(m *Model) (time.Time, error) {
+	if m.AtInput != nil && strings.TrimSpace(m.AtInput.Value()) != "" {
+		return utils.ParseTimeExpression(m.AtInput.Value(), time.Now())
+	}
+
 	yearStr := m.Inputs[InputYear].Value()
 	monthStr := m.Inputs[InputMonth].Value()
 	dayStr := m.Inputs[InputDay].Value()
@@ -307,6 +522,38 @@ func updateInputFocus(m *Model) {
 			m.Inputs[i].TextStyle = m.Styles.InputBlurred
 		}
 	}
+
+	if m.NotesInput != nil {
+		if notesFocused(m) {
+			m.NotesInput.Focus()
+		} else {
+			m.NotesInput.Blur()
+		}
+	}
+
+	if m.AtInput != nil {
+		if atFocused(m) {
+			m.AtInput.Focus()
+			m.AtInput.PromptStyle = m.Styles.InputFocused
+			m.AtInput.TextStyle = m.Styles.InputFocused
+		} else {
+			m.AtInput.Blur()
+			m.AtInput.PromptStyle = m.Styles.InputBlurred
+			m.AtInput.TextStyle = m.Styles.InputBlurred
+		}
+	}
+
+	if m.EndInput != nil {
+		if endFocused(m) {
+			m.EndInput.Focus()
+			m.EndInput.PromptStyle = m.Styles.InputFocused
+			m.EndInput.TextStyle = m.Styles.InputFocused
+		} else {
+			m.EndInput.Blur()
+			m.EndInput.PromptStyle = m.Styles.InputBlurred
+			m.EndInput.TextStyle = m.Styles.InputBlurred
+		}
+	}
 }
 
 // setupFormInputs sets up form inputs with focus on first field
@@ -321,6 +568,22 @@ func setupFormInputs(m *Model) {
 		m.Inputs[i].PromptStyle = m.Styles.InputBlurred
 		m.Inputs[i].TextStyle = m.Styles.InputBlurred
 	}
+
+	if m.NotesInput != nil {
+		m.NotesInput.Blur()
+	}
+
+	if m.AtInput != nil {
+		m.AtInput.Blur()
+		m.AtInput.PromptStyle = m.Styles.InputBlurred
+		m.AtInput.TextStyle = m.Styles.InputBlurred
+	}
+
+	if m.EndInput != nil {
+		m.EndInput.Blur()
+		m.EndInput.PromptStyle = m.Styles.InputBlurred
+		m.EndInput.TextStyle = m.Styles.InputBlurred
+	}
 }
 
 func setDateDefaults(m *Model, date time.Time) {
@@ -357,6 +620,26 @@ func renderEntryFormBody(m *Model, content *strings.Builder) {
 	content.WriteString(timeLabel + "\n")
 	content.WriteString(hourInput + " : " + minuteInput + "\n\n")
 
+	if len(m.Inputs) > InputTags {
+		content.WriteString(m.Styles.Label.Render("Tags (comma-separated):") + "\n")
+		content.WriteString(m.Inputs[InputTags].View() + "\n\n")
+	}
+
+	if m.AtInput != nil {
+		content.WriteString(m.Styles.Label.Render("Or start at (overrides date and time):") + "\n")
+		content.WriteString(m.AtInput.View() + "\n\n")
+	}
+
+	if endFieldActive(m) {
+		content.WriteString(m.Styles.Label.Render("End (HH:MM or YYYY-MM-DD HH:MM):") + "\n")
+		content.WriteString(m.EndInput.View() + "\n\n")
+	}
+
+	if notesFieldActive(m) {
+		content.WriteString(m.Styles.Label.Render("Notes:") + "\n")
+		content.WriteString(m.NotesInput.View() + "\n\n")
+	}
+
 	if m.Status != "" {
 		if strings.Contains(strings.ToLower(m.Status), "error") {
 			content.WriteString(m.Styles.StatusError.Render(m.Status) + "\n\n")
@@ -1,6 +1,7 @@
 package modes
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -40,6 +41,17 @@ func TestOpenFormModesSetProjectSuggestions(t *testing.T) {
 	}
 }
 
+func TestProjectSuggestionsSkipArchivedProjects(t *testing.T) {
+	projects := []models.Project{{Name: "Alpha"}, {Name: "Legacy", Archived: true}}
+	m := newFormDateTestModel()
+	m.Storage = newProjectSuggestionStorage(t, projects)
+
+	openNewMode(m)
+	if got := m.Inputs[InputProject].AvailableSuggestions(); len(got) != 1 || got[0] != "Alpha" {
+		t.Fatalf("new mode suggestions = %+v", got)
+	}
+}
+
 func TestFormModeTabAcceptsProjectSuggestionBeforeChangingFocus(t *testing.T) {
 	m := newFormDateTestModel()
 	m.Storage = newProjectSuggestionStorage(t, []models.Project{{Name: "Backend"}})
@@ -82,3 +94,41 @@ func TestFormModeTabNavigatesWhenProjectAlreadyExactlyMatchesSuggestion(t *testi
 		t.Fatalf("focus index = %d, expected focus to move to title input", updatedModel.FocusIndex)
 	}
 }
+
+func TestFormModeTabCompletesProjectCodeAndAlias(t *testing.T) {
+	m := newFormDateTestModel()
+	m.Storage = newProjectSuggestionStorage(t, []models.Project{
+		{Name: "Acme Website", Code: "ABC-12572"},
+		{Name: "Internal", Aliases: []string{"ops"}},
+	})
+
+	openNewMode(m)
+	m.Inputs[InputProject].SetValue("abc-12572")
+	updatedModel, _ := NewMode.HandleKeyMsg(m, tea.KeyMsg{Type: tea.KeyTab})
+	if updatedModel.Inputs[InputProject].Value() != "Acme Website" || updatedModel.FocusIndex != InputProject {
+		t.Fatalf("project value = %q, focus = %d, expected the code completed in place", updatedModel.Inputs[InputProject].Value(), updatedModel.FocusIndex)
+	}
+
+	updatedModel.Inputs[InputProject].SetValue("OPS")
+	updatedModel, _ = NewMode.HandleKeyMsg(updatedModel, tea.KeyMsg{Type: tea.KeyTab})
+	if updatedModel.Inputs[InputProject].Value() != "Internal" {
+		t.Fatalf("project value = %q, expected the alias completed", updatedModel.Inputs[InputProject].Value())
+	}
+}
+
+func TestFormSubmitRejectsAmbiguousProjectAlias(t *testing.T) {
+	m := newFormDateTestModel()
+	m.Storage = newProjectSuggestionStorage(t, []models.Project{
+		{Name: "Internal", Aliases: []string{"ops"}},
+		{Name: "Support", Code: "OPS"},
+	})
+
+	openNewMode(m)
+	m.Inputs[InputProject].SetValue("ops")
+	m.Inputs[InputTitle].SetValue("Deploy")
+	updatedModel, _ := handleFormSubmit(m, FormModeNew)
+
+	if updatedModel.CurrentMode != NewMode || !strings.Contains(updatedModel.Status, "Internal (alias), Support (code OPS)") {
+		t.Fatalf("expected to stay in the form with the candidates listed, got mode %v and status %q", updatedModel.CurrentMode, updatedModel.Status)
+	}
+}
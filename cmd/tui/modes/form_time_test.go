@@ -63,3 +63,45 @@ func TestParseFormTimeRejectsInvalidCalendarDate(t *testing.T) {
 		t.Fatalf("expected day-related validation error, got %q", err.Error())
 	}
 }
+
+func TestParseFormTimeUsesFreeTextStartTime(t *testing.T) {
+	m := newFormTimeTestModel()
+	atInput := textinput.New()
+	m.AtInput = &atInput
+	// The date and time fields are ignored once a start time is typed
+	m.Inputs[InputYear].SetValue("2020")
+
+	now := time.Now()
+	yesterday := now.AddDate(0, 0, -1)
+	tests := []struct {
+		input string
+		check func(time.Time) bool
+	}{
+		{"09:15", func(got time.Time) bool {
+			return got.YearDay() == now.YearDay() && got.Hour() == 9 && got.Minute() == 15
+		}},
+		{"yesterday 17:30", func(got time.Time) bool {
+			return got.Year() == yesterday.Year() && got.YearDay() == yesterday.YearDay() && got.Hour() == 17 && got.Minute() == 30
+		}},
+		{"10m ago", func(got time.Time) bool {
+			ago := time.Since(got)
+			return ago >= 10*time.Minute && ago < 11*time.Minute
+		}},
+	}
+
+	for _, tt := range tests {
+		m.AtInput.SetValue(tt.input)
+		got, err := parseFormTime(m)
+		if err != nil {
+			t.Fatalf("parseFormTime(%q) returned error: %v", tt.input, err)
+		}
+		if !tt.check(got) {
+			t.Fatalf("parseFormTime(%q) = %s", tt.input, got.Format("2006-01-02 15:04:05"))
+		}
+	}
+
+	m.AtInput.SetValue("tomorrow-ish")
+	if _, err := parseFormTime(m); err == nil {
+		t.Fatal("expected an unparseable start time to be rejected")
+	}
+}
@@ -24,4 +24,7 @@ func TestInputIndexConstants(t *testing.T) {
 	if InputMinute != 6 {
 		t.Fatalf("InputMinute = %d, expected 6", InputMinute)
 	}
+	if InputTags != 7 {
+		t.Fatalf("InputTags = %d, expected 7", InputTags)
+	}
 }
@@ -17,10 +17,16 @@ var ListMode = &Mode{
 		{Keys: "Tab", Label: "STATS", Description: "Switch mode"},
 		{Keys: "/", Label: "SEARCH", Description: "Focus search"},
 		{Keys: "n", Label: "NEW", Description: "New entry"},
+		{Keys: "a", Label: "ADD", Description: "Add a finished entry in the past"},
 		{Keys: "s", Label: "STOP", Description: "Stop running entry"},
+		{Keys: "f", Label: "FORGOTTEN", Description: "Set when a forgotten running entry ended"},
 		{Keys: "r", Label: "RESUME", Description: "Resume entry"},
 		{Keys: "e", Label: "EDIT", Description: "Edit entry"},
+		{Keys: "x", Label: "SPLIT", Description: "Split entry at a time"},
+		{Keys: "c", Label: "COMPACT", Description: "Merge repeated entries and drop very short ones"},
 		{Keys: "d", Label: "DELETE", Description: "Delete entry"},
+		{Keys: "u", Label: "UNDO", Description: "Undo last change"},
+		{Keys: "ctrl+r", Label: "REDO", Description: "Redo undone change"},
 		{Keys: "?", Label: "HELP", Description: "Toggle help"},
 		{Keys: "q", Label: "QUIT", Description: "Quit"},
 	},
@@ -74,13 +80,22 @@ var ListMode = &Mode{
 			openNewMode(m)
 			return m, nil
 
+		case "a":
+			if m.EndInput != nil {
+				openAddMode(m)
+			}
+			return m, nil
+
 		case "s":
 			// Stop only works on running entries
 			if isValidSelection(m) {
 				entry := m.Entries[m.SelectedIdx]
-				if entry.IsRunning() {
+				if forgotten := forgottenEntry(m); forgotten != nil && entry.IsRunning() {
+					// Stopping now would book the whole forgotten time, so ask when it ended
+					openForgottenMode(m, *forgotten)
+				} else if entry.IsRunning() {
 					if _, err := m.TaskManager.StopEntry(); err != nil {
-						m.Status = "Error stopping entry: " + err.Error()
+						m.setErrorStatus("stopping entry", err)
 					} else {
 						m.Status = "Entry stopped"
 					}
@@ -91,6 +106,12 @@ var ListMode = &Mode{
 			}
 			return m, nil
 
+		case "f":
+			if forgotten := forgottenEntry(m); forgotten != nil {
+				openForgottenMode(m, *forgotten)
+			}
+			return m, nil
+
 		case "r":
 			if isValidSelection(m) {
 				entry := m.Entries[m.SelectedIdx]
@@ -107,12 +128,30 @@ var ListMode = &Mode{
 			}
 			return m, nil
 
+		case "x":
+			if isValidSelection(m) && !m.Entries[m.SelectedIdx].IsBlank() {
+				openSplitMode(m, m.SelectedIdx)
+			}
+			return m, nil
+
+		case "c":
+			compactEntries(m)
+			return m, nil
+
 		case "d":
 			if isValidSelection(m) {
 				openConfirmDelete(m, m.SelectedIdx)
 			}
 			return m, nil
 
+		case "u":
+			stepHistory(m, false)
+			return m, nil
+
+		case "ctrl+r":
+			stepHistory(m, true)
+			return m, nil
+
 		case "?":
 			m.PreviousMode = m.CurrentMode
 			m.CurrentMode = m.HelpMode
@@ -180,6 +219,16 @@ var ListMode = &Mode{
 			searchBarHeight = 1
 		}
 
+		banner := ""
+		if forgotten := forgottenEntry(m); forgotten != nil {
+			banner = renderForgottenBanner(m, forgotten)
+			headerHeight++
+		}
+		if m.Timebox != nil {
+			banner += renderTimeboxBanner(m, m.Timebox)
+			headerHeight++
+		}
+
 		listRowHeight := max(availableHeight-headerHeight-searchBarHeight, 1)
 		visibleRows := getVisibleRows(m)
 		ensureSelectionVisibleInRows(m, visibleRows, listRowHeight)
@@ -197,7 +246,7 @@ var ListMode = &Mode{
 			searchInputBar = renderSearchInputBar(m)
 		}
 
-		return header + rows + rowsBottomPadding + searchInputBar
+		return banner + header + rows + rowsBottomPadding + searchInputBar
 	},
 }
 
@@ -401,11 +450,11 @@ func renderTableRows(m *Model, maxHeight int) string {
 		visible := visibleRows[i]
 		entry := visible.Entry
 
-		startStr := entry.Start.Format("2006-01-02 15:04")
+		startStr := utils.FormatDateTime(entry.Start)
 
 		endStr := "running"
 		if entry.End != nil {
-			endStr = entry.End.Format("2006-01-02 15:04")
+			endStr = utils.FormatDateTime(*entry.End)
 		} else if entry.IsBlank() {
 			endStr = "stopped"
 		}
@@ -0,0 +1,37 @@
+package modes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"time-tracker/utils"
+)
+
+// progressBarWidth is the number of cells of the progress bar towards today's target
+const progressBarWidth = 10
+
+// TargetProgress describes today's and this week's tracked time against the schedule
+func (m *Model) TargetProgress(now time.Time) string {
+	if m.Schedule == nil {
+		return ""
+	}
+
+	weekStart := utils.WeekStartOf(now)
+	week := utils.CalculateBalance(m.Entries, m.Schedule, weekStart, weekStart.AddDate(0, 0, 6))
+	weekText := fmt.Sprintf("week %s/%s", utils.FormatDuration(week.Tracked), utils.FormatDuration(week.Expected))
+
+	today := utils.CalculateBalance(m.Entries, m.Schedule, now, now)
+	if today.Expected == 0 {
+		return weekText
+	}
+
+	bar := progressBar(today.Tracked, today.Expected)
+	return fmt.Sprintf("today [%s] %s/%s · %s", bar, utils.FormatDuration(today.Tracked), utils.FormatDuration(today.Expected), weekText)
+}
+
+// progressBar renders the share of done in total as progressBarWidth cells
+func progressBar(done, total time.Duration) string {
+	filled := min(int(float64(progressBarWidth)*float64(done)/float64(total)), progressBarWidth)
+	return strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled)
+}
@@ -0,0 +1,92 @@
+package modes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+// projectDetailHeight is the number of lines of the project detail pane, its separator included
+const projectDetailHeight = 7
+
+// projectDetailLimit is the number of title categories and top titles in the detail pane
+const projectDetailLimit = 3
+
+// sparkLevels are the cells of the weekly trend, from an idle week to the busiest one
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// toggleProjectDetail shows or hides the detail pane of the selected project
+func toggleProjectDetail(m *Model) {
+	m.ProjectDetail = !m.ProjectDetail
+	if m.ProjectDetail {
+		m.Status = "Showing project details"
+	} else {
+		m.Status = "Project details hidden"
+	}
+}
+
+// renderProjectDetail describes the project's metadata and statistics in projectDetailHeight lines
+func renderProjectDetail(m *Model, project models.Project, now time.Time) []string {
+	title := project.Name
+	if path := utils.NewProjectTree(m.Projects).Path(project.Name); len(path) > 1 {
+		title = strings.Join(path, utils.ProjectPathSeparator)
+	}
+	metadata := fmt.Sprintf("Code: %s · Category: %s", dashIfEmpty(project.Code), dashIfEmpty(project.Category))
+	if len(project.Aliases) > 0 {
+		metadata += " · Aliases: " + strings.Join(project.Aliases, ", ")
+	}
+	lines := []string{m.Styles.Header.Render(title), metadata}
+
+	summary := utils.SummarizeProject(project, m.Projects, m.Entries, now)
+	if summary.Entries == 0 {
+		return append(lines, "Nothing tracked yet")
+	}
+	noun := "entries"
+	if summary.Entries == 1 {
+		noun = "entry"
+	}
+	lines = append(lines,
+		fmt.Sprintf("Tracked %s in %d %s · first %s · last %s", utils.FormatDuration(summary.Total), summary.Entries, noun,
+			summary.FirstUsed.Format("2006-01-02"), summary.LastUsed.Format("2006-01-02")),
+		"Categories: "+joinTitleTotals(summary.Categories),
+		"Top titles: "+joinTitleTotals(summary.Titles),
+		fmt.Sprintf("Last %d weeks: %s %s this week", len(summary.Weeks), sparkline(summary.Weeks), utils.FormatDuration(summary.Weeks[len(summary.Weeks)-1].Total)),
+	)
+	return lines
+}
+
+// joinTitleTotals lists the first projectDetailLimit totals on one line, e.g. "Review 4h · Meeting 3h"
+func joinTitleTotals(totals []utils.TitleTotal) string {
+	parts := make([]string, 0, projectDetailLimit)
+	for _, t := range totals[:min(len(totals), projectDetailLimit)] {
+		parts = append(parts, fmt.Sprintf("%s %s", t.Name, utils.FormatDuration(t.Total)))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// sparkline renders one cell per week scaled to the busiest week, e.g. "▁▃█▅"
+func sparkline(weeks []utils.WeeklyTotal) string {
+	var busiest time.Duration
+	for _, week := range weeks {
+		busiest = max(busiest, week.Total)
+	}
+	var line strings.Builder
+	for _, week := range weeks {
+		level := 0
+		if busiest > 0 {
+			level = int(float64(len(sparkLevels)-1) * float64(week.Total) / float64(busiest))
+		}
+		line.WriteRune(sparkLevels[level])
+	}
+	return line.String()
+}
+
+func dashIfEmpty(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
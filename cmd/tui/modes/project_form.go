@@ -1,10 +1,12 @@
 package modes
 
 import (
+	"fmt"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"time-tracker/models"
+	"time-tracker/utils"
 )
 
 type ProjectFormMode int
@@ -14,6 +16,13 @@ const (
 	ProjectFormModeEdit
 )
 
+// Billing inputs follow the name, code, and category inputs in ProjectInputs
+const (
+	ProjectInputRate = iota + 3
+	ProjectInputCurrency
+	ProjectInputBillable
+)
+
 type ProjectFormState struct {
 	Mode        ProjectFormMode
 	EditingName string
@@ -64,6 +73,19 @@ func openProjectEditMode(m *Model, project models.Project) {
 	m.ProjectInputs[0].SetValue(project.Name)
 	m.ProjectInputs[1].SetValue(project.Code)
 	m.ProjectInputs[2].SetValue(project.Category)
+	if hasProjectBillingInputs(m) {
+		rate := ""
+		if project.HourlyRateCents != 0 {
+			rate = utils.FormatMoney(project.HourlyRateCents)
+		}
+		billable := "no"
+		if project.Billable {
+			billable = "yes"
+		}
+		m.ProjectInputs[ProjectInputRate].SetValue(rate)
+		m.ProjectInputs[ProjectInputCurrency].SetValue(project.Currency)
+		m.ProjectInputs[ProjectInputBillable].SetValue(billable)
+	}
 
 	setupProjectFormInputs(m)
 }
@@ -126,20 +148,35 @@ func handleProjectFormSubmit(m *Model, formMode ProjectFormMode) (*Model, tea.Cm
 		return m, nil
 	}
 
+	hourlyRate, currency, billable, err := parseProjectBillingInputs(m)
+	if err != nil {
+		if formMode == ProjectFormModeNew {
+			m.Status = "Error adding project: " + err.Error()
+		} else {
+			m.Status = "Error editing project: " + err.Error()
+		}
+		return m, nil
+	}
+
+	// The metadata and billing are saved together, in one undoable step
+	changes := projectFormChanges(m, formMode, name, code, category, hourlyRate, currency, billable)
 	switch formMode {
 	case ProjectFormModeNew:
-		if _, err := m.TaskManager.AddProject(name, code, category); err != nil {
-			m.Status = "Error adding project: " + err.Error()
+		if _, err := m.TaskManager.CreateProject(name, changes); err != nil {
+			m.setErrorStatus("adding project", err)
 			return m, nil
 		}
 		m.Status = "Project added"
 
 	case ProjectFormModeEdit:
-		if _, err := m.TaskManager.EditProject(m.ProjectFormState.EditingName, name, code, category); err != nil {
-			m.Status = "Error editing project: " + err.Error()
+		if changes.IsEmpty() {
+			m.Status = "No changes to the project"
+		} else if _, err := m.TaskManager.UpdateProject(m.ProjectFormState.EditingName, changes); err != nil {
+			m.setErrorStatus("editing project", err)
 			return m, nil
+		} else {
+			m.Status = "Project updated"
 		}
-		m.Status = "Project updated"
 	}
 
 	if err := m.LoadEntries(); err != nil {
@@ -151,6 +188,66 @@ func handleProjectFormSubmit(m *Model, formMode ProjectFormMode) (*Model, tea.Cm
 	return m, nil
 }
 
+// projectFormChanges returns all fields for a new project, or the ones that differ from the edited one
+func projectFormChanges(m *Model, formMode ProjectFormMode, name, code, category string, hourlyRate int64, currency string, billable bool) utils.ProjectChanges {
+	var original models.Project
+	if formMode == ProjectFormModeEdit {
+		original, _ = findProject(m.Projects, m.ProjectFormState.EditingName)
+	}
+
+	var changes utils.ProjectChanges
+	if formMode == ProjectFormModeEdit && strings.TrimSpace(name) != original.Name {
+		changes.Name = &name
+	}
+	if formMode == ProjectFormModeNew || strings.TrimSpace(code) != original.Code {
+		changes.Code = &code
+	}
+	if formMode == ProjectFormModeNew || strings.TrimSpace(category) != original.Category {
+		changes.Category = &category
+	}
+	if !hasProjectBillingInputs(m) {
+		return changes
+	}
+	if formMode == ProjectFormModeNew || hourlyRate != original.HourlyRateCents {
+		changes.HourlyRate = &hourlyRate
+	}
+	if formMode == ProjectFormModeNew || !strings.EqualFold(strings.TrimSpace(currency), original.Currency) {
+		changes.Currency = &currency
+	}
+	if formMode == ProjectFormModeNew || billable != original.Billable {
+		changes.Billable = &billable
+	}
+	return changes
+}
+
+func hasProjectBillingInputs(m *Model) bool {
+	return len(m.ProjectInputs) > ProjectInputBillable
+}
+
+// parseProjectBillingInputs reads the rate (in cents), currency, and billable inputs of the project form
+func parseProjectBillingInputs(m *Model) (int64, string, bool, error) {
+	if !hasProjectBillingInputs(m) {
+		return 0, "", false, nil
+	}
+
+	hourlyRate, err := utils.ParseMoney(m.ProjectInputs[ProjectInputRate].Value())
+	if err != nil {
+		return 0, "", false, fmt.Errorf("invalid hourly rate: %w", err)
+	}
+
+	var billable bool
+	switch strings.ToLower(strings.TrimSpace(m.ProjectInputs[ProjectInputBillable].Value())) {
+	case "", "n", "no", "false":
+		billable = false
+	case "y", "yes", "true":
+		billable = true
+	default:
+		return 0, "", false, fmt.Errorf("billable must be yes or no")
+	}
+
+	return hourlyRate, m.ProjectInputs[ProjectInputCurrency].Value(), billable, nil
+}
+
 func setupProjectFormInputs(m *Model) {
 	m.ProjectFocusIndex = 0
 	m.ProjectInputs[0].Focus()
@@ -197,6 +294,15 @@ func renderProjectFormContent(m *Model, title string, availableHeight int) strin
 	content.WriteString(categoryLabel + "\n")
 	content.WriteString(categoryInput + "\n\n")
 
+	if hasProjectBillingInputs(m) {
+		content.WriteString(m.Styles.Label.Render("Hourly rate:") + "\n")
+		content.WriteString(m.ProjectInputs[ProjectInputRate].View() + "\n\n")
+		content.WriteString(m.Styles.Label.Render("Currency:") + "\n")
+		content.WriteString(m.ProjectInputs[ProjectInputCurrency].View() + "\n\n")
+		content.WriteString(m.Styles.Label.Render("Billable (yes/no):") + "\n")
+		content.WriteString(m.ProjectInputs[ProjectInputBillable].View() + "\n\n")
+	}
+
 	if m.Status != "" {
 		if strings.Contains(strings.ToLower(m.Status), "error") {
 			content.WriteString(m.Styles.StatusError.Render(m.Status) + "\n\n")
@@ -4,20 +4,27 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"time-tracker/models"
 )
 
+var archivedProjectStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+
 // ProjectsMode is the project metadata list view mode.
 var ProjectsMode = &Mode{
 	Name: "projects",
 	KeyBindings: []KeyBinding{
-		{Keys: "Tab", Label: "LIST", Description: "Switch mode"},
+		{Keys: "Tab", Label: "TASKS", Description: "Switch mode"},
 		{Keys: "n", Label: "NEW", Description: "Add project"},
 		{Keys: "e", Label: "EDIT", Description: "Edit project"},
 		{Keys: "d", Label: "DELETE", Description: "Delete project"},
+		{Keys: "a", Label: "ARCHIVE", Description: "Archive or unarchive project"},
+		{Keys: "Enter", Label: "DETAILS", Description: "Toggle project details"},
+		{Keys: "u", Label: "UNDO", Description: "Undo last change"},
+		{Keys: "ctrl+r", Label: "REDO", Description: "Redo undone change"},
 		{Keys: "k / ↑", Label: "UP", Description: "Scroll up"},
 		{Keys: "j / ↓", Label: "DOWN", Description: "Scroll down"},
 		{Keys: "?", Label: "HELP", Description: "Toggle help"},
@@ -25,6 +32,14 @@ var ProjectsMode = &Mode{
 	},
 	HandleKeyMsg: func(m *Model, msg tea.KeyMsg) (*Model, tea.Cmd) {
 		switch msg.String() {
+		case "u":
+			stepHistory(m, false)
+			return m, nil
+
+		case "ctrl+r":
+			stepHistory(m, true)
+			return m, nil
+
 		case "?":
 			m.PreviousMode = m.CurrentMode
 			m.CurrentMode = m.HelpMode
@@ -34,7 +49,11 @@ var ProjectsMode = &Mode{
 			return m, tea.Quit
 
 		case "tab":
-			m.SwitchMode(m.ListMode)
+			m.SwitchMode(m.TasksMode)
+			return m, nil
+
+		case "enter":
+			toggleProjectDetail(m)
 			return m, nil
 
 		case "n":
@@ -59,7 +78,7 @@ var ProjectsMode = &Mode{
 			name := projects[selected].Name
 
 			if err := m.TaskManager.RemoveProject(name); err != nil {
-				m.Status = "Error removing project: " + err.Error()
+				m.setErrorStatus("removing project", err)
 				return m, nil
 			}
 
@@ -72,6 +91,32 @@ var ProjectsMode = &Mode{
 			setSelectedProjectByName(m, "")
 			return m, nil
 
+		case "a":
+			if len(m.Projects) == 0 {
+				return m, nil
+			}
+
+			projects := sortedProjectsByName(m.Projects)
+			selected := clampSelectedProjectIndex(m, len(projects))
+			project, err := m.TaskManager.SetProjectArchived(projects[selected].Name, !projects[selected].Archived)
+			if err != nil {
+				m.setErrorStatus("archiving project", err)
+				return m, nil
+			}
+
+			if err := m.LoadEntries(); err != nil {
+				m.Err = err
+				return m, nil
+			}
+
+			if project.Archived {
+				m.Status = "Project archived"
+			} else {
+				m.Status = "Project unarchived"
+			}
+			setSelectedProjectByName(m, project.Name)
+			return m, nil
+
 		case "k", "up":
 			if m.SelectedIdx > 0 {
 				m.SelectedIdx--
@@ -111,6 +156,9 @@ func renderProjectsContent(m *Model, availableHeight int) string {
 	projects := sortedProjectsByName(m.Projects)
 	selected := clampSelectedProjectIndex(m, len(projects))
 	headerHeight := 2
+	if m.ProjectDetail {
+		headerHeight += projectDetailHeight
+	}
 	maxRows := max(availableHeight-headerHeight, 1)
 	maxTop := max(len(projects)-maxRows, 0)
 	if m.ViewportTop < 0 {
@@ -140,8 +188,9 @@ func renderProjectsContent(m *Model, availableHeight int) string {
 	codeWidth++
 	categoryWidth++
 
-	headerText := fmt.Sprintf("%-*s %-*s %-*s", nameWidth, "Name", codeWidth, "Code", categoryWidth, "Category")
-	separatorText := strings.Repeat("-", max(lipgloss.Width(headerText), len("Name Code Category")))
+	archivedWidth := len("Archived")
+	headerText := fmt.Sprintf("%-*s %-*s %-*s %-*s %s", nameWidth, "Name", codeWidth, "Code", categoryWidth, "Category", archivedWidth, "Archived", "Budget")
+	separatorText := strings.Repeat("-", max(lipgloss.Width(headerText)+progressBarWidth, len("Name Code Category Archived Budget")))
 
 	var output strings.Builder
 	output.WriteString(m.Styles.Header.Render(headerText))
@@ -149,18 +198,37 @@ func renderProjectsContent(m *Model, availableHeight int) string {
 	output.WriteString(m.Styles.Header.Render(separatorText))
 	output.WriteString("\n")
 
+	now := time.Now()
 	end := min(m.ViewportTop+maxRows, len(projects))
 	for i := m.ViewportTop; i < end; i++ {
 		project := projects[i]
-		row := fmt.Sprintf("%-*s %-*s %-*s", nameWidth, project.Name, codeWidth, project.Code, categoryWidth, project.Category)
+		archived := ""
+		if project.Archived {
+			archived = "yes"
+		}
+		budget, overBudget := renderBudgetBar(m, project, now)
+		row := fmt.Sprintf("%-*s %-*s %-*s %-*s %s", nameWidth, project.Name, codeWidth, project.Code, categoryWidth, project.Category, archivedWidth, archived, budget)
 		if i == selected {
 			output.WriteString(lipgloss.NewStyle().Bold(true).Reverse(true).Render(row))
+		} else if overBudget {
+			output.WriteString(overBudgetStyle.Render(row))
+		} else if project.Archived {
+			output.WriteString(archivedProjectStyle.Render(row))
 		} else {
 			output.WriteString(m.Styles.Unselected.Render(row))
 		}
 		output.WriteString("\n")
 	}
 
+	if m.ProjectDetail {
+		output.WriteString(m.Styles.Header.Render(separatorText))
+		output.WriteString("\n")
+		for _, line := range renderProjectDetail(m, projects[selected], now) {
+			output.WriteString(line)
+			output.WriteString("\n")
+		}
+	}
+
 	return output.String()
 }
 
@@ -217,5 +285,8 @@ func projectVisibleRows(m *Model) int {
 	statusBarHeight := 1
 	availableHeight := max(m.Height-statusBarHeight, 1)
 	headerHeight := 2
+	if m.ProjectDetail {
+		headerHeight += projectDetailHeight
+	}
 	return max(availableHeight-headerHeight, 1)
 }
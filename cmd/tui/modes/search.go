@@ -35,7 +35,17 @@ func entryMatchesSearchQuery(entry models.TimeEntry, query string) bool {
 	project := strings.ToLower(entry.Project)
 	title := strings.ToLower(entry.Title)
 
-	return strings.Contains(project, normalizedQuery) || strings.Contains(title, normalizedQuery)
+	if strings.Contains(project, normalizedQuery) || strings.Contains(title, normalizedQuery) {
+		return true
+	}
+
+	for _, tag := range entry.Tags {
+		if strings.Contains(strings.ToLower(tag), normalizedQuery) {
+			return true
+		}
+	}
+
+	return false
 }
 
 func applySearch(m *Model) {
@@ -1,6 +1,7 @@
 package modes
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -106,6 +107,12 @@ func TestEntryMatchesSearchQuery(t *testing.T) {
 			query:    "frontend",
 			expected: false,
 		},
+		{
+			name:     "matches tag case-insensitive substring",
+			entry:    models.TimeEntry{Project: "Ops", Title: "Sync", Tags: []string{"Meeting"}},
+			query:    "meet",
+			expected: true,
+		},
 		{
 			name:     "empty query matches all entries",
 			entry:    models.TimeEntry{Project: "Any", Title: "Task"},
@@ -365,7 +372,7 @@ func TestEscWhileSearchActiveClearsSearchAndRestoresFullList(t *testing.T) {
 		if visible.SourceIndex != i {
 			t.Fatalf("FilteredEntries[%d].SourceIndex = %d, expected %d", i, visible.SourceIndex, i)
 		}
-		if visible.Entry != updatedModel.Entries[i] {
+		if !reflect.DeepEqual(visible.Entry, updatedModel.Entries[i]) {
 			t.Fatalf("FilteredEntries[%d].Entry = %+v, expected %+v", i, visible.Entry, updatedModel.Entries[i])
 		}
 	}
@@ -628,3 +635,34 @@ func TestSwitchModeBackToListPreservesActiveFilter(t *testing.T) {
 		t.Fatalf("SelectedIdx = %d, expected %d", m.SelectedIdx, 2)
 	}
 }
+
+func TestLoadEntriesKeepsFilteredSelectionOnTheSameEntry(t *testing.T) {
+	storage := utils.NewMemoryStorage()
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	if err := storage.Save([]models.TimeEntry{
+		{Start: start, Project: "Backend", Title: "Build API"},
+		{Start: start.Add(time.Hour), Project: "Frontend", Title: "Review"},
+		{Start: start.Add(3 * time.Hour), Project: "Backend", Title: "Deploy"},
+		{Start: start.Add(4 * time.Hour), Project: "Backend", Title: "Ship"},
+	}); err != nil {
+		t.Fatalf("failed to seed entries: %v", err)
+	}
+
+	m := &Model{Storage: storage, TaskManager: utils.NewTaskManager(storage), SearchAppliedQuery: "backend"}
+	if err := m.LoadEntries(); err != nil {
+		t.Fatalf("LoadEntries failed: %v", err)
+	}
+	m.SelectedIdx = 2
+
+	// Splitting an earlier entry shifts the selected entry's index by one
+	if _, err := m.TaskManager.SplitEntry(1, start.Add(2*time.Hour), "", ""); err != nil {
+		t.Fatalf("SplitEntry failed: %v", err)
+	}
+	if err := m.LoadEntries(); err != nil {
+		t.Fatalf("LoadEntries failed: %v", err)
+	}
+
+	if got := m.Entries[m.SelectedIdx]; got.Title != "Deploy" {
+		t.Fatalf("expected the selection to stay on the same entry, got %+v", got)
+	}
+}
@@ -0,0 +1,90 @@
+package modes
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"time-tracker/utils"
+)
+
+// SplitState holds the entry being split and the typed split time
+type SplitState struct {
+	SplittingIdx int
+	Draft        string
+}
+
+// SplitMode asks where to split an entry in two
+var SplitMode = &Mode{
+	Name: "split",
+	KeyBindings: []KeyBinding{
+		{Keys: "Enter", Label: "SPLIT", Description: "Split at the typed time and edit the second half"},
+		{Keys: "ctrl+u", Label: "CLEAR", Description: "Clear the time"},
+		{Keys: "Esc", Label: "CANCEL", Description: "Back to the list"},
+	},
+	HandleKeyMsg: func(m *Model, msg tea.KeyMsg) (*Model, tea.Cmd) {
+		switch msg.String() {
+		case "esc":
+			m.CurrentMode = m.ListMode
+			m.Status = ""
+			return m, nil
+
+		case "enter":
+			at, err := parseTimeDraft(m.SplitState.Draft)
+			if err != nil {
+				m.Status = "Error: " + err.Error()
+				return m, nil
+			}
+
+			idx := m.SplitState.SplittingIdx
+			second, err := m.TaskManager.SplitEntry(idx, at, "", "")
+			if err != nil {
+				m.setErrorStatus("splitting entry", err)
+				return m, nil
+			}
+			if err := m.LoadEntries(); err != nil {
+				m.Err = err
+				return m, nil
+			}
+
+			// The second half usually belongs to another project or title, so edit it right away
+			m.SelectedIdx = idx + 1
+			openEditMode(m, *second, idx+1)
+			m.Status = "Entry split - edit the second half or press Esc to keep it"
+			return m, nil
+		}
+
+		m.SplitState.Draft = editTimeDraft(m.SplitState.Draft, msg)
+		return m, nil
+	},
+	RenderContent: func(m *Model, _ int) string {
+		idx := m.SplitState.SplittingIdx
+		if idx < 0 || idx >= len(m.Entries) {
+			return "Invalid entry"
+		}
+		entry := m.Entries[idx]
+
+		end := "running"
+		if entry.End != nil {
+			end = utils.FormatDateTime(*entry.End)
+		}
+
+		var out strings.Builder
+		out.WriteString(m.Styles.Title.Render("Split Entry") + "\n\n")
+		out.WriteString(labelStyle.Render("Project: ") + valueStyle.Render(entry.Project) + "\n")
+		out.WriteString(labelStyle.Render("Title: ") + valueStyle.Render(entry.Title) + "\n")
+		out.WriteString(labelStyle.Render("Start: ") + valueStyle.Render(utils.FormatDateTime(entry.Start)) + "\n")
+		out.WriteString(labelStyle.Render("End: ") + valueStyle.Render(end) + "\n")
+		out.WriteString(renderTimeDraft(m, "Split at", m.SplitState.Draft))
+		return out.String()
+	},
+}
+
+// openSplitMode asks where to split the entry, prefilled with its start date
+func openSplitMode(m *Model, idx int) {
+	m.SplitState = SplitState{
+		SplittingIdx: idx,
+		Draft:        m.Entries[idx].Start.Format("2006-01-02") + " ",
+	}
+	m.CurrentMode = m.SplitMode
+	m.Status = ""
+}
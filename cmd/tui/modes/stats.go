@@ -68,12 +68,13 @@ var StatsMode = &Mode{
 		{Keys: "Tab", Label: "PROJECTS", Description: "Switch mode"},
 		{Keys: "k / ↑", Label: "UP", Description: "Move up"},
 		{Keys: "j / ↓", Label: "DOWN", Description: "Move down"},
+		{Keys: "r", Label: "ROLLUP", Description: "Roll sub-projects up a level"},
 		{Keys: "?", Label: "HELP", Description: "Toggle help"},
 		{Keys: "q / Esc", Label: "QUIT", Description: "Quit"},
 	},
 	HandleKeyMsg: func(m *Model, msg tea.KeyMsg) (*Model, tea.Cmd) {
 		// Get the rows (which include separators)
-		aggregated := utils.AggregateByProjectDate(m.Entries)
+		aggregated := aggregateStats(m)
 		rows := buildStatsRows(aggregated)
 
 		switch msg.String() {
@@ -89,6 +90,10 @@ var StatsMode = &Mode{
 			m.SwitchMode(m.ProjectsMode)
 			return m, nil
 
+		case "r":
+			cycleStatsDepth(m)
+			return m, nil
+
 		case "k", "up":
 			if m.ViewportTop > 0 {
 				m.ViewportTop--
@@ -117,7 +122,7 @@ var StatsMode = &Mode{
 // renderStatsContent renders the stats mode content
 func renderStatsContent(m *Model, availableHeight int) string {
 	// Aggregate entries by project and date
-	aggregated := utils.AggregateByProjectDate(m.Entries)
+	aggregated := aggregateStats(m)
 
 	if len(aggregated) == 0 {
 		emptyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Italic(true)
@@ -171,6 +176,39 @@ func renderStatsContent(m *Model, availableHeight int) string {
 	return header + content
 }
 
+// aggregateStats aggregates the entries by project and date at the selected depth
+func aggregateStats(m *Model) []utils.ProjectDateEntry {
+	aggregated := utils.AggregateByProjectDate(m.Entries)
+	return utils.RollUpProjectDates(aggregated, utils.NewProjectTree(m.Projects), m.StatsDepth)
+}
+
+// cycleStatsDepth rolls sub-projects up one more level, wrapping around after the top level
+func cycleStatsDepth(m *Model) {
+	levels := utils.NewProjectTree(m.Projects).Depth()
+	if levels <= 1 {
+		m.StatsDepth = 0
+		m.Status = "No sub-projects to roll up"
+		return
+	}
+
+	switch {
+	case m.StatsDepth == 0:
+		m.StatsDepth = levels - 1
+	case m.StatsDepth > 1:
+		m.StatsDepth--
+	default:
+		m.StatsDepth = 0
+	}
+
+	// Start at the most recent rows again, as the number of rows changed
+	m.ViewportTop = -1
+	if m.StatsDepth == 0 {
+		m.Status = "Showing sub-projects separately"
+	} else {
+		m.Status = fmt.Sprintf("Rolled up to depth %d", m.StatsDepth)
+	}
+}
+
 // buildStatsRows creates StatsRow entries with daily and weekly separators inserted
 func buildStatsRows(aggregated []utils.ProjectDateEntry) []StatsRow {
 	var rows []StatsRow
@@ -195,7 +233,7 @@ func buildStatsRows(aggregated []utils.ProjectDateEntry) []StatsRow {
 			}
 
 			// Then add week separator
-			prevWeekStart := utils.GetMondayOfWeek(aggregated[i-1].Date)
+			prevWeekStart := utils.GetWeekStart(aggregated[i-1].Date)
 			weekTotal := utils.GetWeeklyTotal(aggregated, prevWeekStart)
 			rows = append(rows, StatsWeeklySeparatorRow(prevWeekStart, int(weekTotal.Minutes())))
 
@@ -226,7 +264,7 @@ func buildStatsRows(aggregated []utils.ProjectDateEntry) []StatsRow {
 
 	// Add final week separator if there are entries
 	if len(aggregated) > 0 {
-		lastWeekStart := utils.GetMondayOfWeek(aggregated[len(aggregated)-1].Date)
+		lastWeekStart := utils.GetWeekStart(aggregated[len(aggregated)-1].Date)
 		weekTotal := utils.GetWeeklyTotal(aggregated, lastWeekStart)
 		rows = append(rows, StatsWeeklySeparatorRow(lastWeekStart, int(weekTotal.Minutes())))
 	}
@@ -157,3 +157,40 @@ func contains(str, substr string) bool {
 	}
 	return false
 }
+
+func TestCycleStatsDepthRollsUpSubProjects(t *testing.T) {
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	m := &Model{
+		Entries: []models.TimeEntry{
+			createTestEntry(start, timePtr(start.Add(time.Hour)), "Checkout", "Build"),
+			createTestEntry(start.Add(time.Hour), timePtr(start.Add(2*time.Hour)), "Website", "Review"),
+			createTestEntry(start.Add(2*time.Hour), timePtr(start.Add(3*time.Hour)), "Acme", "Call"),
+		},
+		Projects: []models.Project{
+			{Name: "Acme"},
+			{Name: "Website", Parent: "Acme"},
+			{Name: "Checkout", Parent: "Website"},
+		},
+	}
+
+	if got := aggregateStats(m); len(got) != 3 {
+		t.Fatalf("expected separate sub-projects, got %+v", got)
+	}
+
+	cycleStatsDepth(m)
+	got := aggregateStats(m)
+	if m.StatsDepth != 2 || len(got) != 2 || got[1].Project != "Acme/Website" || got[1].Duration != 2*time.Hour {
+		t.Fatalf("expected Checkout rolled into Acme/Website at depth 2, got depth %d: %+v", m.StatsDepth, got)
+	}
+
+	cycleStatsDepth(m)
+	got = aggregateStats(m)
+	if m.StatsDepth != 1 || len(got) != 1 || got[0].Project != "Acme" || got[0].Duration != 3*time.Hour {
+		t.Fatalf("expected everything rolled into Acme at depth 1, got depth %d: %+v", m.StatsDepth, got)
+	}
+
+	cycleStatsDepth(m)
+	if m.StatsDepth != 0 || m.Status != "Showing sub-projects separately" {
+		t.Fatalf("expected to cycle back to separate sub-projects, got depth %d (%q)", m.StatsDepth, m.Status)
+	}
+}
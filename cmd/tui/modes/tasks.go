@@ -0,0 +1,207 @@
+package modes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"time-tracker/models"
+	"time-tracker/utils"
+)
+
+// TasksMode is the task list view mode.
+var TasksMode = &Mode{
+	Name: "tasks",
+	KeyBindings: []KeyBinding{
+		{Keys: "Tab", Label: "LIST", Description: "Switch mode"},
+		{Keys: "Enter / s", Label: "START", Description: "Start tracking the task"},
+		{Keys: "x", Label: "DONE", Description: "Mark task as done"},
+		{Keys: "u", Label: "UNDO", Description: "Undo last change"},
+		{Keys: "ctrl+r", Label: "REDO", Description: "Redo undone change"},
+		{Keys: "k / ↑", Label: "UP", Description: "Scroll up"},
+		{Keys: "j / ↓", Label: "DOWN", Description: "Scroll down"},
+		{Keys: "?", Label: "HELP", Description: "Toggle help"},
+		{Keys: "q / Esc", Label: "QUIT", Description: "Quit"},
+	},
+	HandleKeyMsg: func(m *Model, msg tea.KeyMsg) (*Model, tea.Cmd) {
+		switch msg.String() {
+		case "u":
+			stepHistory(m, false)
+			return m, nil
+
+		case "ctrl+r":
+			stepHistory(m, true)
+			return m, nil
+
+		case "?":
+			m.PreviousMode = m.CurrentMode
+			m.CurrentMode = m.HelpMode
+			return m, nil
+
+		case "q", "esc":
+			return m, tea.Quit
+
+		case "tab":
+			m.SwitchMode(m.ListMode)
+			return m, nil
+
+		case "enter", "s":
+			tasks := sortedTasksForDisplay(m.Tasks)
+			if len(tasks) == 0 {
+				return m, nil
+			}
+			task := tasks[clampSelectedTaskIndex(m, len(tasks))]
+
+			entry, err := m.TaskManager.StartTaskAt(task.ID, time.Now())
+			if err != nil {
+				m.setErrorStatus("starting task", err)
+				return m, nil
+			}
+			if err := m.LoadEntries(); err != nil {
+				m.Err = err
+				return m, nil
+			}
+
+			m.Status = fmt.Sprintf("Started %s: %s", entry.Project, entry.Title)
+			warnIfOverBudget(m, entry.Project)
+			setSelectedTaskByID(m, task.ID)
+			return m, nil
+
+		case "x":
+			tasks := sortedTasksForDisplay(m.Tasks)
+			if len(tasks) == 0 {
+				return m, nil
+			}
+			task := tasks[clampSelectedTaskIndex(m, len(tasks))]
+
+			if _, err := m.TaskManager.CompleteTask(task.ID); err != nil {
+				m.setErrorStatus("completing task", err)
+				return m, nil
+			}
+			if err := m.LoadEntries(); err != nil {
+				m.Err = err
+				return m, nil
+			}
+
+			m.Status = "Task done"
+			setSelectedTaskByID(m, task.ID)
+			return m, nil
+
+		case "k", "up":
+			if m.SelectedIdx > 0 {
+				m.SelectedIdx--
+			}
+			if m.ViewportTop > 0 && m.SelectedIdx < m.ViewportTop {
+				m.ViewportTop--
+			}
+			m.Status = ""
+			return m, nil
+
+		case "j", "down":
+			if m.SelectedIdx < len(m.Tasks)-1 {
+				m.SelectedIdx++
+			}
+			if m.SelectedIdx >= m.ViewportTop+projectVisibleRows(m) {
+				m.ViewportTop++
+			}
+			m.Status = ""
+			return m, nil
+		}
+
+		return m, nil
+	},
+	RenderContent: func(m *Model, availableHeight int) string {
+		return renderTasksContent(m, availableHeight)
+	},
+}
+
+func renderTasksContent(m *Model, availableHeight int) string {
+	if len(m.Tasks) == 0 {
+		emptyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Italic(true)
+		return emptyStyle.Render("No tasks found. Add one with 'time-tracker task add <project> <name>'.\n")
+	}
+
+	tasks := sortedTasksForDisplay(m.Tasks)
+	selected := clampSelectedTaskIndex(m, len(tasks))
+	headerHeight := 2
+	maxRows := max(availableHeight-headerHeight, 1)
+	maxTop := max(len(tasks)-maxRows, 0)
+	if m.ViewportTop < 0 {
+		m.ViewportTop = 0
+	}
+	if m.ViewportTop > maxTop {
+		m.ViewportTop = maxTop
+	}
+
+	idWidth := len("ID")
+	projectWidth := len("Project")
+	nameWidth := len("Task")
+	statusWidth := len("Status")
+	timeWidth := len("Time")
+	for _, task := range tasks {
+		idWidth = max(idWidth, len(task.ID))
+		projectWidth = max(projectWidth, len(task.Project))
+		nameWidth = max(nameWidth, len(task.Name))
+		statusWidth = max(statusWidth, len(utils.FormatTaskStatus(task.Status)))
+		timeWidth = max(timeWidth, len(utils.FormatDuration(task.AccumulatedTime)))
+	}
+
+	format := fmt.Sprintf("%%-%ds %%-%ds %%-%ds %%-%ds %%%ds", idWidth+1, projectWidth+1, nameWidth+1, statusWidth+1, timeWidth)
+	headerText := fmt.Sprintf(format, "ID", "Project", "Task", "Status", "Time")
+
+	var output strings.Builder
+	output.WriteString(m.Styles.Header.Render(headerText))
+	output.WriteString("\n")
+	output.WriteString(m.Styles.Header.Render(strings.Repeat("-", lipgloss.Width(headerText))))
+	output.WriteString("\n")
+
+	end := min(m.ViewportTop+maxRows, len(tasks))
+	for i := m.ViewportTop; i < end; i++ {
+		task := tasks[i]
+		row := fmt.Sprintf(format, task.ID, task.Project, task.Name, utils.FormatTaskStatus(task.Status), utils.FormatDuration(task.AccumulatedTime))
+		switch {
+		case i == selected:
+			output.WriteString(lipgloss.NewStyle().Bold(true).Reverse(true).Render(row))
+		case task.Status == models.StatusActive:
+			output.WriteString(m.Styles.Running.Render(row))
+		case task.IsCompleted():
+			output.WriteString(m.Styles.Gap.Render(row))
+		default:
+			output.WriteString(m.Styles.Unselected.Render(row))
+		}
+		output.WriteString("\n")
+	}
+
+	return output.String()
+}
+
+// sortedTasksForDisplay lists open tasks first, then done tasks, each in ID order
+func sortedTasksForDisplay(tasks []models.Task) []models.Task {
+	sorted := append([]models.Task(nil), tasks...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return !sorted[i].IsCompleted() && sorted[j].IsCompleted()
+	})
+	return sorted
+}
+
+func clampSelectedTaskIndex(m *Model, taskCount int) int {
+	if taskCount <= 0 {
+		m.SelectedIdx = 0
+		return 0
+	}
+	m.SelectedIdx = min(max(m.SelectedIdx, 0), taskCount-1)
+	return m.SelectedIdx
+}
+
+func setSelectedTaskByID(m *Model, id string) {
+	for i, task := range sortedTasksForDisplay(m.Tasks) {
+		if task.ID == id {
+			m.SelectedIdx = i
+			return
+		}
+	}
+	clampSelectedTaskIndex(m, len(m.Tasks))
+}
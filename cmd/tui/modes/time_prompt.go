@@ -0,0 +1,49 @@
+package modes
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"time-tracker/utils"
+)
+
+// timeDraftLayout is how prompts prefill times; ParseTimeExpression accepts it
+const timeDraftLayout = "2006-01-02 15:04"
+
+// timePromptHint lists the forms a time prompt accepts
+const timePromptHint = `e.g. "18:00", "yesterday 17:30", "2025-03-10 18:00", or "10h ago"`
+
+// editTimeDraft applies a key press to the text of a time prompt
+func editTimeDraft(draft string, msg tea.KeyMsg) string {
+	switch msg.String() {
+	case "backspace", "ctrl+h":
+		if draft != "" {
+			runes := []rune(draft)
+			return string(runes[:len(runes)-1])
+		}
+		return draft
+	case "ctrl+u":
+		return ""
+	}
+	return draft + string(msg.Runes)
+}
+
+// parseTimeDraft parses the text of a time prompt, which must not be in the future
+func parseTimeDraft(draft string) (time.Time, error) {
+	now := time.Now()
+	at, err := utils.ParseTimeExpression(draft, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if at.After(now) {
+		return time.Time{}, fmt.Errorf("time %s is in the future", utils.FormatDateTime(at))
+	}
+	return at, nil
+}
+
+// renderTimeDraft renders the input line of a time prompt with its hint
+func renderTimeDraft(m *Model, label, draft string) string {
+	return "\n" + m.Styles.InputFocused.Render(label+": "+draft+"█") + "\n" +
+		"\n" + labelStyle.Render(timePromptHint) + "\n"
+}
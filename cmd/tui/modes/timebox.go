@@ -0,0 +1,60 @@
+package modes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"time-tracker/utils"
+)
+
+var timeboxStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("14")).Bold(true)
+
+// CheckTimebox applies the expiry of the running entry's timebox and remembers it for the countdown
+func (m *Model) CheckTimebox(now time.Time) {
+	if m.Timeboxes == nil {
+		return
+	}
+
+	changes, err := m.TaskManager.ExpireTimebox(m.Timeboxes, now)
+	if err != nil {
+		m.setErrorStatus("applying timebox", err)
+	}
+	if len(changes) > 0 {
+		m.Status = changes[len(changes)-1].String()
+		if err := m.LoadEntries(); err != nil {
+			m.Err = err
+			return
+		}
+	}
+
+	// The countdown is best effort and simply disappears when the box cannot be read
+	m.Timebox, _ = utils.ActiveTimebox(m.Timeboxes, m.Entries)
+}
+
+// TimeboxExpired reports whether the countdown has run out, so the timebox has to be applied
+func (m *Model) TimeboxExpired(now time.Time) bool {
+	return m.Timebox != nil && !now.Before(m.Timebox.Until)
+}
+
+// renderTimeboxBanner shows the countdown of the running entry's timebox above the list
+func renderTimeboxBanner(m *Model, box *utils.Timebox) string {
+	text := fmt.Sprintf("%s: %s left", box.Label(), formatCountdown(box.Remaining(time.Now())))
+	if box.Pomodoro != nil {
+		text += fmt.Sprintf(" - %s: %s", box.Pomodoro.Project, box.Pomodoro.Title)
+	}
+	style := timeboxStyle
+	if m.Width > 0 {
+		style = style.MaxWidth(m.Width)
+	}
+	return style.Render(text) + "\n"
+}
+
+// formatCountdown formats the time left as "MM:SS", or "H:MM:SS" from an hour on
+func formatCountdown(d time.Duration) string {
+	seconds := int(d.Round(time.Second).Seconds())
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
@@ -1,6 +1,9 @@
 package modes
 
 import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -31,6 +34,7 @@ const (
 	InputDay
 	InputHour
 	InputMinute
+	InputTags
 )
 
 // Styles defines the visual styling for different UI elements
@@ -55,6 +59,7 @@ type Model struct {
 	TaskManager *utils.TaskManager // Task management operations
 	Entries     []models.TimeEntry // Loaded time entries
 	Projects    []models.Project   // Loaded project metadata
+	Tasks       []models.Task      // Loaded tasks with their status and accumulated time
 	SelectedIdx int                // Index of currently selected entry (list mode)
 	ViewportTop int                // Index of first visible row (list mode) or viewport scroll position (stats mode)
 	Err         error              // Error state
@@ -63,12 +68,33 @@ type Model struct {
 	Width       int                // Terminal width
 	Height      int                // Terminal height
 
+	// Forgotten entry detection
+	ForgottenAfter     time.Duration       // How long an entry may run before it counts as forgotten (0 disables)
+	Activity           utils.ActivityStore // Last activity, suggested as the end of a forgotten entry (nil disables)
+	activityRecordedAt time.Time           // When a key press was last recorded as activity
+
+	CompactThreshold time.Duration // Entries and gaps shorter than this are dropped by compact
+
+	Schedule *utils.Schedule // Expected hours per weekday, shown as progress in the status bar (nil disables)
+	Progress string          // Progress towards the schedule as of the last reload, shown in the status bar
+
+	StatsDepth int // Depth sub-projects are rolled up to in stats mode (0 keeps them separate)
+
+	ProjectDetail bool // Whether projects mode shows the detail pane of the selected project
+
+	// Timeboxed entries and pomodoro cycles
+	Timeboxes utils.TimeboxStore // Timebox of the running entry, applied as it expires (nil disables)
+	Timebox   *utils.Timebox     // Timebox of the running entry as of the last check, for the countdown
+
 	// Mode state
 	CurrentMode       *Mode             // Current TUI mode
 	PreviousMode      *Mode             // Previous mode (used for help context)
-	Inputs            []textinput.Model // Text inputs for project, title, year, month, day, hour, minute
-	FocusIndex        int               // Currently focused input index
-	ProjectInputs     []textinput.Model // Text inputs for project metadata form (name, code, category)
+	Inputs            []textinput.Model // Text inputs for project, title, year, month, day, hour, minute, tags
+	FocusIndex        int               // Currently focused input index (len(Inputs) focuses the notes or end field)
+	NotesInput        *textarea.Model   // Multi-line notes input for the edit form (nil disables notes)
+	EndInput          *textinput.Model  // End time input for the add form (nil disables adding past entries)
+	AtInput           *textinput.Model  // Free-text start time such as "10m ago", overriding the date and time fields (nil disables it)
+	ProjectInputs     []textinput.Model // Text inputs for project metadata form (name, code, category, rate, currency, billable)
 	ProjectFocusIndex int               // Currently focused metadata input
 
 	// Loading state
@@ -79,14 +105,18 @@ type Model struct {
 	HelpMode        *Mode
 	StatsMode       *Mode
 	ProjectsMode    *Mode
+	TasksMode       *Mode
 	NewMode         *Mode
 	EditMode        *Mode
 	ResumeMode      *Mode
+	AddMode         *Mode
 	ConfirmMode     *Mode
 	ProjectNewMode  *Mode
 	ProjectEditMode *Mode
+	ForgottenMode   *Mode
+	SplitMode       *Mode
 
-	// Form state for new/edit/resume modes
+	// Form state for new/edit/resume/add modes
 	FormState FormState
 
 	// Form state for project metadata add/edit modes
@@ -95,6 +125,12 @@ type Model struct {
 	// Confirm state for delete confirmation
 	ConfirmState ConfirmState
 
+	// End time state for a forgotten running entry
+	ForgottenState ForgottenState
+
+	// Split time state for splitting an entry
+	SplitState SplitState
+
 	// Search state for list filtering
 	SearchActive       bool
 	SearchInputFocused bool
@@ -106,7 +142,15 @@ type Model struct {
 // LoadEntries loads time entries from storage
 func (m *Model) LoadEntries() error {
 	previousSelection := m.SelectedIdx
+	selectedID := ""
+	if previousSelection >= 0 && previousSelection < len(m.Entries) {
+		selectedID = m.Entries[previousSelection].ID
+	}
 
+	// The data loaded here is what the user acts on, so later saves are checked against it
+	if baseline, ok := m.Storage.(interface{ ResetBaseline() }); ok {
+		baseline.ResetBaseline()
+	}
 	entries, err := m.Storage.Load()
 	if err != nil {
 		return err
@@ -115,10 +159,24 @@ func (m *Model) LoadEntries() error {
 	if err != nil {
 		return err
 	}
+	tasks, err := m.Storage.LoadTasks()
+	if err != nil {
+		return err
+	}
 
 	m.Entries = entries
 	m.Projects = projects
+	m.Tasks = utils.SummarizeTasks(tasks, entries)
+	m.Progress = m.TargetProgress(time.Now())
+	if m.Timeboxes != nil {
+		// The countdown is best effort and simply disappears when the box cannot be read
+		m.Timebox, _ = utils.ActiveTimebox(m.Timeboxes, entries)
+	}
 	m.SelectedIdx = previousSelection
+	// Entries before the selection may have been added or removed, so follow its ID
+	if idx, err := utils.EntryIndexByID(entries, selectedID); err == nil {
+		m.SelectedIdx = idx
+	}
 
 	if m.SearchAppliedQuery != "" {
 		m.FilteredEntries = filterVisibleEntries(m.Entries, m.SearchAppliedQuery)
@@ -138,6 +196,19 @@ func (m *Model) LoadEntries() error {
 	return nil
 }
 
+// setErrorStatus reports a failed action, reloading the entries after a conflict
+func (m *Model) setErrorStatus(action string, err error) {
+	if !utils.IsConflict(err) {
+		m.Status = "Error " + action + ": " + err.Error()
+		return
+	}
+
+	m.Status = "Error " + action + ": data file was changed by another process, reloaded - please try again"
+	if err := m.LoadEntries(); err != nil {
+		m.Err = err
+	}
+}
+
 // GetColumnWidths calculates the width of each column based on content
 func (m *Model) GetColumnWidths() (int, int, int, int, int) {
 	// Minimum widths for headers
@@ -149,14 +220,14 @@ func (m *Model) GetColumnWidths() (int, int, int, int, int) {
 
 	// Measure content
 	for _, entry := range m.Entries {
-		startStr := entry.Start.Format("2006-01-02 15:04")
+		startStr := utils.FormatDateTime(entry.Start)
 		if len(startStr) > startWidth {
 			startWidth = len(startStr)
 		}
 
 		endStr := "running"
 		if entry.End != nil {
-			endStr = entry.End.Format("2006-01-02 15:04")
+			endStr = utils.FormatDateTime(*entry.End)
 		}
 		if len(endStr) > endWidth {
 			endWidth = len(endStr)
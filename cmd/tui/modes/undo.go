@@ -0,0 +1,22 @@
+package modes
+
+// stepHistory undoes (or redoes) the most recent change and reloads the entries
+func stepHistory(m *Model, redo bool) {
+	step, action, done := m.TaskManager.Undo, "undoing", "Undid"
+	if redo {
+		step, action, done = m.TaskManager.Redo, "redoing", "Redid"
+	}
+
+	record, err := step()
+	if err != nil {
+		m.setErrorStatus(action, err)
+		return
+	}
+
+	if err := m.LoadEntries(); err != nil {
+		m.Err = err
+		return
+	}
+
+	m.Status = done + ": " + record.Description
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"time-tracker/utils"
+)
+
+type historyStepper interface {
+	Undo() (*utils.HistoryRecord, error)
+	Redo() (*utils.HistoryRecord, error)
+}
+
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Undo the most recent change to entries or projects",
+	Long: `Undo the most recent change made by track, note, project, or the TUI.
+
+The last 50 changes are kept. A change can only be undone while the entries and
+projects it touched are unchanged since.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		storage, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		return undoChange(newTaskManager(storage), os.Stdout)
+	},
+}
+
+var redoCmd = &cobra.Command{
+	Use:   "redo",
+	Short: "Redo the most recently undone change",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		storage, err := openStorage()
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+
+		return redoChange(newTaskManager(storage), os.Stdout)
+	},
+}
+
+func undoChange(history historyStepper, out io.Writer) error {
+	record, err := history.Undo()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Undid: %s\n", record.Description)
+	return nil
+}
+
+func redoChange(history historyStepper, out io.Writer) error {
+	record, err := history.Redo()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Redid: %s\n", record.Description)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(undoCmd)
+	rootCmd.AddCommand(redoCmd)
+}
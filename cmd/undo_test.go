@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"time-tracker/utils"
+)
+
+type fakeHistoryStepper struct {
+	record *utils.HistoryRecord
+	err    error
+}
+
+func (f fakeHistoryStepper) Undo() (*utils.HistoryRecord, error) {
+	return f.record, f.err
+}
+
+func (f fakeHistoryStepper) Redo() (*utils.HistoryRecord, error) {
+	return f.record, f.err
+}
+
+func TestUndoRedoChange_PrintDescription(t *testing.T) {
+	history := fakeHistoryStepper{record: &utils.HistoryRecord{Description: "delete entry"}}
+
+	var out bytes.Buffer
+	if err := undoChange(history, &out); err != nil {
+		t.Fatalf("undoChange returned error: %v", err)
+	}
+	if err := redoChange(history, &out); err != nil {
+		t.Fatalf("redoChange returned error: %v", err)
+	}
+
+	if out.String() != "Undid: delete entry\nRedid: delete entry\n" {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestUndoChange_ReturnsErrors(t *testing.T) {
+	var out bytes.Buffer
+	err := undoChange(fakeHistoryStepper{err: utils.ErrNothingToUndo}, &out)
+	if err == nil || !strings.Contains(err.Error(), "nothing to undo") {
+		t.Fatalf("expected nothing to undo error, got: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("expected no output, got %q", out.String())
+	}
+}
@@ -3,8 +3,16 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 )
 
+// StorageEnvVar selects the storage backend: "json" (default), "sqlite", or "journal"
+const StorageEnvVar = "TIME_TRACKER_STORAGE"
+
+// BackupsEnvVar sets how many backups of data.json are kept (default 10, 0 disables backups)
+const BackupsEnvVar = "TIME_TRACKER_BACKUPS"
+
 var ConfigPath = func() string {
 	configDir, err := os.UserConfigDir()
 	if err != nil {
@@ -20,7 +28,64 @@ var ConfigPath = func() string {
 	return filepath.Join(configDir, "time-tracker")
 }()
 
-// DataFilePath returns the path to the data.json file
+// DataFilePath returns the path to the data file: data.json in ConfigPath unless configured otherwise
 func DataFilePath() string {
-	return filepath.Join(ConfigPath, "data.json")
+	return resolvedValue("data-file")
+}
+
+// DatabaseFilePath returns the SQLite database path next to the data file, e.g. work.db for work.json
+func DatabaseFilePath() string {
+	dir, name := dataFileParts()
+	return filepath.Join(dir, name+".db")
+}
+
+// JournalFilePath returns the event journal path next to the data file, like DatabaseFilePath
+func JournalFilePath() string {
+	dir, name := dataFileParts()
+	return filepath.Join(dir, name+".journal")
+}
+
+// HistoryFilePath returns the path to the undo/redo history shared by the CLI and the TUI
+func HistoryFilePath() string {
+	return sideFilePath("history.json")
+}
+
+// ActivityFilePath returns the path to the last activity time used to end forgotten entries
+func ActivityFilePath() string {
+	return sideFilePath("activity.json")
+}
+
+// TimeboxFilePath returns the path to the timebox or pomodoro cycle of the running entry
+func TimeboxFilePath() string {
+	return sideFilePath("timebox.json")
+}
+
+// dataFileParts splits the data file path into its directory and its name without extension
+func dataFileParts() (dir, name string) {
+	path := DataFilePath()
+	base := filepath.Base(path)
+	return filepath.Dir(path), strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// sideFilePath returns the path of file next to the data file, prefixed by its name unless it is data.json
+func sideFilePath(file string) string {
+	dir, name := dataFileParts()
+	if name == "data" {
+		return filepath.Join(dir, file)
+	}
+	return filepath.Join(dir, name+"."+file)
+}
+
+// StorageBackend returns the configured storage backend name, defaulting to "json"
+func StorageBackend() string {
+	return resolvedValue("storage")
+}
+
+// MaxBackups returns the configured number of data file backups to keep
+func MaxBackups() (int, error) {
+	resolved, err := Resolve("backups")
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(resolved.Value)
 }
@@ -0,0 +1,661 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"time-tracker/utils"
+)
+
+// Setting sources, from highest to lowest precedence
+const (
+	SourceFlag    = "flag"
+	SourceEnv     = "env"
+	SourceFile    = "file"
+	SourceDefault = "default"
+)
+
+// Setting describes a single configuration key
+type Setting struct {
+	Key         string
+	EnvVar      string
+	Description string
+	numeric     bool
+	defaultFunc func() string
+	normalize   func(string) (string, error)
+}
+
+// Default returns the value used when the setting is not configured anywhere
+func (s Setting) Default() string {
+	return s.defaultFunc()
+}
+
+// ResolvedSetting is a setting's effective value and where it came from
+type ResolvedSetting struct {
+	Setting
+	Value  string
+	Source string
+}
+
+// Settings lists every configuration key, in the order `config list` shows them
+var Settings = []Setting{
+	{
+		Key:         "data-file",
+		EnvVar:      "TIME_TRACKER_DATA_FILE",
+		Description: "path of the data file used by the json storage backend; the database, journal, and history are kept next to it",
+		defaultFunc: func() string { return filepath.Join(ConfigPath, "data.json") },
+		normalize:   normalizePath,
+	},
+	{
+		Key:         "storage",
+		EnvVar:      StorageEnvVar,
+		Description: `storage backend: "json", "sqlite", or "journal"`,
+		defaultFunc: func() string { return "json" },
+		normalize:   oneOf("json", "sqlite", "journal"),
+	},
+	{
+		Key:         "backups",
+		EnvVar:      BackupsEnvVar,
+		Description: "number of data file backups to keep (0 disables backups)",
+		numeric:     true,
+		defaultFunc: func() string { return strconv.Itoa(utils.DefaultMaxBackups) },
+		normalize:   integer(0),
+	},
+	{
+		Key:         "week-start",
+		EnvVar:      "TIME_TRACKER_WEEK_START",
+		Description: "first day of the week in weekly stats",
+		defaultFunc: func() string { return "monday" },
+		normalize:   normalizeWeekday,
+	},
+	{
+		Key:         "export-days",
+		EnvVar:      "TIME_TRACKER_EXPORT_DAYS",
+		Description: "default number of past days included by export",
+		numeric:     true,
+		defaultFunc: func() string { return "7" },
+		normalize:   integer(1),
+	},
+	{
+		Key:         "stats-rows",
+		EnvVar:      "TIME_TRACKER_STATS_ROWS",
+		Description: "default number of days shown by stats",
+		numeric:     true,
+		defaultFunc: func() string { return "14" },
+		normalize:   integer(1),
+	},
+	{
+		Key:         "forgotten-after",
+		EnvVar:      "TIME_TRACKER_FORGOTTEN_AFTER",
+		Description: "hours after which a running entry counts as forgotten (0 disables the warning)",
+		numeric:     true,
+		defaultFunc: func() string { return "10" },
+		normalize:   integer(0),
+	},
+	{
+		Key:         "compact-threshold",
+		EnvVar:      "TIME_TRACKER_COMPACT_THRESHOLD",
+		Description: "seconds below which compact drops an entry or gap",
+		numeric:     true,
+		defaultFunc: func() string { return "60" },
+		normalize:   integer(0),
+	},
+	{
+		Key:         "pomodoro-work",
+		EnvVar:      "TIME_TRACKER_POMODORO_WORK",
+		Description: "minutes of work in each pomodoro",
+		numeric:     true,
+		defaultFunc: func() string { return "25" },
+		normalize:   integer(1),
+	},
+	{
+		Key:         "pomodoro-break",
+		EnvVar:      "TIME_TRACKER_POMODORO_BREAK",
+		Description: "minutes of the break after each pomodoro",
+		numeric:     true,
+		defaultFunc: func() string { return "5" },
+		normalize:   integer(1),
+	},
+	{
+		Key:         "pomodoro-long-break",
+		EnvVar:      "TIME_TRACKER_POMODORO_LONG_BREAK",
+		Description: "minutes of the break that ends a pomodoro cycle (0 ends it after the last pomodoro)",
+		numeric:     true,
+		defaultFunc: func() string { return "15" },
+		normalize:   integer(0),
+	},
+	{
+		Key:         "pomodoro-rounds",
+		EnvVar:      "TIME_TRACKER_POMODORO_ROUNDS",
+		Description: "pomodoros in a cycle",
+		numeric:     true,
+		defaultFunc: func() string { return "4" },
+		normalize:   integer(1),
+	},
+	{
+		Key:         "target-hours",
+		EnvVar:      "TIME_TRACKER_TARGET_HOURS",
+		Description: `expected hours per weekday for balance and stats, e.g. "mon-thu=8,fri=6" (default: none)`,
+		defaultFunc: func() string { return "" },
+		normalize:   normalizeTargetHours,
+	},
+	{
+		Key:         "holidays",
+		EnvVar:      "TIME_TRACKER_HOLIDAYS",
+		Description: `public holidays without expected hours, e.g. "2026-12-25,2026-12-26"`,
+		defaultFunc: func() string { return "" },
+		normalize:   normalizeDates,
+	},
+	{
+		Key:         "vacation",
+		EnvVar:      "TIME_TRACKER_VACATION",
+		Description: `vacation days without expected hours, e.g. "2026-08-03..2026-08-14,2026-10-30"`,
+		defaultFunc: func() string { return "" },
+		normalize:   normalizeDates,
+	},
+	{
+		Key:         "balance-start",
+		EnvVar:      "TIME_TRACKER_BALANCE_START",
+		Description: "first day counted by balance, as YYYY-MM-DD (default: the first tracked day)",
+		defaultFunc: func() string { return "" },
+		normalize:   normalizeDate,
+	},
+	{
+		Key:         "time-format",
+		EnvVar:      "TIME_TRACKER_TIME_FORMAT",
+		Description: `clock format for displayed times: "24h" or "12h"`,
+		defaultFunc: func() string { return "24h" },
+		normalize:   oneOf("24h", "12h"),
+	},
+	{
+		Key:         "timezone",
+		EnvVar:      "TIME_TRACKER_TIMEZONE",
+		Description: `IANA timezone for displaying and grouping times, e.g. "Europe/Berlin" (default: system)`,
+		defaultFunc: func() string { return "" },
+		normalize:   normalizeTimezone,
+	},
+}
+
+// overrides holds values set by command line flags, which take precedence over everything else
+var overrides = map[string]string{}
+
+// FilePath returns the path to the configuration file
+func FilePath() string {
+	return filepath.Join(ConfigPath, "config.json")
+}
+
+// SetOverride sets a value from a command line flag for the rest of the process
+func SetOverride(key, value string) error {
+	setting, err := lookup(key)
+	if err != nil {
+		return err
+	}
+	normalized, err := setting.normalize(value)
+	if err != nil {
+		return fmt.Errorf("invalid --%s value %q: %w", key, value, err)
+	}
+	overrides[key] = normalized
+	return nil
+}
+
+// Resolve returns the effective value of a setting: flag, environment, configuration file, or default
+func Resolve(key string) (ResolvedSetting, error) {
+	setting, err := lookup(key)
+	if err != nil {
+		return ResolvedSetting{}, err
+	}
+
+	if value, ok := overrides[key]; ok {
+		return ResolvedSetting{Setting: setting, Value: value, Source: SourceFlag}, nil
+	}
+
+	if value := strings.TrimSpace(os.Getenv(setting.EnvVar)); value != "" {
+		normalized, err := setting.normalize(value)
+		if err != nil {
+			return ResolvedSetting{}, fmt.Errorf("invalid %s value %q: %w", setting.EnvVar, value, err)
+		}
+		return ResolvedSetting{Setting: setting, Value: normalized, Source: SourceEnv}, nil
+	}
+
+	values, err := readFile()
+	if err != nil {
+		return ResolvedSetting{}, err
+	}
+	if value, ok := values[key]; ok {
+		normalized, err := setting.normalize(value)
+		if err != nil {
+			return ResolvedSetting{}, fmt.Errorf("invalid %s value %q in %s: %w", key, value, FilePath(), err)
+		}
+		return ResolvedSetting{Setting: setting, Value: normalized, Source: SourceFile}, nil
+	}
+
+	return ResolvedSetting{Setting: setting, Value: setting.Default(), Source: SourceDefault}, nil
+}
+
+// List resolves every setting
+func List() ([]ResolvedSetting, error) {
+	resolved := make([]ResolvedSetting, 0, len(Settings))
+	for _, setting := range Settings {
+		value, err := Resolve(setting.Key)
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, value)
+	}
+	return resolved, nil
+}
+
+// Set validates a value and stores it in the configuration file; an empty value removes it
+func Set(key, value string) (string, error) {
+	setting, err := lookup(key)
+	if err != nil {
+		return "", err
+	}
+
+	values, err := readFile()
+	if err != nil {
+		return "", err
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		delete(values, key)
+	} else {
+		normalized, err := setting.normalize(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s value %q: %w", key, value, err)
+		}
+		values[key] = normalized
+		value = normalized
+	}
+
+	return value, writeFile(values)
+}
+
+// resolvedValue returns a setting's value, or its default when the configuration is invalid
+func resolvedValue(key string) string {
+	resolved, err := Resolve(key)
+	if err != nil {
+		setting, _ := lookup(key)
+		return setting.Default()
+	}
+	return resolved.Value
+}
+
+func lookup(key string) (Setting, error) {
+	key = strings.ToLower(strings.TrimSpace(key))
+	for _, setting := range Settings {
+		if setting.Key == key {
+			return setting, nil
+		}
+	}
+
+	keys := make([]string, len(Settings))
+	for i, setting := range Settings {
+		keys[i] = setting.Key
+	}
+	sort.Strings(keys)
+	return Setting{}, fmt.Errorf("unknown configuration key %q (available: %s)", key, strings.Join(keys, ", "))
+}
+
+// readFile returns the values stored in the configuration file; a missing file has none
+func readFile() (map[string]string, error) {
+	jsonData, err := os.ReadFile(FilePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(jsonData, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", FilePath(), err)
+	}
+
+	values := make(map[string]string, len(raw))
+	for key, rawValue := range raw {
+		if _, err := lookup(key); err != nil {
+			return nil, fmt.Errorf("invalid config file %s: %w", FilePath(), err)
+		}
+
+		var text string
+		if err := json.Unmarshal(rawValue, &text); err == nil {
+			values[key] = text
+			continue
+		}
+		var number json.Number
+		if err := json.Unmarshal(rawValue, &number); err != nil {
+			return nil, fmt.Errorf("invalid config file %s: %s must be a string or a number", FilePath(), key)
+		}
+		values[key] = number.String()
+	}
+	return values, nil
+}
+
+func writeFile(values map[string]string) error {
+	out := make(map[string]any, len(values))
+	for key, value := range values {
+		setting, _ := lookup(key)
+		if number, err := strconv.Atoi(value); setting.numeric && err == nil {
+			out[key] = number
+		} else {
+			out[key] = value
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	if err := os.MkdirAll(ConfigPath, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(FilePath(), append(jsonData, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+func oneOf(allowed ...string) func(string) (string, error) {
+	return func(value string) (string, error) {
+		value = strings.ToLower(strings.TrimSpace(value))
+		for _, candidate := range allowed {
+			if value == candidate {
+				return value, nil
+			}
+		}
+		return "", fmt.Errorf("must be one of %s", strings.Join(allowed, ", "))
+	}
+}
+
+func integer(minimum int) func(string) (string, error) {
+	return func(value string) (string, error) {
+		number, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || number < minimum {
+			if minimum == 0 {
+				return "", errors.New("must be a non-negative number")
+			}
+			return "", errors.New("must be a positive number")
+		}
+		return strconv.Itoa(number), nil
+	}
+}
+
+func normalizePath(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", errors.New("must not be empty")
+	}
+	if value == "~" || strings.HasPrefix(value, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to expand ~: %w", err)
+		}
+		value = filepath.Join(homeDir, strings.TrimPrefix(value, "~"))
+	}
+	return filepath.Abs(value)
+}
+
+func normalizeWeekday(value string) (string, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		if value == name || value == name[:3] {
+			return name, nil
+		}
+	}
+	return "", errors.New("must be a day of the week, e.g. monday or sunday")
+}
+
+func normalizeTimezone(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if _, err := time.LoadLocation(value); err != nil {
+		return "", fmt.Errorf("unknown timezone")
+	}
+	return value, nil
+}
+
+// weekdayNames maps the accepted weekday abbreviations, in schedule order from Monday
+var weekdayNames = []struct {
+	name string
+	day  time.Weekday
+}{
+	{"mon", time.Monday}, {"tue", time.Tuesday}, {"wed", time.Wednesday}, {"thu", time.Thursday},
+	{"fri", time.Friday}, {"sat", time.Saturday}, {"sun", time.Sunday},
+}
+
+func weekdayIndex(value string) (int, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for i, weekday := range weekdayNames {
+		if value == weekday.name || value == strings.ToLower(weekday.day.String()) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday %q", value)
+}
+
+// parseTargetHours parses "mon-thu=8,fri=6.5" into hours per weekday, indexed like weekdayNames
+func parseTargetHours(value string) ([7]float64, error) {
+	var hours [7]float64
+	for _, part := range strings.Split(value, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		days, amount, ok := strings.Cut(part, "=")
+		if !ok {
+			return hours, fmt.Errorf("expected weekday=hours, got %q", strings.TrimSpace(part))
+		}
+		number, err := strconv.ParseFloat(strings.TrimSpace(amount), 64)
+		if err != nil || number < 0 || number > 24 {
+			return hours, fmt.Errorf("hours must be a number from 0 to 24, got %q", strings.TrimSpace(amount))
+		}
+
+		first, last, isRange := strings.Cut(days, "-")
+		from, err := weekdayIndex(first)
+		if err != nil {
+			return hours, err
+		}
+		to := from
+		if isRange {
+			if to, err = weekdayIndex(last); err != nil {
+				return hours, err
+			}
+			if to < from {
+				return hours, fmt.Errorf("weekday range %q must run from Monday towards Sunday", strings.TrimSpace(days))
+			}
+		}
+		for i := from; i <= to; i++ {
+			hours[i] = number
+		}
+	}
+	return hours, nil
+}
+
+func normalizeTargetHours(value string) (string, error) {
+	hours, err := parseTargetHours(value)
+	if err != nil {
+		return "", err
+	}
+
+	var parts []string
+	for i, amount := range hours {
+		if amount > 0 {
+			parts = append(parts, weekdayNames[i].name+"="+strconv.FormatFloat(amount, 'f', -1, 64))
+		}
+	}
+	return strings.Join(parts, ","), nil
+}
+
+// maxDateRange keeps a mistyped year from expanding into decades of days off
+const maxDateRange = 366
+
+// parseDates parses comma-separated dates and "first..last" ranges into the days they cover
+func parseDates(value string) ([]time.Time, error) {
+	var days []time.Time
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		first, last, isRange := strings.Cut(part, "..")
+		from, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(first), time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("expected dates like 2026-12-25 or 2026-08-03..2026-08-14, got %q", part)
+		}
+		to := from
+		if isRange {
+			if to, err = time.ParseInLocation("2006-01-02", strings.TrimSpace(last), time.Local); err != nil {
+				return nil, fmt.Errorf("expected dates like 2026-12-25 or 2026-08-03..2026-08-14, got %q", part)
+			}
+		}
+		if to.Before(from) || to.Sub(from) > maxDateRange*24*time.Hour {
+			return nil, fmt.Errorf("date range %q must end after its start and span at most %d days", part, maxDateRange)
+		}
+		for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
+
+func normalizeDates(value string) (string, error) {
+	if _, err := parseDates(value); err != nil {
+		return "", err
+	}
+
+	var parts []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			first, last, isRange := strings.Cut(part, "..")
+			if isRange {
+				part = strings.TrimSpace(first) + ".." + strings.TrimSpace(last)
+			}
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ","), nil
+}
+
+func normalizeDate(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if _, err := time.ParseInLocation("2006-01-02", value, time.Local); err != nil {
+		return "", errors.New("must be a date like 2026-01-01")
+	}
+	return value, nil
+}
+
+// WeekStart returns the configured first day of the week
+func WeekStart() time.Weekday {
+	name := resolvedValue("week-start")
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.ToLower(day.String()) == name {
+			return day
+		}
+	}
+	return time.Monday
+}
+
+// ExportDays returns the default number of past days included by export
+func ExportDays() int {
+	days, _ := strconv.Atoi(resolvedValue("export-days"))
+	return days
+}
+
+// StatsRows returns the default number of days shown by stats
+func StatsRows() int {
+	rows, _ := strconv.Atoi(resolvedValue("stats-rows"))
+	return rows
+}
+
+// ForgottenAfter returns how long an entry may run before it is reported; zero disables the check
+func ForgottenAfter() time.Duration {
+	hours, _ := strconv.Atoi(resolvedValue("forgotten-after"))
+	return time.Duration(hours) * time.Hour
+}
+
+// CompactThreshold returns the duration below which compact drops entries and gaps
+func CompactThreshold() time.Duration {
+	seconds, _ := strconv.Atoi(resolvedValue("compact-threshold"))
+	return time.Duration(seconds) * time.Second
+}
+
+// PomodoroWork returns the length of the work period of a pomodoro
+func PomodoroWork() time.Duration {
+	return resolvedMinutes("pomodoro-work")
+}
+
+// PomodoroBreak returns the length of the break after each pomodoro
+func PomodoroBreak() time.Duration {
+	return resolvedMinutes("pomodoro-break")
+}
+
+// PomodoroLongBreak returns the length of the break that ends a pomodoro cycle
+func PomodoroLongBreak() time.Duration {
+	return resolvedMinutes("pomodoro-long-break")
+}
+
+// PomodoroRounds returns the number of pomodoros in a cycle
+func PomodoroRounds() int {
+	rounds, _ := strconv.Atoi(resolvedValue("pomodoro-rounds"))
+	return rounds
+}
+
+func resolvedMinutes(key string) time.Duration {
+	minutes, _ := strconv.Atoi(resolvedValue(key))
+	return time.Duration(minutes) * time.Minute
+}
+
+// TargetHours returns the expected working time per weekday
+func TargetHours() map[time.Weekday]time.Duration {
+	hours, _ := parseTargetHours(resolvedValue("target-hours"))
+	targets := make(map[time.Weekday]time.Duration)
+	for i, amount := range hours {
+		if amount > 0 {
+			targets[weekdayNames[i].day] = time.Duration(amount * float64(time.Hour))
+		}
+	}
+	return targets
+}
+
+// DaysOff returns the configured public holidays and vacation days
+func DaysOff() []time.Time {
+	holidays, _ := parseDates(resolvedValue("holidays"))
+	vacation, _ := parseDates(resolvedValue("vacation"))
+	return append(holidays, vacation...)
+}
+
+// BalanceStart returns the first day counted by balance, or zero for the first tracked day
+func BalanceStart() time.Time {
+	start, err := time.ParseInLocation("2006-01-02", resolvedValue("balance-start"), time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return start
+}
+
+// Use12HourClock reports whether times are displayed with a 12-hour clock
+func Use12HourClock() bool {
+	return resolvedValue("time-format") == "12h"
+}
+
+// Location returns the configured timezone, or the system timezone when none is set
+func Location() *time.Location {
+	name := resolvedValue("timezone")
+	if name == "" {
+		return time.Local
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return time.Local
+	}
+	return location
+}
@@ -0,0 +1,194 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func useTempConfigPath(t *testing.T) {
+	t.Helper()
+	previous := ConfigPath
+	ConfigPath = t.TempDir()
+	t.Cleanup(func() {
+		ConfigPath = previous
+		overrides = map[string]string{}
+	})
+}
+
+func TestResolve_Precedence(t *testing.T) {
+	useTempConfigPath(t)
+	t.Setenv("TIME_TRACKER_WEEK_START", "")
+
+	if got, _ := Resolve("week-start"); got.Value != "monday" || got.Source != SourceDefault {
+		t.Fatalf("expected default monday, got %+v", got)
+	}
+
+	if _, err := Set("week-start", "Sun"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if got, _ := Resolve("week-start"); got.Value != "sunday" || got.Source != SourceFile {
+		t.Fatalf("expected sunday from file, got %+v", got)
+	}
+
+	t.Setenv("TIME_TRACKER_WEEK_START", "saturday")
+	if got, _ := Resolve("week-start"); got.Value != "saturday" || got.Source != SourceEnv {
+		t.Fatalf("expected saturday from env, got %+v", got)
+	}
+	if WeekStart() != time.Saturday {
+		t.Fatalf("expected WeekStart to follow the env, got %v", WeekStart())
+	}
+}
+
+func TestSetOverride_DataFile(t *testing.T) {
+	useTempConfigPath(t)
+	t.Setenv("TIME_TRACKER_DATA_FILE", "")
+
+	if DataFilePath() != filepath.Join(ConfigPath, "data.json") {
+		t.Fatalf("unexpected default data file %q", DataFilePath())
+	}
+
+	path := filepath.Join(t.TempDir(), "work.json")
+	t.Setenv("TIME_TRACKER_DATA_FILE", filepath.Join(t.TempDir(), "env.json"))
+	if err := SetOverride("data-file", path); err != nil {
+		t.Fatalf("SetOverride failed: %v", err)
+	}
+	if DataFilePath() != path {
+		t.Fatalf("expected the flag to win, got %q", DataFilePath())
+	}
+}
+
+func TestSideFilePaths_FollowTheDataFile(t *testing.T) {
+	useTempConfigPath(t)
+	t.Setenv("TIME_TRACKER_DATA_FILE", "")
+
+	if DatabaseFilePath() != filepath.Join(ConfigPath, "data.db") || HistoryFilePath() != filepath.Join(ConfigPath, "history.json") {
+		t.Fatalf("unexpected default side files %q, %q", DatabaseFilePath(), HistoryFilePath())
+	}
+
+	dir := t.TempDir()
+	if err := SetOverride("data-file", filepath.Join(dir, "work.json")); err != nil {
+		t.Fatalf("SetOverride failed: %v", err)
+	}
+	want := map[string]string{
+		DatabaseFilePath(): "work.db",
+		JournalFilePath():  "work.journal",
+		HistoryFilePath():  "work.history.json",
+		ActivityFilePath(): "work.activity.json",
+		TimeboxFilePath():  "work.timebox.json",
+	}
+	for got, name := range want {
+		if got != filepath.Join(dir, name) {
+			t.Errorf("expected %s next to the data file, got %q", name, got)
+		}
+	}
+}
+
+func TestSet_ValidatesAndRemoves(t *testing.T) {
+	useTempConfigPath(t)
+	t.Setenv("TIME_TRACKER_EXPORT_DAYS", "")
+
+	if _, err := Set("export-days", "0"); err == nil || !strings.Contains(err.Error(), "positive") {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if _, err := Set("colour", "blue"); err == nil || !strings.Contains(err.Error(), "unknown configuration key") {
+		t.Fatalf("expected unknown key error, got %v", err)
+	}
+
+	if _, err := Set("export-days", "30"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if ExportDays() != 30 {
+		t.Fatalf("expected 30 export days, got %d", ExportDays())
+	}
+	data, _ := os.ReadFile(FilePath())
+	if !strings.Contains(string(data), `"export-days": 30`) {
+		t.Fatalf("expected a numeric value in the config file, got %s", data)
+	}
+
+	if _, err := Set("export-days", ""); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if ExportDays() != 7 {
+		t.Fatalf("expected the default after removing, got %d", ExportDays())
+	}
+}
+
+func TestList_ReportsInvalidFile(t *testing.T) {
+	useTempConfigPath(t)
+	if err := os.WriteFile(FilePath(), []byte(`{"time-format": "13h"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := List(); err == nil || !strings.Contains(err.Error(), "time-format") {
+		t.Fatalf("expected invalid time-format error, got %v", err)
+	}
+}
+
+func TestForgottenAfter(t *testing.T) {
+	useTempConfigPath(t)
+	t.Setenv("TIME_TRACKER_FORGOTTEN_AFTER", "")
+
+	if got := ForgottenAfter(); got != 10*time.Hour {
+		t.Fatalf("expected the default of 10h, got %v", got)
+	}
+
+	t.Setenv("TIME_TRACKER_FORGOTTEN_AFTER", "0")
+	if got := ForgottenAfter(); got != 0 {
+		t.Fatalf("expected 0 to disable the check, got %v", got)
+	}
+}
+
+func TestTargetHours_NormalizesWeekdayRanges(t *testing.T) {
+	useTempConfigPath(t)
+	t.Setenv("TIME_TRACKER_TARGET_HOURS", "")
+
+	if got := TargetHours(); len(got) != 0 {
+		t.Fatalf("expected no targets by default, got %v", got)
+	}
+
+	value, err := Set("target-hours", "Mon-Thu=8, friday=6.5")
+	if err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if value != "mon=8,tue=8,wed=8,thu=8,fri=6.5" {
+		t.Fatalf("unexpected normalized value %q", value)
+	}
+
+	got := TargetHours()
+	if len(got) != 5 || got[time.Wednesday] != 8*time.Hour || got[time.Friday] != 6*time.Hour+30*time.Minute || got[time.Sunday] != 0 {
+		t.Fatalf("unexpected targets %v", got)
+	}
+
+	for _, invalid := range []string{"mon", "mon=25", "fri-mon=8", "someday=8"} {
+		if _, err := Set("target-hours", invalid); err == nil {
+			t.Errorf("expected %q to be rejected", invalid)
+		}
+	}
+}
+
+func TestDaysOff_ExpandsHolidaysAndVacationRanges(t *testing.T) {
+	useTempConfigPath(t)
+	t.Setenv("TIME_TRACKER_HOLIDAYS", "")
+	t.Setenv("TIME_TRACKER_VACATION", "")
+
+	if _, err := Set("holidays", "2026-12-25, 2026-12-26"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if _, err := Set("vacation", "2026-08-03 .. 2026-08-05"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	days := DaysOff()
+	if len(days) != 5 || days[2].Format("2006-01-02") != "2026-08-03" || days[4].Format("2006-01-02") != "2026-08-05" {
+		t.Fatalf("unexpected days off %v", days)
+	}
+
+	for _, invalid := range []string{"2026-13-01", "2026-08-05..2026-08-03", "2026-01-01..2028-01-01"} {
+		if _, err := Set("vacation", invalid); err == nil {
+			t.Errorf("expected %q to be rejected", invalid)
+		}
+	}
+}
@@ -42,9 +42,136 @@ type V4Entry struct {
 	Title   string    `json:"title"`
 }
 
+// V5Entry is the format after V4->V5 migration (tags added).
+type V5Entry struct {
+	Start   time.Time `json:"start"`
+	Project string    `json:"project"`
+	Title   string    `json:"title"`
+	Tags    []string  `json:"tags,omitempty"`
+}
+
+// V6Entry is the format after V5->V6 migration (notes added).
+type V6Entry struct {
+	Start   time.Time `json:"start"`
+	Project string    `json:"project"`
+	Title   string    `json:"title"`
+	Tags    []string  `json:"tags,omitempty"`
+	Notes   string    `json:"notes,omitempty"`
+}
+
+// V8Entry is the format after V7->V8 migration (task reference added).
+type V8Entry struct {
+	Start   time.Time `json:"start"`
+	Project string    `json:"project"`
+	Title   string    `json:"title"`
+	Tags    []string  `json:"tags,omitempty"`
+	Notes   string    `json:"notes,omitempty"`
+	Task    string    `json:"task,omitempty"`
+}
+
+// V9Entry is the format after V8->V9 migration (stable entry ID added).
+type V9Entry struct {
+	ID      string    `json:"id"`
+	Start   time.Time `json:"start"`
+	Project string    `json:"project"`
+	Title   string    `json:"title"`
+	Tags    []string  `json:"tags,omitempty"`
+	Notes   string    `json:"notes,omitempty"`
+	Task    string    `json:"task,omitempty"`
+}
+
 // V4Project is the project metadata format in v4.
 type V4Project struct {
 	Name     string `json:"name"`
 	Code     string `json:"code"`
 	Category string `json:"category"`
 }
+
+// V7Project is the project metadata format in v7 (billing fields added).
+type V7Project struct {
+	Name       string  `json:"name"`
+	Code       string  `json:"code"`
+	Category   string  `json:"category"`
+	HourlyRate float64 `json:"hourlyRate,omitempty"`
+	Currency   string  `json:"currency,omitempty"`
+	Billable   bool    `json:"billable,omitempty"`
+}
+
+// V8Task is the task format introduced in v8.
+type V8Task struct {
+	ID        string     `json:"id"`
+	Project   string     `json:"project"`
+	Name      string     `json:"name"`
+	Created   time.Time  `json:"created"`
+	Completed *time.Time `json:"completed,omitempty"`
+}
+
+// V10Project is the project metadata format in v10 (archiving added).
+type V10Project struct {
+	Name       string  `json:"name"`
+	Code       string  `json:"code"`
+	Category   string  `json:"category"`
+	HourlyRate float64 `json:"hourlyRate,omitempty"`
+	Currency   string  `json:"currency,omitempty"`
+	Billable   bool    `json:"billable,omitempty"`
+	Archived   bool    `json:"archived,omitempty"`
+}
+
+// V11Project is the project metadata format in v11 (parent project added).
+type V11Project struct {
+	Name       string  `json:"name"`
+	Code       string  `json:"code"`
+	Category   string  `json:"category"`
+	HourlyRate float64 `json:"hourlyRate,omitempty"`
+	Currency   string  `json:"currency,omitempty"`
+	Billable   bool    `json:"billable,omitempty"`
+	Archived   bool    `json:"archived,omitempty"`
+	Parent     string  `json:"parent,omitempty"`
+}
+
+// V12Project is the project metadata format in v12 (hour budget added).
+type V12Project struct {
+	Name       string     `json:"name"`
+	Code       string     `json:"code"`
+	Category   string     `json:"category"`
+	HourlyRate float64    `json:"hourlyRate,omitempty"`
+	Currency   string     `json:"currency,omitempty"`
+	Billable   bool       `json:"billable,omitempty"`
+	Archived   bool       `json:"archived,omitempty"`
+	Parent     string     `json:"parent,omitempty"`
+	Budget     float64    `json:"budgetHours,omitempty"`
+	BudgetFrom *time.Time `json:"budgetFrom,omitempty"`
+	BudgetTo   *time.Time `json:"budgetTo,omitempty"`
+}
+
+// V13Project is the project metadata format in v13 (aliases added).
+type V13Project struct {
+	Name       string     `json:"name"`
+	Code       string     `json:"code"`
+	Category   string     `json:"category"`
+	HourlyRate float64    `json:"hourlyRate,omitempty"`
+	Currency   string     `json:"currency,omitempty"`
+	Billable   bool       `json:"billable,omitempty"`
+	Archived   bool       `json:"archived,omitempty"`
+	Parent     string     `json:"parent,omitempty"`
+	Budget     float64    `json:"budgetHours,omitempty"`
+	BudgetFrom *time.Time `json:"budgetFrom,omitempty"`
+	BudgetTo   *time.Time `json:"budgetTo,omitempty"`
+	Aliases    []string   `json:"aliases,omitempty"`
+}
+
+// V14Project is the project metadata format in v14 (hourly rate stored in cents).
+type V14Project struct {
+	Name            string     `json:"name"`
+	Code            string     `json:"code"`
+	Category        string     `json:"category"`
+	HourlyRateCents int64      `json:"hourlyRateCents,omitempty"`
+	Currency        string     `json:"currency,omitempty"`
+	Billable        bool       `json:"billable,omitempty"`
+	Archived        bool       `json:"archived,omitempty"`
+	Parent          string     `json:"parent,omitempty"`
+	Budget          float64    `json:"budgetHours,omitempty"`
+	BudgetFrom      *time.Time `json:"budgetFrom,omitempty"`
+	BudgetTo        *time.Time `json:"budgetTo,omitempty"`
+	Aliases         []string   `json:"aliases,omitempty"`
+}
@@ -1,8 +1,26 @@
 package models
 
+import "time"
+
 // Project represents metadata associated with a project name.
 type Project struct {
 	Name     string `json:"name"`
 	Code     string `json:"code"`
 	Category string `json:"category"`
+	// HourlyRateCents is the hourly rate in cents of Currency, so invoice amounts add up exactly
+	HourlyRateCents int64  `json:"hourlyRateCents,omitempty"`
+	Currency        string `json:"currency,omitempty"`
+	Billable        bool   `json:"billable,omitempty"`
+	// Archived projects are hidden from autocomplete and the project list, but keep their entries
+	Archived bool `json:"archived,omitempty"`
+	// Parent is the project this one is a sub-project of; durations roll up to it
+	Parent string `json:"parent,omitempty"`
+
+	// Budget is the planned hours from BudgetFrom through BudgetTo (0 means no budget)
+	Budget     float64    `json:"budgetHours,omitempty"`
+	BudgetFrom *time.Time `json:"budgetFrom,omitempty"`
+	BudgetTo   *time.Time `json:"budgetTo,omitempty"`
+
+	// Aliases are alternative names the project can be started by, like its code, e.g. "web"
+	Aliases []string `json:"aliases,omitempty"`
 }
@@ -1,11 +1,13 @@
 package models
 
 // This needs incremented when we change the data format
-const CurrentVersion = 4
+const CurrentVersion = 14
 
 type Storage interface {
 	Load() ([]TimeEntry, error)
 	Save([]TimeEntry) error
 	LoadProjects() ([]Project, error)
 	SaveProjects([]Project) error
+	LoadTasks() ([]Task, error)
+	SaveTasks([]Task) error
 }
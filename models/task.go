@@ -11,13 +11,20 @@ const (
 	StatusCompleted  TaskStatus = "completed"
 )
 
+// Task is a named piece of work within a project. Time entries reference a task by ID.
 type Task struct {
-	ID              string        `json:"id"`
-	Name            string        `json:"name"`
-	Status          TaskStatus    `json:"status"`
-	StartTime       time.Time     `json:"startTime,omitempty"`
-	EndTime         time.Time     `json:"endTime,omitempty"`
-	Duration        string        `json:"duration"`
-	AccumulatedTime time.Duration `json:"accumulatedTime"`
-	LastResumeTime  time.Time     `json:"lastResumeTime,omitempty"`
+	ID        string     `json:"id"`
+	Project   string     `json:"project"`
+	Name      string     `json:"name"`
+	Created   time.Time  `json:"created"`
+	Completed *time.Time `json:"completed,omitempty"`
+
+	// Derived from the entries referencing the task when tasks are listed; not stored
+	Status          TaskStatus    `json:"-"`
+	AccumulatedTime time.Duration `json:"-"`
+}
+
+// IsCompleted returns true if the task has been marked done
+func (t *Task) IsCompleted() bool {
+	return t.Completed != nil
 }
@@ -1,15 +1,20 @@
 package models
 
 import (
+	"strings"
 	"time"
 )
 
 // TimeEntry represents a period of tracked time
 type TimeEntry struct {
+	ID      string     `json:"id,omitempty"` // Short stable identifier, assigned when the entry is first saved
 	Start   time.Time  `json:"start"`
 	End     *time.Time `json:"end,omitempty"`
 	Project string     `json:"project"`
 	Title   string     `json:"title"`
+	Tags    []string   `json:"tags,omitempty"`
+	Notes   string     `json:"notes,omitempty"`
+	Task    string     `json:"task,omitempty"` // ID of the task the entry is tracked against
 }
 
 // IsRunning returns true if the entry is currently active
@@ -29,3 +34,14 @@ func (te *TimeEntry) Duration() time.Duration {
 func (te *TimeEntry) IsBlank() bool {
 	return te.Project == "" && te.Title == ""
 }
+
+// HasTag returns true if the entry carries the given tag (case-insensitive)
+func (te *TimeEntry) HasTag(tag string) bool {
+	tag = strings.TrimSpace(tag)
+	for _, t := range te.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
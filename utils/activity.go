@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"time-tracker/models"
+)
+
+// ActivityStore persists when the tracker was last used interactively
+type ActivityStore interface {
+	LoadActivity() (time.Time, error)
+	SaveActivity(time.Time) error
+}
+
+// FileActivityStore implements ActivityStore using a JSON file shared by the CLI and the TUI
+type FileActivityStore struct {
+	FilePath string
+}
+
+func NewFileActivityStore(filePath string) *FileActivityStore {
+	return &FileActivityStore{FilePath: filePath}
+}
+
+type activityFile struct {
+	LastActivity time.Time `json:"last-activity"`
+}
+
+func (as *FileActivityStore) LoadActivity() (time.Time, error) {
+	jsonData, err := os.ReadFile(as.FilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read activity file: %w", err)
+	}
+
+	var activity activityFile
+	if err := json.Unmarshal(jsonData, &activity); err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse activity file: %w", err)
+	}
+	return activity.LastActivity, nil
+}
+
+func (as *FileActivityStore) SaveActivity(at time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(as.FilePath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	jsonData, err := json.MarshalIndent(activityFile{LastActivity: at}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal activity: %w", err)
+	}
+	return writeFileAtomic(as.FilePath, jsonData)
+}
+
+// MemoryActivityStore implements ActivityStore in memory for testing
+type MemoryActivityStore struct {
+	lastActivity time.Time
+}
+
+func NewMemoryActivityStore() *MemoryActivityStore {
+	return &MemoryActivityStore{}
+}
+
+func (as *MemoryActivityStore) LoadActivity() (time.Time, error) {
+	return as.lastActivity, nil
+}
+
+func (as *MemoryActivityStore) SaveActivity(at time.Time) error {
+	as.lastActivity = at
+	return nil
+}
+
+// ForgottenEntry returns the running entry when it runs longer than threshold (if not zero), or nil
+func ForgottenEntry(entries []models.TimeEntry, now time.Time, threshold time.Duration) *models.TimeEntry {
+	if threshold <= 0 || len(entries) == 0 {
+		return nil
+	}
+
+	last := entries[len(entries)-1]
+	if !last.IsRunning() || last.IsBlank() || now.Sub(last.Start) <= threshold {
+		return nil
+	}
+	return &last
+}
+
+// RecordActivity stores now as the last activity, unless the running entry is forgotten
+func RecordActivity(store ActivityStore, entries []models.TimeEntry, now time.Time, threshold time.Duration) error {
+	if ForgottenEntry(entries, now, threshold) != nil {
+		return nil
+	}
+	return store.SaveActivity(now)
+}
+
+// SuggestedEnd returns the last activity recorded while the forgotten entry was running
+func SuggestedEnd(store ActivityStore, forgotten models.TimeEntry, now time.Time) (time.Time, bool) {
+	lastActivity, err := store.LoadActivity()
+	if err != nil || !lastActivity.After(forgotten.Start) || lastActivity.After(now) {
+		return time.Time{}, false
+	}
+	return lastActivity, true
+}
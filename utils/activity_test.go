@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+)
+
+func runningEntries(start time.Time) []models.TimeEntry {
+	return []models.TimeEntry{{Start: start, Project: "Acme", Title: "Build"}}
+}
+
+func TestForgottenEntry(t *testing.T) {
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	entries := runningEntries(start)
+
+	if got := ForgottenEntry(entries, start.Add(10*time.Hour), 10*time.Hour); got != nil {
+		t.Fatalf("expected no forgotten entry at the threshold, got %+v", got)
+	}
+	if got := ForgottenEntry(entries, start.Add(11*time.Hour), 10*time.Hour); got == nil || got.Title != "Build" {
+		t.Fatalf("expected the running entry to be forgotten, got %+v", got)
+	}
+	if got := ForgottenEntry(entries, start.Add(48*time.Hour), 0); got != nil {
+		t.Fatalf("expected a zero threshold to disable the check, got %+v", got)
+	}
+
+	end := start.Add(time.Hour)
+	stopped := []models.TimeEntry{{Start: start, End: &end, Project: "Acme", Title: "Build"}, {Start: end}}
+	if got := ForgottenEntry(stopped, start.Add(48*time.Hour), 10*time.Hour); got != nil {
+		t.Fatalf("expected a trailing gap not to be forgotten, got %+v", got)
+	}
+}
+
+func TestRecordActivity_StopsWhileEntryIsForgotten(t *testing.T) {
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	entries := runningEntries(start)
+	store := NewMemoryActivityStore()
+
+	if err := RecordActivity(store, entries, start.Add(8*time.Hour), 10*time.Hour); err != nil {
+		t.Fatalf("RecordActivity failed: %v", err)
+	}
+	if err := RecordActivity(store, entries, start.Add(23*time.Hour), 10*time.Hour); err != nil {
+		t.Fatalf("RecordActivity failed: %v", err)
+	}
+
+	suggested, ok := SuggestedEnd(store, entries[0], start.Add(23*time.Hour))
+	if !ok || !suggested.Equal(start.Add(8*time.Hour)) {
+		t.Fatalf("expected the activity before the entry was forgotten, got %v (%v)", suggested, ok)
+	}
+}
+
+func TestSuggestedEnd_IgnoresActivityBeforeStart(t *testing.T) {
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	store := NewMemoryActivityStore()
+	if err := store.SaveActivity(start.Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := SuggestedEnd(store, runningEntries(start)[0], start.Add(12*time.Hour)); ok {
+		t.Fatal("expected no suggestion for activity before the entry started")
+	}
+}
+
+func TestFileActivityStore_RoundTrip(t *testing.T) {
+	store := NewFileActivityStore(filepath.Join(t.TempDir(), "nested", "activity.json"))
+
+	if got, err := store.LoadActivity(); err != nil || !got.IsZero() {
+		t.Fatalf("expected zero time without a file, got %v (%v)", got, err)
+	}
+
+	at := time.Date(2026, 3, 16, 18, 12, 0, 0, time.UTC)
+	if err := store.SaveActivity(at); err != nil {
+		t.Fatalf("SaveActivity failed: %v", err)
+	}
+	if got, err := store.LoadActivity(); err != nil || !got.Equal(at) {
+		t.Fatalf("expected %v, got %v (%v)", at, got, err)
+	}
+}
@@ -1,20 +1,26 @@
 package utils
 
 import (
+	"slices"
 	"sort"
+	"strings"
 	"time"
 	"time-tracker/models"
 )
 
 // ProjectDateEntry represents an aggregated group of time entries for a (project, date) combination
 type ProjectDateEntry struct {
-	Project         string
-	ProjectCode     string
-	ProjectCategory string
-	Date            time.Time
-	Duration        time.Duration
-	Tasks           []string // Deduplicated task titles
-	RawDuration     time.Duration
+	Project          string
+	ProjectCode      string
+	ProjectCategory  string
+	ProjectRateCents int64 // Hourly rate in cents from project metadata
+	ProjectCurrency  string
+	ProjectBillable  bool
+	Date             time.Time
+	Duration         time.Duration
+	Tasks            []string // Deduplicated task titles
+	Notes            []string // Deduplicated entry notes, flattened to a single line each
+	RawDuration      time.Duration
 }
 
 // AggregateByProjectDate groups time entries by (project, date) and collects task descriptions.
@@ -55,6 +61,7 @@ func AggregateByProjectDate(entries []models.TimeEntry) []ProjectDateEntry {
 				Project: entry.Project,
 				Date:    parsedDate,
 				Tasks:   []string{},
+				Notes:   []string{},
 			}
 		}
 
@@ -73,6 +80,11 @@ func AggregateByProjectDate(entries []models.TimeEntry) []ProjectDateEntry {
 				aggregated[key].Tasks = append(aggregated[key].Tasks, entry.Title)
 			}
 		}
+
+		// Add notes in entry order, skipping duplicates
+		if notes := flattenNotes(entry.Notes); notes != "" && !slices.Contains(aggregated[key].Notes, notes) {
+			aggregated[key].Notes = append(aggregated[key].Notes, notes)
+		}
 	}
 
 	// Convert to slice and sort
@@ -96,7 +108,18 @@ func AggregateByProjectDate(entries []models.TimeEntry) []ProjectDateEntry {
 	return result
 }
 
-// ApplyProjectMetadata enriches aggregated entries with project code/category.
+// flattenNotes joins the non-empty lines of multi-line notes with "; "
+func flattenNotes(notes string) string {
+	var lines []string
+	for _, line := range strings.Split(notes, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "; ")
+}
+
+// ApplyProjectMetadata enriches aggregated entries with project code/category and billing metadata.
 // Entries whose project has no metadata remain in the result with empty metadata fields.
 func ApplyProjectMetadata(entries []ProjectDateEntry, projects []models.Project) []ProjectDateEntry {
 	if len(entries) == 0 {
@@ -113,11 +136,17 @@ func ApplyProjectMetadata(entries []ProjectDateEntry, projects []models.Project)
 		if !ok {
 			entries[i].ProjectCode = ""
 			entries[i].ProjectCategory = ""
+			entries[i].ProjectRateCents = 0
+			entries[i].ProjectCurrency = ""
+			entries[i].ProjectBillable = false
 			continue
 		}
 
 		entries[i].ProjectCode = project.Code
 		entries[i].ProjectCategory = project.Category
+		entries[i].ProjectRateCents = project.HourlyRateCents
+		entries[i].ProjectCurrency = project.Currency
+		entries[i].ProjectBillable = project.Billable
 	}
 
 	return entries
@@ -125,17 +154,17 @@ func ApplyProjectMetadata(entries []ProjectDateEntry, projects []models.Project)
 
 // GetWeekSeparators returns indices in the aggregated slice where week boundaries occur.
 // Returns indices where a new week starts (where a weekly separator row should be inserted before).
-// Week is Monday to Sunday (Monday = start).
+// Weeks start on the configured week start day (Monday by default).
 func GetWeekSeparators(aggregated []ProjectDateEntry) []int {
 	if len(aggregated) == 0 {
 		return []int{}
 	}
 
 	separators := []int{}
-	currentWeekStart := getMonday(aggregated[0].Date)
+	currentWeekStart := getWeekStart(aggregated[0].Date)
 
 	for i := 1; i < len(aggregated); i++ {
-		weekStart := getMonday(aggregated[i].Date)
+		weekStart := getWeekStart(aggregated[i].Date)
 		if !weekStart.Equal(currentWeekStart) {
 			separators = append(separators, i)
 			currentWeekStart = weekStart
@@ -159,13 +188,12 @@ func GetWeeklyTotal(aggregated []ProjectDateEntry, weekStart time.Time) time.Dur
 	return total
 }
 
-// getMonday returns the Monday of the week containing the given date
-func getMonday(date time.Time) time.Time {
-	offset := (int(date.Weekday()) + 6) % 7 // Monday -> 0, Sunday -> 6
-	return date.AddDate(0, 0, -offset).Truncate(24 * time.Hour)
+// getWeekStart returns the first day of the week containing the given date
+func getWeekStart(date time.Time) time.Time {
+	return date.AddDate(0, 0, -weekStartOffset(date.Weekday())).Truncate(24 * time.Hour)
 }
 
-// GetMondayOfWeek is the exported version of getMonday
-func GetMondayOfWeek(date time.Time) time.Time {
-	return getMonday(date)
+// GetWeekStart is the exported version of getWeekStart
+func GetWeekStart(date time.Time) time.Time {
+	return getWeekStart(date)
 }
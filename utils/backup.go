@@ -0,0 +1,318 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"time-tracker/models"
+)
+
+// DefaultMaxBackups is how many backups FileStorage keeps unless configured otherwise
+const DefaultMaxBackups = 10
+
+// backupIDFormat sorts lexically in creation order and is safe to use in file names
+const backupIDFormat = "20060102T150405.000000000Z"
+
+// Backup is a copy of the data file taken before FileStorage replaced it
+type Backup struct {
+	ID        string
+	Path      string
+	CreatedAt time.Time
+	Size      int64
+}
+
+// TimeRange is an inclusive span of time
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// BackupDiff summarizes how a backup differs from the current data
+type BackupDiff struct {
+	CurrentEntries  int
+	BackupEntries   int
+	CurrentProjects int
+	BackupProjects  int
+	Added           int         // Entries only in the backup, which a restore brings back
+	Removed         int         // Entries only in the current data, which a restore drops
+	Changed         int         // Entries with the same start but different content
+	Ranges          []TimeRange // Spans of consecutive differing entries
+}
+
+// HasChanges reports whether restoring the backup would change any entries or projects
+func (d BackupDiff) HasChanges() bool {
+	return d.Added > 0 || d.Removed > 0 || d.Changed > 0 || d.CurrentProjects != d.BackupProjects
+}
+
+// backupDir returns the directory holding the backups of the data file
+func (fs *FileStorage) backupDir() string {
+	if fs.BackupDir != "" {
+		return fs.BackupDir
+	}
+	return filepath.Join(filepath.Dir(fs.FilePath), "backups")
+}
+
+// createBackup stores data as a new backup unless it matches the newest, pruning beyond MaxBackups
+func (fs *FileStorage) createBackup(data []byte) error {
+	if fs.MaxBackups <= 0 || len(data) == 0 {
+		return nil
+	}
+
+	dir := fs.backupDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	existing, err := fs.ListBackups()
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		if newest, err := os.ReadFile(existing[0].Path); err == nil && bytes.Equal(newest, data) {
+			return nil
+		}
+	}
+
+	id := time.Now().UTC().Format(backupIDFormat)
+	if err := os.WriteFile(filepath.Join(dir, id+".json"), data, 0644); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	backups, err := fs.ListBackups()
+	if err != nil {
+		return err
+	}
+	for _, backup := range backups[min(fs.MaxBackups, len(backups)):] {
+		if err := os.Remove(backup.Path); err != nil {
+			return fmt.Errorf("failed to remove old backup %s: %w", backup.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// CreateBackup backs up the current data file, e.g. before it is edited by hand or compacted
+func (fs *FileStorage) CreateBackup() error {
+	jsonData, err := os.ReadFile(fs.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read data file: %w", err)
+	}
+	return fs.createBackup(jsonData)
+}
+
+// BackupsEnabled reports whether backups are kept, i.e. MaxBackups is positive
+func (fs *FileStorage) BackupsEnabled() bool {
+	return fs.MaxBackups > 0
+}
+
+// ListBackups returns the available backups, newest first
+func (fs *FileStorage) ListBackups() ([]Backup, error) {
+	dirEntries, err := os.ReadDir(fs.backupDir())
+	if errors.Is(err, os.ErrNotExist) {
+		return []Backup{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup directory: %w", err)
+	}
+
+	backups := []Backup{}
+	for _, dirEntry := range dirEntries {
+		name := dirEntry.Name()
+		if dirEntry.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		id := strings.TrimSuffix(name, ".json")
+		createdAt, err := time.Parse(backupIDFormat, id)
+		if err != nil {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat backup %s: %w", id, err)
+		}
+		backups = append(backups, Backup{
+			ID:        id,
+			Path:      filepath.Join(fs.backupDir(), name),
+			CreatedAt: createdAt,
+			Size:      info.Size(),
+		})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].ID > backups[j].ID
+	})
+	return backups, nil
+}
+
+// FindBackup returns the backup with the given ID. A unique ID prefix is accepted too.
+func (fs *FileStorage) FindBackup(id string) (Backup, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return Backup{}, errors.New("backup ID cannot be empty")
+	}
+
+	backups, err := fs.ListBackups()
+	if err != nil {
+		return Backup{}, err
+	}
+
+	var matches []Backup
+	for _, backup := range backups {
+		if backup.ID == id {
+			return backup, nil
+		}
+		if strings.HasPrefix(backup.ID, id) {
+			matches = append(matches, backup)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return Backup{}, fmt.Errorf("backup %q not found", id)
+	case 1:
+		return matches[0], nil
+	default:
+		return Backup{}, fmt.Errorf("backup ID %q is ambiguous: matches %d backups", id, len(matches))
+	}
+}
+
+// LoadBackup reads the entries and projects stored in a backup, migrating older formats
+func LoadBackup(backup Backup) ([]models.TimeEntry, []models.Project, error) {
+	jsonData, err := os.ReadFile(backup.Path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read backup %s: %w", backup.ID, err)
+	}
+
+	entries, err := parseEntries(jsonData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid backup %s: %w", backup.ID, err)
+	}
+	projects, err := parseProjects(jsonData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid backup %s: %w", backup.ID, err)
+	}
+
+	return entries, projects, nil
+}
+
+// DiffBackup compares backup data against the current data
+func DiffBackup(currentEntries []models.TimeEntry, currentProjects []models.Project, backupEntries []models.TimeEntry, backupProjects []models.Project) BackupDiff {
+	diff := BackupDiff{
+		CurrentEntries:  len(currentEntries),
+		BackupEntries:   len(backupEntries),
+		CurrentProjects: len(currentProjects),
+		BackupProjects:  len(backupProjects),
+	}
+
+	current := make(map[int64]models.TimeEntry, len(currentEntries))
+	for _, entry := range currentEntries {
+		current[entry.Start.UnixNano()] = entry
+	}
+	backup := make(map[int64]models.TimeEntry, len(backupEntries))
+	for _, entry := range backupEntries {
+		backup[entry.Start.UnixNano()] = entry
+	}
+
+	keys := make([]int64, 0, len(current)+len(backup))
+	for key := range current {
+		keys = append(keys, key)
+	}
+	for key := range backup {
+		if _, ok := current[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	var open *TimeRange
+	for _, key := range keys {
+		currentEntry, inCurrent := current[key]
+		backupEntry, inBackup := backup[key]
+
+		differs := true
+		var entry models.TimeEntry
+		switch {
+		case inCurrent && !inBackup:
+			diff.Removed++
+			entry = currentEntry
+		case !inCurrent && inBackup:
+			diff.Added++
+			entry = backupEntry
+		case !sameEntryContent(currentEntry, backupEntry):
+			diff.Changed++
+			entry = backupEntry
+		default:
+			differs = false
+		}
+
+		if !differs {
+			if open != nil {
+				diff.Ranges = append(diff.Ranges, *open)
+				open = nil
+			}
+			continue
+		}
+
+		end := entry.Start
+		if entry.End != nil {
+			end = *entry.End
+		}
+		if open == nil {
+			open = &TimeRange{Start: entry.Start, End: end}
+		} else if end.After(open.End) {
+			open.End = end
+		}
+	}
+	if open != nil {
+		diff.Ranges = append(diff.Ranges, *open)
+	}
+
+	return diff
+}
+
+func sameEntryContent(a, b models.TimeEntry) bool {
+	return a.Project == b.Project &&
+		a.Title == b.Title &&
+		a.Notes == b.Notes &&
+		slices.Equal(a.Tags, b.Tags)
+}
+
+// RestoreBackup replaces the data file with the backup, backing up the replaced data first
+func (fs *FileStorage) RestoreBackup(backup Backup) error {
+	jsonData, err := os.ReadFile(backup.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read backup %s: %w", backup.ID, err)
+	}
+	if _, err := parseProjects(jsonData); err != nil {
+		return fmt.Errorf("invalid backup %s: %w", backup.ID, err)
+	}
+
+	unlock, err := lockFile(fs.FilePath + ".lock")
+	if err != nil {
+		return fmt.Errorf("failed to lock data file: %w", err)
+	}
+	defer unlock()
+
+	currentData, err := os.ReadFile(fs.FilePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read data file: %w", err)
+	}
+	if err := fs.createBackup(currentData); err != nil {
+		return err
+	}
+
+	if err := writeFileAtomic(fs.FilePath, jsonData); err != nil {
+		return err
+	}
+
+	// Later saves build on the restored data
+	fs.loadedHash = hashData(jsonData)
+	return nil
+}
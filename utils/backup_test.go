@@ -0,0 +1,196 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+)
+
+func TestFileStorage_SaveKeepsRollingBackups(t *testing.T) {
+	storage, err := NewFileStorage(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("Failed to create file storage: %v", err)
+	}
+	storage.MaxBackups = 2
+	tm := NewTaskManager(storage)
+
+	for _, project := range []string{"A", "B", "C", "D"} {
+		if _, err := tm.StartEntry(project, "Task"); err != nil {
+			t.Fatalf("StartEntry returned error: %v", err)
+		}
+	}
+
+	backups, err := storage.ListBackups()
+	if err != nil {
+		t.Fatalf("ListBackups returned error: %v", err)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("Expected 2 backups, got %d", len(backups))
+	}
+	if !backups[0].CreatedAt.After(backups[1].CreatedAt) {
+		t.Fatalf("Expected newest backup first, got %+v", backups)
+	}
+
+	// The newest backup is the data before the last save
+	entries, _, err := LoadBackup(backups[0])
+	if err != nil {
+		t.Fatalf("LoadBackup returned error: %v", err)
+	}
+	if len(entries) != 3 || entries[2].Project != "C" {
+		t.Fatalf("Expected backup to hold the three entries before D, got %+v", entries)
+	}
+}
+
+func TestFileStorage_ExplicitBackupIsNotRepeatedBySave(t *testing.T) {
+	storage, err := NewFileStorage(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("Failed to create file storage: %v", err)
+	}
+	tm := NewTaskManager(storage)
+
+	for _, project := range []string{"A", "B"} {
+		if _, err := tm.StartEntry(project, "Task"); err != nil {
+			t.Fatalf("StartEntry returned error: %v", err)
+		}
+	}
+	if backups, _ := storage.ListBackups(); len(backups) != 2 {
+		t.Fatalf("Expected a backup before every save, got %d", len(backups))
+	}
+
+	// A backup taken right before a save, e.g. by compact, leaves a single copy of that data
+	if err := storage.CreateBackup(); err != nil {
+		t.Fatalf("CreateBackup returned error: %v", err)
+	}
+	if _, err := tm.StartEntry("C", "Task"); err != nil {
+		t.Fatalf("StartEntry returned error: %v", err)
+	}
+	if backups, _ := storage.ListBackups(); len(backups) != 3 {
+		t.Fatalf("Expected the explicit backup and the save to share one backup, got %d", len(backups))
+	}
+}
+
+func TestFileStorage_NoBackupsWhenDisabled(t *testing.T) {
+	dir := t.TempDir()
+	storage, err := NewFileStorage(filepath.Join(dir, "data.json"))
+	if err != nil {
+		t.Fatalf("Failed to create file storage: %v", err)
+	}
+	storage.MaxBackups = 0
+
+	if err := storage.Save([]models.TimeEntry{{Start: time.Now(), Project: "A", Title: "Task"}}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "backups")); !os.IsNotExist(err) {
+		t.Fatalf("Expected no backup directory, got err=%v", err)
+	}
+}
+
+func TestFileStorage_RestoreBackup(t *testing.T) {
+	storage, err := NewFileStorage(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("Failed to create file storage: %v", err)
+	}
+	tm := NewTaskManager(storage)
+
+	if _, err := tm.StartEntry("Keep", "Task"); err != nil {
+		t.Fatalf("StartEntry returned error: %v", err)
+	}
+	if err := tm.DeleteEntry(0); err != nil {
+		t.Fatalf("DeleteEntry returned error: %v", err)
+	}
+
+	backups, err := storage.ListBackups()
+	if err != nil {
+		t.Fatalf("ListBackups returned error: %v", err)
+	}
+	backup, err := storage.FindBackup(backups[0].ID[:len(backups[0].ID)-3])
+	if err != nil {
+		t.Fatalf("FindBackup by prefix returned error: %v", err)
+	}
+
+	if err := storage.RestoreBackup(backup); err != nil {
+		t.Fatalf("RestoreBackup returned error: %v", err)
+	}
+
+	entries, err := storage.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Project != "Keep" {
+		t.Fatalf("Expected deleted entry to be restored, got %+v", entries)
+	}
+
+	after, err := storage.ListBackups()
+	if err != nil {
+		t.Fatalf("ListBackups returned error: %v", err)
+	}
+	if len(after) != len(backups)+1 {
+		t.Fatalf("Expected restore to back up the replaced data, got %d backups (was %d)", len(after), len(backups))
+	}
+
+	// Saving after a restore builds on the restored data instead of reporting a conflict
+	if _, err := tm.StartEntry("Next", "Task"); err != nil {
+		t.Fatalf("StartEntry after restore returned error: %v", err)
+	}
+}
+
+func TestFileStorage_FindBackupErrors(t *testing.T) {
+	storage, err := NewFileStorage(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("Failed to create file storage: %v", err)
+	}
+
+	if _, err := storage.FindBackup(""); err == nil {
+		t.Fatal("Expected error for empty ID")
+	}
+	if _, err := storage.FindBackup("20200101"); err == nil {
+		t.Fatal("Expected error for unknown ID")
+	}
+}
+
+func TestDiffBackup(t *testing.T) {
+	nine := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	ten := nine.Add(time.Hour)
+	eleven := ten.Add(time.Hour)
+	noon := eleven.Add(time.Hour)
+	one := noon.Add(time.Hour)
+
+	current := []models.TimeEntry{
+		{Start: nine, End: &ten, Project: "A", Title: "Same"},
+		{Start: ten, End: &eleven, Project: "B", Title: "Renamed"},
+		{Start: eleven, End: &noon, Project: "A", Title: "Same"},
+		{Start: noon, Project: "New", Title: "Only current"},
+	}
+	backup := []models.TimeEntry{
+		{Start: nine, End: &ten, Project: "A", Title: "Same"},
+		{Start: ten, End: &eleven, Project: "B", Title: "Original"},
+		{Start: eleven, End: &one, Project: "A", Title: "Same"},
+		{Start: one, Project: "Old", Title: "Only backup"},
+	}
+
+	diff := DiffBackup(current, nil, backup, nil)
+
+	if diff.Added != 1 || diff.Removed != 1 || diff.Changed != 1 {
+		t.Fatalf("Unexpected diff counts: %+v", diff)
+	}
+	if !diff.HasChanges() {
+		t.Fatal("Expected diff to report changes")
+	}
+	if len(diff.Ranges) != 2 {
+		t.Fatalf("Expected 2 changed ranges, got %+v", diff.Ranges)
+	}
+	if !diff.Ranges[0].Start.Equal(ten) || !diff.Ranges[0].End.Equal(eleven) {
+		t.Fatalf("Unexpected first range: %+v", diff.Ranges[0])
+	}
+	if !diff.Ranges[1].Start.Equal(noon) || !diff.Ranges[1].End.Equal(one) {
+		t.Fatalf("Unexpected second range: %+v", diff.Ranges[1])
+	}
+
+	if DiffBackup(current, nil, current, nil).HasChanges() {
+		t.Fatal("Expected identical data to have no changes")
+	}
+}
@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"time-tracker/models"
+)
+
+// BudgetBurnWeeks is the number of recent weeks the weekly burn rate is averaged over
+const BudgetBurnWeeks = 4
+
+// BudgetReport compares the time tracked on a project and its sub-projects with its budget
+type BudgetReport struct {
+	Project    string
+	Budget     time.Duration
+	From       *time.Time // First day counted, nil for no limit
+	To         *time.Time // Last day counted, nil for no limit
+	Consumed   time.Duration
+	WeeklyBurn time.Duration // Average time per week over the last BudgetBurnWeeks weeks
+	Exhausted  time.Time     // When the budget runs out at the weekly burn rate; zero when it does not
+}
+
+// Remaining returns the time left in the budget, negative when it is overrun
+func (r BudgetReport) Remaining() time.Duration {
+	return r.Budget - r.Consumed
+}
+
+// OverBudget reports whether more time was tracked than budgeted
+func (r BudgetReport) OverBudget() bool {
+	return r.Consumed > r.Budget
+}
+
+// Percent returns the share of the budget consumed, e.g. 50 for half of it
+func (r BudgetReport) Percent() int {
+	if r.Budget <= 0 {
+		return 0
+	}
+	return int(r.Consumed * 100 / r.Budget)
+}
+
+// CalculateBudget reports how much of the project's budget its entries and sub-projects use
+func CalculateBudget(project models.Project, projects []models.Project, entries []models.TimeEntry, now time.Time) BudgetReport {
+	report := BudgetReport{
+		Project: project.Name,
+		Budget:  time.Duration(project.Budget * float64(time.Hour)),
+		From:    project.BudgetFrom,
+		To:      project.BudgetTo,
+	}
+
+	burnStart := now.AddDate(0, 0, -7*BudgetBurnWeeks)
+	if report.From != nil && report.From.After(burnStart) {
+		burnStart = startOfDay(*report.From)
+	}
+
+	tree := NewProjectTree(projects)
+	var firstCounted time.Time
+	var burned time.Duration
+	for _, entry := range entries {
+		if entry.IsBlank() || entry.Start.After(now) || !inBudgetWindow(report, entry.Start) || !isProjectOrSubProject(tree, entry.Project, project.Name) {
+			continue
+		}
+
+		end := now
+		if entry.End != nil && entry.End.Before(now) {
+			end = *entry.End
+		}
+		duration := end.Sub(entry.Start)
+		report.Consumed += duration
+		if !entry.Start.Before(burnStart) {
+			burned += duration
+		}
+		if firstCounted.IsZero() || entry.Start.Before(firstCounted) {
+			firstCounted = entry.Start
+		}
+	}
+
+	// A project started recently burns at the rate since its first entry
+	if day := startOfDay(firstCounted); day.After(burnStart) {
+		burnStart = day
+	}
+	elapsed := max(now.Sub(burnStart), 24*time.Hour)
+	report.WeeklyBurn = time.Duration(float64(burned) / float64(elapsed) * float64(7*24*time.Hour))
+
+	windowOver := report.To != nil && startOfDay(now).After(startOfDay(*report.To))
+	if report.Remaining() > 0 && report.WeeklyBurn > 0 && !windowOver {
+		weeksLeft := float64(report.Remaining()) / float64(report.WeeklyBurn)
+		report.Exhausted = now.Add(time.Duration(weeksLeft * float64(7*24*time.Hour)))
+	}
+
+	return report
+}
+
+func inBudgetWindow(report BudgetReport, t time.Time) bool {
+	day := startOfDay(t)
+	if report.From != nil && day.Before(startOfDay(*report.From)) {
+		return false
+	}
+	return report.To == nil || !day.After(startOfDay(*report.To))
+}
+
+// isProjectOrSubProject reports whether name is the project or one of its sub-projects
+func isProjectOrSubProject(tree *ProjectTree, name, project string) bool {
+	if name == "" {
+		return false
+	}
+	for _, ancestor := range tree.Path(name) {
+		if strings.EqualFold(ancestor, project) {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatBudgetWindow describes the dates a budget counts, or "" when it has no window
+func FormatBudgetWindow(from, to *time.Time) string {
+	switch {
+	case from != nil && to != nil:
+		return fmt.Sprintf("%s to %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	case from != nil:
+		return "from " + from.Format("2006-01-02")
+	case to != nil:
+		return "until " + to.Format("2006-01-02")
+	}
+	return ""
+}
+
+// SetProjectBudget sets the hour budget of a project and its optional dates; zero removes it
+func (tm *TaskManager) SetProjectBudget(name string, hours float64, from, to *time.Time) (_ *models.Project, err error) {
+	defer tm.record(fmt.Sprintf("set budget of project %s", strings.TrimSpace(name)))(&err)
+
+	projects, projectIndex, err := tm.loadProject(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := setProjectBudget(&projects[projectIndex], hours, from, to); err != nil {
+		return nil, err
+	}
+
+	if err := tm.storage.SaveProjects(projects); err != nil {
+		return nil, err
+	}
+
+	updated := projects[projectIndex]
+	return &updated, nil
+}
+
+// setProjectBudget validates the budget and sets it on the project, see SetProjectBudget
+func setProjectBudget(project *models.Project, hours float64, from, to *time.Time) error {
+	if hours < 0 {
+		return fmt.Errorf("budget cannot be negative")
+	}
+	if from != nil && to != nil && to.Before(*from) {
+		return fmt.Errorf("budget end %s is before its start %s", to.Format("2006-01-02"), from.Format("2006-01-02"))
+	}
+
+	project.Budget = hours
+	project.BudgetFrom = from
+	project.BudgetTo = to
+	if hours == 0 {
+		project.BudgetFrom = nil
+		project.BudgetTo = nil
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+)
+
+func TestCalculateBudget_CountsSubProjectsWithinWindow(t *testing.T) {
+	now := time.Date(2026, 3, 29, 12, 0, 0, 0, time.UTC)
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	project := models.Project{Name: "Acme", Budget: 40, BudgetFrom: &from}
+	projects := []models.Project{project, {Name: "Website", Parent: "Acme"}, {Name: "Internal"}}
+
+	var entries []models.TimeEntry
+	add := func(start time.Time, hours int, name string) {
+		end := start.Add(time.Duration(hours) * time.Hour)
+		entries = append(entries, models.TimeEntry{Start: start, End: &end, Project: name, Title: "Work"})
+	}
+	add(time.Date(2026, 2, 27, 9, 0, 0, 0, time.UTC), 5, "Acme")    // Before the window
+	add(time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC), 4, "Acme")     // 4 weeks before now
+	add(time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC), 8, "Website") // Sub-project
+	add(time.Date(2026, 3, 17, 9, 0, 0, 0, time.UTC), 6, "Internal")
+	add(time.Date(2026, 3, 23, 9, 0, 0, 0, time.UTC), 4, "acme")
+
+	report := CalculateBudget(project, projects, entries, now)
+
+	if report.Consumed != 16*time.Hour || report.Remaining() != 24*time.Hour || report.OverBudget() || report.Percent() != 40 {
+		t.Fatalf("unexpected consumption: %+v", report)
+	}
+	// 16h over the 27.5 days since the window started
+	wantBurn := time.Duration(float64(16*time.Hour) / float64(now.Sub(from)) * float64(7*24*time.Hour))
+	if report.WeeklyBurn != wantBurn {
+		t.Fatalf("expected a weekly burn of %v, got %v", wantBurn, report.WeeklyBurn)
+	}
+	if report.Exhausted.Before(now.AddDate(0, 0, 40)) || report.Exhausted.After(now.AddDate(0, 0, 43)) {
+		t.Fatalf("expected the budget to run out in about six weeks, got %v", report.Exhausted)
+	}
+}
+
+func TestCalculateBudget_OverBudgetHasNoExhaustionDate(t *testing.T) {
+	now := time.Date(2026, 3, 17, 12, 0, 0, 0, time.UTC)
+	project := models.Project{Name: "Acme", Budget: 2}
+	entries := []models.TimeEntry{{Start: now.Add(-3 * time.Hour), Project: "Acme", Title: "Running"}}
+
+	report := CalculateBudget(project, []models.Project{project}, entries, now)
+
+	if !report.OverBudget() || report.Remaining() != -time.Hour || !report.Exhausted.IsZero() {
+		t.Fatalf("expected the running entry to overrun the budget, got %+v", report)
+	}
+}
+
+func TestTaskManager_SetProjectBudget(t *testing.T) {
+	storage := NewMemoryStorage()
+	tm := NewTaskManager(storage)
+	if _, err := tm.AddProject("Acme", "", ""); err != nil {
+		t.Fatalf("AddProject failed: %v", err)
+	}
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	project, err := tm.SetProjectBudget("acme", 40, &from, &to)
+	if err != nil {
+		t.Fatalf("SetProjectBudget failed: %v", err)
+	}
+	if project.Budget != 40 || FormatBudgetWindow(project.BudgetFrom, project.BudgetTo) != "2026-03-01 to 2026-03-31" {
+		t.Fatalf("unexpected project: %+v", project)
+	}
+
+	if _, err := tm.SetProjectBudget("Acme", 40, &to, &from); err == nil || !strings.Contains(err.Error(), "before its start") {
+		t.Fatalf("expected an error for a reversed window, got %v", err)
+	}
+	if _, err := tm.SetProjectBudget("Acme", -1, nil, nil); err == nil {
+		t.Fatal("expected an error for a negative budget")
+	}
+
+	if project, err = tm.SetProjectBudget("Acme", 0, &from, nil); err != nil || project.Budget != 0 || project.BudgetFrom != nil {
+		t.Fatalf("expected the budget to be removed with its window, got %+v (%v)", project, err)
+	}
+}
@@ -0,0 +1,159 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"time-tracker/models"
+)
+
+// CompactChange is one entry removed by CompactEntries
+type CompactChange struct {
+	Entry    models.TimeEntry
+	Duration time.Duration
+	Dropped  bool // Shorter than the threshold; otherwise merged into the previous entry
+	Running  bool // The running entry, which has no duration yet
+}
+
+func (c CompactChange) String() string {
+	duration := formatShortDuration(c.Duration)
+	if c.Running {
+		duration = "running"
+	}
+	description := fmt.Sprintf("%s (%s, %s)", FormatDateTime(c.Entry.Start.Local()), describeEntry(c.Entry.Project, c.Entry.Title), duration)
+	if c.Dropped {
+		return "drop " + description
+	}
+	return "merge " + description + " into the previous entry"
+}
+
+// CompactResult reports what CompactEntries changed
+type CompactResult struct {
+	Changes []CompactChange
+	Before  int // Number of entries before compacting
+	After   int // Number of entries after compacting
+}
+
+// Dropped returns how many entries were dropped for being shorter than the threshold
+func (r *CompactResult) Dropped() int {
+	dropped := 0
+	for _, change := range r.Changes {
+		if change.Dropped {
+			dropped++
+		}
+	}
+	return dropped
+}
+
+// Merged returns how many entries were merged into the previous entry
+func (r *CompactResult) Merged() int {
+	return len(r.Changes) - r.Dropped()
+}
+
+// CompactEntries drops entries shorter than threshold and merges adjacent repeats
+func CompactEntries(entries []models.TimeEntry, threshold time.Duration) ([]models.TimeEntry, *CompactResult) {
+	sorted := sortedEntries(entries)
+	result := &CompactResult{Before: len(sorted)}
+
+	var compacted, dropped []models.TimeEntry
+	for i, entry := range sorted {
+		last := i == len(sorted)-1
+		duration := time.Duration(0)
+		if !last {
+			duration = sorted[i+1].Start.Sub(entry.Start)
+		} else if entry.End != nil {
+			duration = entry.End.Sub(entry.Start)
+		}
+
+		if !last && duration < threshold {
+			result.Changes = append(result.Changes, CompactChange{Entry: entry, Duration: duration, Dropped: true})
+			dropped = append(dropped, entry)
+			continue
+		}
+		droppedBefore := dropped
+		dropped = nil
+
+		if n := len(compacted); n > 0 && sameCompactEntry(compacted[n-1], entry) {
+			previous := &compacted[n-1]
+			previous.Tags = NormalizeTags(append(previous.Tags, entry.Tags...))
+			previous.Notes = joinNotes(previous.Notes, entry.Notes)
+			if previous.Task == "" {
+				previous.Task = entry.Task
+			}
+			result.Changes = append(result.Changes, CompactChange{Entry: entry, Duration: duration, Running: last && entry.End == nil})
+			continue
+		}
+
+		for j := len(droppedBefore) - 1; j >= 0 && sameCompactEntry(droppedBefore[j], entry); j-- {
+			entry.Start = droppedBefore[j].Start
+		}
+		entry.Tags = append([]string(nil), entry.Tags...)
+		compacted = append(compacted, entry)
+	}
+
+	for i := 0; i < len(compacted)-1; i++ {
+		next := compacted[i+1].Start
+		compacted[i].End = &next
+	}
+	if n := len(compacted); n > 0 {
+		compacted[n-1].End = sorted[len(sorted)-1].End
+	}
+
+	result.After = len(compacted)
+	return compacted, result
+}
+
+// Compact applies CompactEntries to the stored entries
+func (tm *TaskManager) Compact(threshold time.Duration) (_ *CompactResult, err error) {
+	defer tm.record("compact entries")(&err)
+
+	entries, err := tm.storage.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	compacted, result := CompactEntries(entries, threshold)
+	if len(result.Changes) == 0 {
+		return result, nil
+	}
+
+	// Compacting drops entries, so it always leaves a restore point behind
+	if backups, ok := tm.backingStorage().(interface{ CreateBackup() error }); ok {
+		if err := backups.CreateBackup(); err != nil {
+			return nil, fmt.Errorf("failed to back up data file: %w", err)
+		}
+	}
+	if err := tm.storage.Save(compacted); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// sameCompactEntry reports whether b continues a: both blank, or the same project and title
+func sameCompactEntry(a, b models.TimeEntry) bool {
+	if a.IsBlank() || b.IsBlank() {
+		return a.IsBlank() && b.IsBlank()
+	}
+	return a.Project == b.Project && a.Title == b.Title
+}
+
+// joinNotes appends notes on a new line unless they are empty or already present
+func joinNotes(notes, more string) string {
+	switch {
+	case more == "" || strings.Contains(notes, more):
+		return notes
+	case notes == "":
+		return more
+	default:
+		return notes + "\n" + more
+	}
+}
+
+// formatShortDuration formats durations under a minute in seconds, like the fragments compact removes
+func formatShortDuration(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	return FormatDuration(d)
+}
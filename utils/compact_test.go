@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+)
+
+func churnEntries(start time.Time) []models.TimeEntry {
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+	return []models.TimeEntry{
+		{Start: at(0), Project: "Acme", Title: "Build", Tags: []string{"dev"}, Notes: "compiled"},
+		{Start: at(30 * time.Minute), Project: "Acme", Title: "Build", Tags: []string{"review"}, Notes: "tested"},
+		{Start: at(time.Hour)},
+		{Start: at(time.Hour + 10*time.Second), Project: "Beta", Title: "Mail"},
+		{Start: at(time.Hour + 40*time.Second), Project: "Acme", Title: "Build"},
+		{Start: at(2 * time.Hour)},
+	}
+}
+
+func TestCompactEntries_DropsMicroEntriesAndMergesRepeats(t *testing.T) {
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+
+	compacted, result := CompactEntries(churnEntries(start), time.Minute)
+
+	if len(compacted) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", compacted)
+	}
+	first := compacted[0]
+	if first.Project != "Acme" || !first.Start.Equal(start) || first.End == nil || !first.End.Equal(start.Add(2*time.Hour)) {
+		t.Fatalf("expected Acme: Build from 09:00 to 11:00, got %+v", first)
+	}
+	if FormatTags(first.Tags) != "dev, review" || first.Notes != "compiled\ntested" {
+		t.Fatalf("expected combined tags and notes, got %q and %q", FormatTags(first.Tags), first.Notes)
+	}
+	if !compacted[1].IsBlank() || compacted[1].End != nil {
+		t.Fatalf("expected the trailing gap to be kept, got %+v", compacted[1])
+	}
+
+	if result.Before != 6 || result.After != 2 || result.Merged() != 2 || result.Dropped() != 2 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if got := result.Changes[1].String(); !strings.HasPrefix(got, "drop ") || !strings.Contains(got, "blank entry, 10s") {
+		t.Fatalf("unexpected change description %q", got)
+	}
+}
+
+func TestCompactEntries_ZeroThresholdOnlyMerges(t *testing.T) {
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+
+	compacted, result := CompactEntries(churnEntries(start), 0)
+
+	if result.Dropped() != 0 || result.Merged() != 1 || len(compacted) != 5 {
+		t.Fatalf("expected only the repeated entry to be merged, got %+v", result)
+	}
+}
+
+func TestCompactEntries_DroppedFirstEntryKeepsMergedStart(t *testing.T) {
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	entries := []models.TimeEntry{
+		{Start: start, Project: "Beta", Title: "Mail"},
+		{Start: start.Add(time.Hour), Project: "Acme", Title: "Build"},
+		{Start: start.Add(time.Hour + 20*time.Second), Project: "Acme", Title: "Build"},
+		{Start: start.Add(2 * time.Hour)},
+	}
+
+	compacted, result := CompactEntries(entries, time.Minute)
+
+	if result.Dropped() != 1 || len(compacted) != 3 {
+		t.Fatalf("expected the first Acme fragment to be dropped, got %+v", result)
+	}
+	merged := compacted[1]
+	if merged.Project != "Acme" || !merged.Start.Equal(start.Add(time.Hour)) {
+		t.Fatalf("expected Acme: Build to start at 10:00, got %+v", merged)
+	}
+	if !compacted[0].End.Equal(start.Add(time.Hour)) {
+		t.Fatalf("expected Beta: Mail to keep ending at 10:00, got %+v", compacted[0])
+	}
+}
+
+func TestCompactEntries_MergesIntoRunningEntry(t *testing.T) {
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	entries := []models.TimeEntry{
+		{Start: start, Project: "Acme", Title: "Build"},
+		{Start: start.Add(time.Hour), Project: "Acme", Title: "Build"},
+	}
+
+	compacted, result := CompactEntries(entries, time.Minute)
+
+	if len(compacted) != 1 || !compacted[0].IsRunning() || !compacted[0].Start.Equal(start) {
+		t.Fatalf("expected one running entry from 09:00, got %+v", compacted)
+	}
+	if !strings.Contains(result.Changes[0].String(), "running") {
+		t.Fatalf("unexpected change description %q", result.Changes[0].String())
+	}
+}
+
+func TestTaskManager_CompactCanBeUndone(t *testing.T) {
+	storage, tm := newHistoryTaskManager()
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	if err := storage.Save(churnEntries(start)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tm.Compact(time.Minute); err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	entries, _ := storage.Load()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries after compacting, got %d", len(entries))
+	}
+
+	record, err := tm.Undo()
+	if err != nil || record.Description != "compact entries" {
+		t.Fatalf("expected to undo compacting, got %+v (%v)", record, err)
+	}
+	entries, _ = storage.Load()
+	if len(entries) != 6 {
+		t.Fatalf("expected the 6 original entries back, got %d", len(entries))
+	}
+}
+
+func TestTaskManager_CompactBacksUpFileStorage(t *testing.T) {
+	storage, err := NewFileStorage(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("Failed to create file storage: %v", err)
+	}
+	if err := storage.Save(churnEntries(time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC))); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	before, _ := storage.ListBackups()
+
+	if _, err := NewTaskManager(storage).Compact(time.Minute); err != nil {
+		t.Fatalf("Compact returned error: %v", err)
+	}
+
+	after, _ := storage.ListBackups()
+	if len(after) != len(before)+1 {
+		t.Fatalf("Expected compact to add a backup despite the interval, got %d then %d", len(before), len(after))
+	}
+	entries, _, err := LoadBackup(after[0])
+	if err != nil || len(entries) != 6 {
+		t.Fatalf("Expected the newest backup to hold the uncompacted entries, got %d (%v)", len(entries), err)
+	}
+}
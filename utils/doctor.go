@@ -0,0 +1,331 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"time-tracker/models"
+)
+
+// LongEntryThreshold is the duration beyond which doctor reports an entry as suspiciously long
+const LongEntryThreshold = 24 * time.Hour
+
+// Doctor checks, in the order they are reported
+const (
+	CheckVersion        = "version"
+	CheckFutureStart    = "future-start"
+	CheckOrdering       = "ordering"
+	CheckDuplicateStart = "duplicate-start"
+	CheckLongEntry      = "long-entry"
+	CheckProjectName    = "project-name"
+	CheckDuplicateEntry = "duplicate-entry"
+)
+
+// DoctorIssue is a single problem found in the data file
+type DoctorIssue struct {
+	Check   string
+	Message string
+	Fixable bool
+}
+
+// DoctorReport is the result of checking a data file, with entries in file order
+type DoctorReport struct {
+	Version  int
+	Entries  []models.TimeEntry
+	Projects []models.Project
+	Issues   []DoctorIssue
+}
+
+// Fixable returns the number of issues Fix repairs
+func (r *DoctorReport) Fixable() int {
+	count := 0
+	for _, issue := range r.Issues {
+		if issue.Fixable {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *DoctorReport) add(check string, fixable bool, format string, args ...any) {
+	r.Issues = append(r.Issues, DoctorIssue{Check: check, Message: fmt.Sprintf(format, args...), Fixable: fixable})
+}
+
+// Diagnose checks the raw content of a data file for problems
+func Diagnose(jsonData []byte, now time.Time) (*DoctorReport, error) {
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(jsonData, &header); err != nil {
+		return nil, fmt.Errorf("failed to parse data: %w", err)
+	}
+
+	report := &DoctorReport{Version: header.Version}
+	if header.Version < 0 || header.Version > models.CurrentVersion {
+		report.add(CheckVersion, false, "unknown schema version %d (this build supports up to %d)", header.Version, models.CurrentVersion)
+		return report, nil
+	}
+
+	entries, err := parseEntries(jsonData)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := parseV14Projects(jsonData)
+	if err != nil {
+		return nil, err
+	}
+	report.Entries = entries
+	report.Projects = fromV14Projects(projects)
+
+	for i, entry := range entries {
+		if entry.Start.After(now) {
+			report.add(CheckFutureStart, false, "entry %s starts in the future", describeDoctorEntry(entry))
+		}
+		if i > 0 && entry.Start.Before(entries[i-1].Start) {
+			report.add(CheckOrdering, true, "entry %s is stored after the later entry %s", describeDoctorEntry(entry), describeDoctorEntry(entries[i-1]))
+		}
+	}
+
+	sorted := sortedEntries(entries)
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Start.Equal(sorted[i-1].Start) {
+			report.add(CheckDuplicateStart, true, "entries %s and %s start at the same time", describeDoctorEntry(sorted[i-1]), describeDoctorEntry(sorted[i]))
+		}
+	}
+
+	for i, entry := range sorted {
+		if entry.IsBlank() {
+			continue
+		}
+		end := now
+		if i < len(sorted)-1 {
+			end = sorted[i+1].Start
+		}
+		if duration := end.Sub(entry.Start); duration > LongEntryThreshold {
+			state := "lasts"
+			if i == len(sorted)-1 {
+				state = "has been running for"
+			}
+			report.add(CheckLongEntry, false, "entry %s %s %s", describeDoctorEntry(entry), state, FormatDuration(duration))
+		}
+	}
+
+	for _, variants := range projectNameVariants(report.Projects, entries) {
+		if len(variants) == 1 {
+			report.add(CheckProjectName, true, "project name %q has surrounding whitespace", variants[0])
+			continue
+		}
+		report.add(CheckProjectName, true, "project names %s differ only by case or whitespace and will be merged into %q", quoteAll(variants), strings.TrimSpace(variants[0]))
+	}
+
+	canonical := canonicalProjectNames(report.Projects, entries)
+	for i := 1; i < len(sorted); i++ {
+		previous, entry := sorted[i-1], sorted[i]
+		if entry.Start.Equal(previous.Start) {
+			continue
+		}
+		if sameDoctorEntry(previous, entry, canonical) {
+			report.add(CheckDuplicateEntry, true, "entry %s repeats the previous entry", describeDoctorEntry(entry))
+		}
+	}
+
+	return report, nil
+}
+
+// Fix returns the entries and projects with all fixable issues repaired
+func (r *DoctorReport) Fix() ([]models.TimeEntry, []models.Project) {
+	canonical := canonicalProjectNames(r.Projects, r.Entries)
+
+	var entries []models.TimeEntry
+	for _, entry := range sortedEntries(r.Entries) {
+		entry.End = nil
+		if name, ok := canonical[projectKey(entry.Project)]; ok {
+			entry.Project = name
+		}
+
+		if n := len(entries); n > 0 {
+			if entries[n-1].Start.Equal(entry.Start) {
+				entries[n-1] = entry
+				continue
+			}
+			if sameDoctorEntry(entries[n-1], entry, canonical) {
+				continue
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	for i := 0; i < len(entries)-1; i++ {
+		next := entries[i+1].Start
+		entries[i].End = &next
+	}
+
+	var projects []models.Project
+	seen := make(map[string]bool, len(r.Projects))
+	for _, project := range r.Projects {
+		key := projectKey(project.Name)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		project.Name = canonical[key]
+		projects = append(projects, project)
+	}
+
+	return entries, projects
+}
+
+// DoctorEntryLines formats entries and projects one per line, for showing what Fix changes
+func DoctorEntryLines(entries []models.TimeEntry, projects []models.Project) []string {
+	lines := make([]string, 0, len(entries)+len(projects))
+	for _, project := range projects {
+		lines = append(lines, fmt.Sprintf("project %q code=%q category=%q", project.Name, project.Code, project.Category))
+	}
+	for _, entry := range entries {
+		line := fmt.Sprintf("entry   %s %q %q", FormatDateTime(entry.Start.Local()), entry.Project, entry.Title)
+		if len(entry.Tags) > 0 {
+			line += " tags=" + strings.Join(entry.Tags, ",")
+		}
+		if entry.Notes != "" {
+			line += fmt.Sprintf(" notes=%q", entry.Notes)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// DiffLines returns the lines removed from before ("- ") and added in after ("+ ")
+func DiffLines(before, after []string) []string {
+	remaining := make(map[string]int, len(after))
+	for _, line := range after {
+		remaining[line]++
+	}
+
+	type diffLine struct {
+		text string
+		sign string
+	}
+	var diff []diffLine
+	for _, line := range before {
+		if remaining[line] > 0 {
+			remaining[line]--
+			continue
+		}
+		diff = append(diff, diffLine{text: line, sign: "-"})
+	}
+
+	unmatched := make(map[string]int, len(before))
+	for _, line := range before {
+		unmatched[line]++
+	}
+	for _, line := range after {
+		if unmatched[line] > 0 {
+			unmatched[line]--
+			continue
+		}
+		diff = append(diff, diffLine{text: line, sign: "+"})
+	}
+
+	sort.SliceStable(diff, func(i, j int) bool {
+		if diff[i].text != diff[j].text {
+			return diff[i].text < diff[j].text
+		}
+		return diff[i].sign < diff[j].sign
+	})
+
+	lines := make([]string, len(diff))
+	for i, line := range diff {
+		lines[i] = line.sign + " " + line.text
+	}
+	return lines
+}
+
+func sortedEntries(entries []models.TimeEntry) []models.TimeEntry {
+	sorted := append([]models.TimeEntry(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Start.Before(sorted[j].Start)
+	})
+	return sorted
+}
+
+func projectKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// projectNameVariants groups the project name spellings that normalizeProjects would collapse
+func projectNameVariants(projects []models.Project, entries []models.TimeEntry) [][]string {
+	var keys []string
+	groups := make(map[string][]string)
+	addName := func(name string) {
+		key := projectKey(name)
+		if key == "" || slices.Contains(groups[key], name) {
+			return
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], name)
+	}
+
+	for _, project := range projects {
+		addName(project.Name)
+	}
+	for _, entry := range entries {
+		addName(entry.Project)
+	}
+
+	var variants [][]string
+	for _, key := range keys {
+		if group := groups[key]; len(group) > 1 || group[0] != strings.TrimSpace(group[0]) {
+			variants = append(variants, groups[key])
+		}
+	}
+	return variants
+}
+
+// canonicalProjectNames maps each project key to the spelling normalizeProjects keeps
+func canonicalProjectNames(projects []models.Project, entries []models.TimeEntry) map[string]string {
+	canonical := make(map[string]string)
+	for _, project := range projects {
+		if key := projectKey(project.Name); key != "" {
+			if _, ok := canonical[key]; !ok {
+				canonical[key] = strings.TrimSpace(project.Name)
+			}
+		}
+	}
+	for _, entry := range entries {
+		if key := projectKey(entry.Project); key != "" {
+			if _, ok := canonical[key]; !ok {
+				canonical[key] = strings.TrimSpace(entry.Project)
+			}
+		}
+	}
+	return canonical
+}
+
+// sameDoctorEntry reports whether b merely continues a, comparing project names the way Fix merges them
+func sameDoctorEntry(a, b models.TimeEntry, canonical map[string]string) bool {
+	if a.IsBlank() != b.IsBlank() {
+		return false
+	}
+	return canonical[projectKey(a.Project)] == canonical[projectKey(b.Project)] &&
+		a.Title == b.Title &&
+		a.Notes == b.Notes &&
+		slices.Equal(a.Tags, b.Tags)
+}
+
+func describeDoctorEntry(entry models.TimeEntry) string {
+	return fmt.Sprintf("%s (%s)", FormatDateTime(entry.Start.Local()), describeEntry(entry.Project, entry.Title))
+}
+
+func quoteAll(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func doctorChecks(report *DoctorReport) []string {
+	checks := make([]string, len(report.Issues))
+	for i, issue := range report.Issues {
+		checks[i] = issue.Check
+	}
+	return checks
+}
+
+func TestDiagnose_CleanData(t *testing.T) {
+	data := `{"version": 7, "time-entries": [
+		{"start": "2025-03-10T09:00:00Z", "project": "Alpha", "title": "Design"},
+		{"start": "2025-03-10T10:00:00Z", "project": "", "title": ""}
+	], "projects": [{"name": "Alpha"}]}`
+
+	report, err := Diagnose([]byte(data), time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+	if len(report.Issues) != 0 {
+		t.Fatalf("expected no issues, got %+v", report.Issues)
+	}
+}
+
+func TestDiagnose_UnknownVersion(t *testing.T) {
+	report, err := Diagnose([]byte(`{"version": 99, "time-entries": []}`), time.Now())
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+	if len(report.Issues) != 1 || report.Issues[0].Check != CheckVersion || report.Issues[0].Fixable {
+		t.Fatalf("expected a single unfixable version issue, got %+v", report.Issues)
+	}
+}
+
+func TestDiagnose_ReportsProblems(t *testing.T) {
+	data := `{"version": 7, "time-entries": [
+		{"start": "2025-03-10T09:00:00Z", "project": "Alpha", "title": "Design"},
+		{"start": "2025-03-08T09:00:00Z", "project": "alpha ", "title": "Design"},
+		{"start": "2025-03-10T10:00:00Z", "project": "Alpha", "title": "Design"},
+		{"start": "2025-03-10T11:00:00Z", "project": "Beta", "title": "Review"},
+		{"start": "2025-03-10T11:00:00Z", "project": "Beta", "title": "Other"},
+		{"start": "2025-03-20T09:00:00Z", "project": "", "title": ""}
+	], "projects": [{"name": "Alpha", "code": "A-1"}]}`
+
+	report, err := Diagnose([]byte(data), time.Date(2025, 3, 12, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+
+	checks := strings.Join(doctorChecks(report), ",")
+	for _, want := range []string{CheckFutureStart, CheckOrdering, CheckDuplicateStart, CheckLongEntry, CheckProjectName, CheckDuplicateEntry} {
+		if !strings.Contains(checks, want) {
+			t.Errorf("expected a %s issue, got %s", want, checks)
+		}
+	}
+}
+
+func TestDoctorReport_Fix(t *testing.T) {
+	data := `{"version": 7, "time-entries": [
+		{"start": "2025-03-10T10:00:00Z", "project": "alpha ", "title": "Design"},
+		{"start": "2025-03-10T09:00:00Z", "project": "Alpha", "title": "Design"},
+		{"start": "2025-03-10T11:00:00Z", "project": "Beta", "title": "Review"},
+		{"start": "2025-03-10T11:00:00Z", "project": "Beta", "title": "Other"},
+		{"start": "2025-03-10T12:00:00Z", "project": "", "title": ""},
+		{"start": "2025-03-10T13:00:00Z", "project": "", "title": ""}
+	], "projects": [{"name": "Alpha", "code": "A-1"}, {"name": "ALPHA"}]}`
+
+	report, err := Diagnose([]byte(data), time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+
+	entries, projects := report.Fix()
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries after fixing, got %+v", entries)
+	}
+	if entries[0].Project != "Alpha" || entries[0].End == nil || !entries[0].End.Equal(entries[1].Start) {
+		t.Errorf("expected merged Alpha entry first, got %+v", entries[0])
+	}
+	if entries[1].Title != "Other" {
+		t.Errorf("expected the last entry sharing a start to be kept, got %+v", entries[1])
+	}
+	if !entries[2].IsBlank() || entries[2].End != nil {
+		t.Errorf("expected a single trailing blank entry, got %+v", entries[2])
+	}
+	if len(projects) != 1 || projects[0].Name != "Alpha" || projects[0].Code != "A-1" {
+		t.Fatalf("expected a single Alpha project, got %+v", projects)
+	}
+
+	fixed := strings.Join(DiffLines(DoctorEntryLines(report.Entries, report.Projects), DoctorEntryLines(entries, projects)), "\n")
+	if !strings.Contains(fixed, `- entry`) || !strings.Contains(fixed, `"alpha "`) {
+		t.Fatalf("expected the diff to show removed entries, got:\n%s", fixed)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	diff := DiffLines([]string{"a", "b", "c", "c"}, []string{"a", "c", "d"})
+	want := []string{"- b", "- c", "+ d"}
+	if strings.Join(diff, "|") != strings.Join(want, "|") {
+		t.Fatalf("expected %v, got %v", want, diff)
+	}
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+
+	"time-tracker/models"
+)
+
+// entryIDRange keeps entry IDs at exactly five base-36 characters, e.g. "k3x9a"
+const (
+	entryIDMin   = 36 * 36 * 36 * 36
+	entryIDRange = 36*36*36*36*36 - entryIDMin
+)
+
+// assignEntryIDs gives every entry without an ID one derived from its start, project, and title
+func assignEntryIDs(entries []models.TimeEntry) {
+	used := make(map[string]bool, len(entries))
+	var missing []int
+	for i, entry := range entries {
+		if entry.ID == "" {
+			missing = append(missing, i)
+			continue
+		}
+		used[entry.ID] = true
+	}
+
+	sort.SliceStable(missing, func(a, b int) bool {
+		return entries[missing[a]].Start.Before(entries[missing[b]].Start)
+	})
+	for _, i := range missing {
+		for attempt := 0; ; attempt++ {
+			id := entryID(entries[i], attempt)
+			if !used[id] {
+				entries[i].ID = id
+				used[id] = true
+				break
+			}
+		}
+	}
+}
+
+// entryID derives a candidate ID; attempt is raised to resolve collisions
+func entryID(entry models.TimeEntry, attempt int) string {
+	hash := fnv.New32a()
+	fmt.Fprintf(hash, "%d|%s|%s|%d", entry.Start.UnixNano(), entry.Project, entry.Title, attempt)
+	return strconv.FormatUint(uint64(entryIDMin+hash.Sum32()%entryIDRange), 36)
+}
+
+// EntryIndexByID returns the index of the entry with the given ID
+func EntryIndexByID(entries []models.TimeEntry, id string) (int, error) {
+	id = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(id), "#"))
+	if id == "" {
+		return -1, fmt.Errorf("entry ID cannot be empty")
+	}
+	for i, entry := range entries {
+		if entry.ID == id {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("no entry with ID %s", id)
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"time-tracker/models"
+)
+
+func TestAssignEntryIDs_KeepsExistingAndResolvesCollisions(t *testing.T) {
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	entries := []models.TimeEntry{
+		{Start: start.Add(time.Hour), Project: "Acme", Title: "Build"},
+		{ID: "abcde", Start: start},
+		{Start: start.Add(2 * time.Hour)},
+	}
+	// Another entry already holds the ID the first entry would get
+	taken := entryID(entries[0], 0)
+	entries = append(entries, models.TimeEntry{ID: taken, Start: start.Add(3 * time.Hour)})
+
+	assignEntryIDs(entries)
+
+	if entries[1].ID != "abcde" || entries[3].ID != taken {
+		t.Fatalf("Expected existing IDs to be kept, got %+v", entries)
+	}
+	if entries[0].ID == taken || len(entries[0].ID) != 5 || len(entries[2].ID) != 5 || entries[0].ID == entries[2].ID {
+		t.Fatalf("Expected new entries to get distinct five-character IDs, got %+v", entries)
+	}
+}
+
+func TestEntryIndexByID(t *testing.T) {
+	entries := []models.TimeEntry{{ID: "a1b2c"}, {ID: "k3x9a"}}
+
+	for _, id := range []string{"k3x9a", "#k3x9a", " K3X9A "} {
+		if idx, err := EntryIndexByID(entries, id); err != nil || idx != 1 {
+			t.Errorf("EntryIndexByID(%q) = %d, %v; expected 1", id, idx, err)
+		}
+	}
+	if _, err := EntryIndexByID(entries, "zzzzz"); err == nil {
+		t.Error("Expected an unknown ID to fail")
+	}
+	if _, err := EntryIndexByID(entries, ""); err == nil {
+		t.Error("Expected an empty ID to fail")
+	}
+}
+
+func TestDeleteEntry_RetiresTheEntryID(t *testing.T) {
+	storage := NewMemoryStorage()
+	tm := NewTaskManager(storage)
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	if err := storage.Save([]models.TimeEntry{
+		{Start: start, Project: "Acme", Title: "Build"},
+		{Start: start.Add(time.Hour), Project: "Beta", Title: "Docs"},
+	}); err != nil {
+		t.Fatalf("Failed to seed entries: %v", err)
+	}
+	before, _ := storage.Load()
+
+	if err := tm.DeleteEntry(1); err != nil {
+		t.Fatalf("DeleteEntry failed: %v", err)
+	}
+
+	entries, _ := storage.Load()
+	if !entries[1].IsBlank() || entries[1].ID == "" || entries[1].ID == before[1].ID || entries[0].ID != before[0].ID {
+		t.Fatalf("Expected the gap to get a new ID and the other entry to keep its own, got %+v", entries)
+	}
+	if _, err := EntryIndexByID(entries, before[1].ID); err == nil {
+		t.Fatal("Expected the deleted entry's ID to no longer resolve")
+	}
+}
@@ -13,6 +13,7 @@ import (
 // Assumes entries are already aggregated and filtered (e.g., via AggregateByProjectDate).
 // Running and blank entries are already excluded in the aggregation step.
 // Returns a TSV string with columns: ProjectName, ProjectCode, ProjectCategory, Date, Duration, Description
+// The Description lists the task titles followed by any entry notes.
 // Returns an error if any write operation fails.
 func ExportDailyProjects(entries []ProjectDateEntry) (string, error) {
 	var buf bytes.Buffer
@@ -28,7 +29,7 @@ func ExportDailyProjects(entries []ProjectDateEntry) (string, error) {
 	for _, entry := range entries {
 		dateStr := entry.Date.Format("2006-01-02")
 		durationMin := int64(entry.Duration.Minutes())
-		description := strings.Join(entry.Tasks, ", ")
+		description := describeProjectDate(entry)
 
 		if err := writer.Write([]string{
 			entry.Project,
@@ -50,9 +51,24 @@ func ExportDailyProjects(entries []ProjectDateEntry) (string, error) {
 	return buf.String(), nil
 }
 
+// describeProjectDate builds the Description column for an aggregated entry
+func describeProjectDate(entry ProjectDateEntry) string {
+	description := strings.Join(entry.Tasks, ", ")
+	if len(entry.Notes) == 0 {
+		return description
+	}
+
+	notes := strings.Join(entry.Notes, "; ")
+	if description == "" {
+		return notes
+	}
+	return description + " - " + notes
+}
+
 // ExportRaw exports raw time entries as TSV format.
 // Filters out blank entries (empty project and title) and running entries (no End time).
-// Returns a TSV string with columns: Project, Task, Start, End, Duration
+// Returns a TSV string with columns: Project, Task, Start, End, Duration, Tags, Description
+// where Description holds the entry notes.
 // Returns an error if any write operation fails.
 func ExportRaw(entries []models.TimeEntry) (string, error) {
 	var buf bytes.Buffer
@@ -60,7 +76,7 @@ func ExportRaw(entries []models.TimeEntry) (string, error) {
 	writer.Comma = '\t'
 
 	// Write header
-	if err := writer.Write([]string{"Project", "Task", "Start", "End", "Duration"}); err != nil {
+	if err := writer.Write([]string{"Project", "Task", "Start", "End", "Duration", "Tags", "Description"}); err != nil {
 		return "", fmt.Errorf("failed to write header: %w", err)
 	}
 
@@ -86,6 +102,8 @@ func ExportRaw(entries []models.TimeEntry) (string, error) {
 			startStr,
 			endStr,
 			fmt.Sprintf("%d", durationMin),
+			strings.Join(entry.Tags, ","),
+			entry.Notes,
 		}); err != nil {
 			return "", fmt.Errorf("failed to write row for entry %q: %w", entry.Title, err)
 		}
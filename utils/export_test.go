@@ -81,6 +81,30 @@ func TestExportDailyProjectsBasic(t *testing.T) {
 	}
 }
 
+func TestExportDailyProjectsIncludesNotesInDescription(t *testing.T) {
+	start := time.Date(2025, 12, 23, 9, 0, 0, 0, time.UTC)
+	mid := start.Add(time.Hour)
+	end := mid.Add(time.Hour)
+	aggregated := AggregateByProjectDate([]models.TimeEntry{
+		{Start: start, End: &mid, Project: "ProjectA", Title: "Task1", Notes: "Drafted API\n\nWrote tests"},
+		{Start: mid, End: &end, Project: "ProjectA", Title: "Task2", Notes: "Reviewed PR"},
+	})
+
+	result, err := ExportDailyProjects(aggregated)
+	if err != nil {
+		t.Fatalf("ExportDailyProjects failed: %v", err)
+	}
+	records := parseRawTSV(t, result)
+
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 rows (header + 1 data), got %d", len(records))
+	}
+	expected := "Task1, Task2 - Drafted API; Wrote tests; Reviewed PR"
+	if records[1][5] != expected {
+		t.Errorf("Expected description %q, got %q", expected, records[1][5])
+	}
+}
+
 func TestExportDailyProjectsWithSpecialCharacters(t *testing.T) {
 	date := time.Date(2025, 12, 23, 0, 0, 0, 0, time.UTC)
 	entries := []ProjectDateEntry{
@@ -153,7 +177,7 @@ func TestExportRawHeader(t *testing.T) {
 		t.Fatalf("Expected at least header row, got %d rows", len(records))
 	}
 
-	expectedHeader := []string{"Project", "Task", "Start", "End", "Duration"}
+	expectedHeader := []string{"Project", "Task", "Start", "End", "Duration", "Tags", "Description"}
 	if !slices.Equal(records[0], expectedHeader) {
 		t.Errorf("Expected header %v, got %v", expectedHeader, records[0])
 	}
@@ -168,6 +192,8 @@ func TestExportRawBasic(t *testing.T) {
 			End:     &end,
 			Project: "ProjectA",
 			Title:   "Task1",
+			Tags:    []string{"meeting", "review"},
+			Notes:   "Paired on the parser",
 		},
 	}
 
@@ -187,6 +213,8 @@ func TestExportRawBasic(t *testing.T) {
 		start.Format(time.RFC3339),
 		end.Format(time.RFC3339),
 		"90",
+		"meeting,review",
+		"Paired on the parser",
 	}
 	if !slices.Equal(records[1], expectedRow) {
 		t.Errorf("Expected row %v, got %v", expectedRow, records[1])
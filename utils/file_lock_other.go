@@ -0,0 +1,8 @@
+//go:build !unix
+
+package utils
+
+// lockFile is a no-op on platforms without flock
+func lockFile(lockPath string) (func(), error) {
+	return func() {}, nil
+}
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,9 +16,10 @@ import (
 )
 
 type fileData struct {
-	Version     int                `json:"version"`
-	TimeEntries []models.V4Entry   `json:"time-entries"`
-	Projects    []models.V4Project `json:"projects"`
+	Version     int                 `json:"version"`
+	TimeEntries []models.V9Entry    `json:"time-entries"`
+	Projects    []models.V14Project `json:"projects"`
+	Tasks       []models.V8Task     `json:"tasks"`
 }
 
 type loadData struct {
@@ -24,9 +27,28 @@ type loadData struct {
 	TimeEntries json.RawMessage `json:"time-entries"`
 }
 
+// ConflictError is returned when another process saved the data since the caller's baseline
+type ConflictError struct {
+	FilePath string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("data file %q was modified by another process since it was loaded", e.FilePath)
+}
+
+// IsConflict reports whether err is or wraps a ConflictError
+func IsConflict(err error) bool {
+	var conflict *ConflictError
+	return errors.As(err, &conflict)
+}
+
 // FileStorage implements Storage using JSON files
 type FileStorage struct {
-	FilePath string
+	FilePath   string
+	BackupDir  string // Defaults to a "backups" directory next to FilePath
+	MaxBackups int    // Number of backups kept; 0 disables backups
+
+	loadedHash []byte // Hash of the baseline content, see Storage
 }
 
 // NewFileStorage creates a new file-based storage, initializing the file if needed
@@ -42,8 +64,9 @@ func NewFileStorage(filePath string) (*FileStorage, error) {
 		// File does not exist, create it with initial data
 		initialData := fileData{
 			Version:     models.CurrentVersion,
-			TimeEntries: []models.V4Entry{},
-			Projects:    []models.V4Project{},
+			TimeEntries: []models.V9Entry{},
+			Projects:    []models.V14Project{},
+			Tasks:       []models.V8Task{},
 		}
 		jsonData, err := json.MarshalIndent(initialData, "", "  ")
 		if err != nil {
@@ -60,7 +83,7 @@ func NewFileStorage(filePath string) (*FileStorage, error) {
 		return nil, errors.New("provided path must be a file, not a directory")
 	}
 
-	return &FileStorage{FilePath: filePath}, nil
+	return &FileStorage{FilePath: filePath, MaxBackups: DefaultMaxBackups}, nil
 }
 
 func (fs *FileStorage) Load() ([]models.TimeEntry, error) {
@@ -69,6 +92,16 @@ func (fs *FileStorage) Load() ([]models.TimeEntry, error) {
 		return nil, fmt.Errorf("failed to read data file: %w", err)
 	}
 
+	entries, err := parseEntries(jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	fs.remember(jsonData)
+	return entries, nil
+}
+
+func parseEntries(jsonData []byte) ([]models.TimeEntry, error) {
 	var loadData loadData
 	if err := json.Unmarshal(jsonData, &loadData); err != nil {
 		return nil, fmt.Errorf("failed to parse data: %w", err)
@@ -80,6 +113,10 @@ func (fs *FileStorage) Load() ([]models.TimeEntry, error) {
 	var v2Entries []models.V2Entry
 	var v3Entries []models.V3Entry
 	var v4Entries []models.V4Entry
+	var v5Entries []models.V5Entry
+	var v6Entries []models.V6Entry
+	var v8Entries []models.V8Entry
+	var v9Entries []models.V9Entry
 
 	// Step 1: Unmarshal based on version
 	switch loadData.Version {
@@ -103,8 +140,26 @@ func (fs *FileStorage) Load() ([]models.TimeEntry, error) {
 		if err := json.Unmarshal(loadData.TimeEntries, &v4Entries); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal v4 data: %w", err)
 		}
+	case 5:
+		if err := json.Unmarshal(loadData.TimeEntries, &v5Entries); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal v5 data: %w", err)
+		}
+	case 6, 7:
+		// v7 only extended project metadata, so entries keep the v6 format
+		if err := json.Unmarshal(loadData.TimeEntries, &v6Entries); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal v6 data: %w", err)
+		}
+	case 8:
+		if err := json.Unmarshal(loadData.TimeEntries, &v8Entries); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal v8 data: %w", err)
+		}
+	case 9, 10, 11, 12, 13, 14:
+		// v10 to v14 only changed project metadata, so entries keep the v9 format
+		if err := json.Unmarshal(loadData.TimeEntries, &v9Entries); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal v9 data: %w", err)
+		}
 	default:
-		if loadData.Version > 4 {
+		if loadData.Version > models.CurrentVersion {
 			return nil, fmt.Errorf("unknown version: %d", loadData.Version)
 		}
 	}
@@ -121,6 +176,14 @@ func (fs *FileStorage) Load() ([]models.TimeEntry, error) {
 			v3Entries, err = TransformV2ToV3(v2Entries)
 		case 3:
 			v4Entries, err = TransformV3ToV4(v3Entries)
+		case 4:
+			v5Entries, err = TransformV4ToV5(v4Entries)
+		case 5:
+			v6Entries, err = TransformV5ToV6(v5Entries)
+		case 7:
+			v8Entries, err = TransformV7ToV8(v6Entries)
+		case 8:
+			v9Entries, err = TransformV8ToV9(v8Entries)
 		}
 		if err != nil {
 			return nil, fmt.Errorf("migration from version %d failed: %w", v, err)
@@ -128,13 +191,11 @@ func (fs *FileStorage) Load() ([]models.TimeEntry, error) {
 	}
 
 	var entries []models.TimeEntry
-	for _, v4 := range v4Entries {
-		entries = append(entries, models.TimeEntry{
-			Start:   v4.Start,
-			Project: v4.Project,
-			Title:   v4.Title,
-		})
+	for _, v9 := range v9Entries {
+		entries = append(entries, fromV9Entry(v9))
 	}
+	// Entries added by hand get an ID here, the same one on every load until they are saved
+	assignEntryIDs(entries)
 
 	// Reconstruct End times from next entry's Start time for all entries
 	for i := 0; i < len(entries)-1; i++ {
@@ -154,64 +215,192 @@ func (fs *FileStorage) Save(entries []models.TimeEntry) error {
 	// older version and migrated, this will upgrade the on-disk format to
 	// include migrated changes (e.g., blank entries).
 
-	projects, err := fs.LoadProjects()
+	return fs.update(func(data *storedData) {
+		data.entries = entries
+	})
+}
+
+// storedData is the content of the data file
+type storedData struct {
+	entries  []models.TimeEntry
+	projects []models.Project
+	tasks    []models.Task
+}
+
+func parseStoredData(jsonData []byte) (storedData, error) {
+	entries, err := parseEntries(jsonData)
 	if err != nil {
-		return err
+		return storedData{}, err
 	}
-
-	return fs.saveEntriesAndProjects(entries, projects)
+	projects, err := parseStoredProjects(jsonData)
+	if err != nil {
+		return storedData{}, err
+	}
+	tasks, err := parseTasks(jsonData)
+	if err != nil {
+		return storedData{}, err
+	}
+	return storedData{entries: entries, projects: projects, tasks: tasks}, nil
 }
 
-func (fs *FileStorage) saveEntriesAndProjects(entries []models.TimeEntry, projects []models.Project) error {
-	saved := toSortedV4Entries(entries)
+// update rewrites the data file under an exclusive lock with the parts changed by modify
+func (fs *FileStorage) update(modify func(data *storedData)) error {
+	unlock, err := lockFile(fs.FilePath + ".lock")
+	if err != nil {
+		return fmt.Errorf("failed to lock data file: %w", err)
+	}
+	defer unlock()
+
+	jsonData, err := os.ReadFile(fs.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read data file: %w", err)
+	}
+	if fs.loadedHash != nil && !bytes.Equal(fs.loadedHash, hashData(jsonData)) {
+		// The caller has to load the data again, which takes the new baseline
+		fs.loadedHash = nil
+		return &ConflictError{FilePath: fs.FilePath}
+	}
+
+	stored, err := parseStoredData(jsonData)
+	if err != nil {
+		return err
+	}
+	modify(&stored)
+
+	if err := fs.createBackup(jsonData); err != nil {
+		return err
+	}
 
 	data := fileData{
 		Version:     models.CurrentVersion,
-		TimeEntries: saved,
-		Projects:    toV4Projects(projects),
+		TimeEntries: toSortedV9Entries(stored.entries),
+		Projects:    toV14Projects(stored.projects),
+		Tasks:       toV8Tasks(stored.tasks),
 	}
 
 	return fs.writeDataAtomic(data)
 }
 
-func toSortedV4Entries(entries []models.TimeEntry) []models.V4Entry {
+// remember takes the loaded content as the baseline of later saves, unless there already is one
+func (fs *FileStorage) remember(jsonData []byte) {
+	if fs.loadedHash == nil {
+		fs.loadedHash = hashData(jsonData)
+	}
+}
+
+// ResetBaseline makes the next load the baseline of later saves, see Storage
+func (fs *FileStorage) ResetBaseline() {
+	fs.loadedHash = nil
+}
+
+func hashData(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+// toSortedV9Entries converts entries for saving, giving new entries an ID
+func toSortedV9Entries(entries []models.TimeEntry) []models.V9Entry {
 	// Sort entries by start time before saving
 	sorted := append([]models.TimeEntry(nil), entries...)
+	assignEntryIDs(sorted)
 	sort.Slice(sorted, func(i, j int) bool {
 		return sorted[i].Start.Before(sorted[j].Start)
 	})
 
-	saved := make([]models.V4Entry, len(sorted))
+	saved := make([]models.V9Entry, len(sorted))
 	for i, entry := range sorted {
-		saved[i] = models.V4Entry{
+		saved[i] = models.V9Entry{
+			ID:      entry.ID,
 			Start:   entry.Start,
 			Project: entry.Project,
 			Title:   entry.Title,
+			Tags:    entry.Tags,
+			Notes:   entry.Notes,
+			Task:    entry.Task,
 		}
 	}
 
 	return saved
 }
 
-func toV4Projects(projects []models.Project) []models.V4Project {
-	out := make([]models.V4Project, len(projects))
+// fromV9Entry converts a stored entry; its End is reconstructed by the caller
+func fromV9Entry(entry models.V9Entry) models.TimeEntry {
+	return models.TimeEntry{
+		ID:      entry.ID,
+		Start:   entry.Start,
+		Project: entry.Project,
+		Title:   entry.Title,
+		Tags:    entry.Tags,
+		Notes:   entry.Notes,
+		Task:    entry.Task,
+	}
+}
+
+func toV14Projects(projects []models.Project) []models.V14Project {
+	out := make([]models.V14Project, len(projects))
 	for i, project := range projects {
-		out[i] = models.V4Project{
-			Name:     project.Name,
-			Code:     project.Code,
-			Category: project.Category,
+		out[i] = models.V14Project{
+			Name:            project.Name,
+			Code:            project.Code,
+			Category:        project.Category,
+			HourlyRateCents: project.HourlyRateCents,
+			Currency:        project.Currency,
+			Billable:        project.Billable,
+			Archived:        project.Archived,
+			Parent:          project.Parent,
+			Budget:          project.Budget,
+			BudgetFrom:      project.BudgetFrom,
+			BudgetTo:        project.BudgetTo,
+			Aliases:         project.Aliases,
 		}
 	}
 	return out
 }
 
-func fromV4Projects(projects []models.V4Project) []models.Project {
+func fromV14Projects(projects []models.V14Project) []models.Project {
 	out := make([]models.Project, len(projects))
 	for i, project := range projects {
 		out[i] = models.Project{
-			Name:     project.Name,
-			Code:     project.Code,
-			Category: project.Category,
+			Name:            project.Name,
+			Code:            project.Code,
+			Category:        project.Category,
+			HourlyRateCents: project.HourlyRateCents,
+			Currency:        project.Currency,
+			Billable:        project.Billable,
+			Archived:        project.Archived,
+			Parent:          project.Parent,
+			Budget:          project.Budget,
+			BudgetFrom:      project.BudgetFrom,
+			BudgetTo:        project.BudgetTo,
+			Aliases:         project.Aliases,
+		}
+	}
+	return out
+}
+
+func toV8Tasks(tasks []models.Task) []models.V8Task {
+	out := make([]models.V8Task, len(tasks))
+	for i, task := range tasks {
+		out[i] = models.V8Task{
+			ID:        task.ID,
+			Project:   task.Project,
+			Name:      task.Name,
+			Created:   task.Created,
+			Completed: task.Completed,
+		}
+	}
+	return out
+}
+
+func fromV8Tasks(tasks []models.V8Task) []models.Task {
+	out := make([]models.Task, len(tasks))
+	for i, task := range tasks {
+		out[i] = models.Task{
+			ID:        task.ID,
+			Project:   task.Project,
+			Name:      task.Name,
+			Created:   task.Created,
+			Completed: task.Completed,
 		}
 	}
 	return out
@@ -223,7 +412,17 @@ func (fs *FileStorage) writeDataAtomic(data fileData) error {
 		return fmt.Errorf("failed to marshal data: %w", err)
 	}
 
-	dir := filepath.Dir(fs.FilePath)
+	if err := writeFileAtomic(fs.FilePath, jsonData); err != nil {
+		return err
+	}
+
+	fs.loadedHash = hashData(jsonData)
+	return nil
+}
+
+// writeFileAtomic replaces filePath with data via a synced temp file and rename
+func writeFileAtomic(filePath string, data []byte) error {
+	dir := filepath.Dir(filePath)
 	tmpFile, err := os.CreateTemp(dir, ".time-tracker-*.json")
 	if err != nil {
 		return fmt.Errorf("failed to create temp data file: %w", err)
@@ -242,7 +441,7 @@ func (fs *FileStorage) writeDataAtomic(data fileData) error {
 		return fmt.Errorf("failed to set temp file permissions: %w", err)
 	}
 
-	if _, err := tmpFile.Write(jsonData); err != nil {
+	if _, err := tmpFile.Write(data); err != nil {
 		_ = tmpFile.Close()
 		return fmt.Errorf("failed to write temp data file: %w", err)
 	}
@@ -256,7 +455,7 @@ func (fs *FileStorage) writeDataAtomic(data fileData) error {
 		return fmt.Errorf("failed to close temp data file: %w", err)
 	}
 
-	if err := os.Rename(tmpPath, fs.FilePath); err != nil {
+	if err := os.Rename(tmpPath, filePath); err != nil {
 		return fmt.Errorf("failed to atomically replace data file: %w", err)
 	}
 
@@ -270,23 +469,77 @@ func (fs *FileStorage) LoadProjects() ([]models.Project, error) {
 		return nil, fmt.Errorf("failed to read data file: %w", err)
 	}
 
+	projects, err := parseProjects(jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	fs.remember(jsonData)
+	return projects, nil
+}
+
+// LoadStoredProjects returns the saved projects, without the ones only named by entries
+func (fs *FileStorage) LoadStoredProjects() ([]models.Project, error) {
+	jsonData, err := os.ReadFile(fs.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data file: %w", err)
+	}
+	return parseStoredProjects(jsonData)
+}
+
+func parseStoredProjects(jsonData []byte) ([]models.Project, error) {
+	projects, err := parseV14Projects(jsonData)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeProjects(fromV14Projects(projects)), nil
+}
+
+// parseV14Projects reads the projects of a data file, migrating older formats to v14
+func parseV14Projects(jsonData []byte) ([]models.V14Project, error) {
 	var data struct {
-		Projects []models.V4Project `json:"projects"`
+		Version  int             `json:"version"`
+		Projects json.RawMessage `json:"projects"`
 	}
 	if err := json.Unmarshal(jsonData, &data); err != nil {
 		return nil, fmt.Errorf("failed to parse data: %w", err)
 	}
-	if data.Projects == nil {
-		data.Projects = []models.V4Project{}
+	if len(data.Projects) == 0 || string(data.Projects) == "null" {
+		return []models.V14Project{}, nil
+	}
+
+	if data.Version >= 14 {
+		var projects []models.V14Project
+		if err := json.Unmarshal(data.Projects, &projects); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal v14 projects: %w", err)
+		}
+		return projects, nil
+	}
+
+	// Older project formats are a subset of v13, so they unmarshal directly
+	var v13Projects []models.V13Project
+	if err := json.Unmarshal(data.Projects, &v13Projects); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal v13 projects: %w", err)
+	}
+	projects, err := TransformV13ToV14(v13Projects)
+	if err != nil {
+		return nil, fmt.Errorf("migration from version 13 failed: %w", err)
+	}
+	return projects, nil
+}
+
+func parseProjects(jsonData []byte) ([]models.Project, error) {
+	projects, err := parseStoredProjects(jsonData)
+	if err != nil {
+		return nil, err
 	}
 
-	projects := fromV4Projects(data.Projects)
 	byName := make(map[string]struct{}, len(projects))
 	for _, project := range projects {
 		byName[project.Name] = struct{}{}
 	}
 
-	entries, err := fs.Load()
+	entries, err := parseEntries(jsonData)
 	if err != nil {
 		return nil, err
 	}
@@ -310,10 +563,46 @@ func (fs *FileStorage) LoadProjects() ([]models.Project, error) {
 }
 
 func (fs *FileStorage) SaveProjects(projects []models.Project) error {
-	entries, err := fs.Load()
+	return fs.update(func(data *storedData) {
+		data.projects = projects
+	})
+}
+
+// SaveAll replaces the entries, projects, and tasks in one write
+func (fs *FileStorage) SaveAll(entries []models.TimeEntry, projects []models.Project, tasks []models.Task) error {
+	return fs.update(func(data *storedData) {
+		*data = storedData{entries: entries, projects: projects, tasks: tasks}
+	})
+}
+
+func (fs *FileStorage) LoadTasks() ([]models.Task, error) {
+	jsonData, err := os.ReadFile(fs.FilePath)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to read data file: %w", err)
+	}
+
+	tasks, err := parseTasks(jsonData)
+	if err != nil {
+		return nil, err
 	}
 
-	return fs.saveEntriesAndProjects(entries, projects)
+	fs.remember(jsonData)
+	return tasks, nil
+}
+
+func parseTasks(jsonData []byte) ([]models.Task, error) {
+	// Files before v8 have no tasks key and load with no tasks
+	var data struct {
+		Tasks []models.V8Task `json:"tasks"`
+	}
+	if err := json.Unmarshal(jsonData, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse data: %w", err)
+	}
+	return sortTasks(fromV8Tasks(data.Tasks)), nil
+}
+
+func (fs *FileStorage) SaveTasks(tasks []models.Task) error {
+	return fs.update(func(data *storedData) {
+		data.tasks = tasks
+	})
 }
@@ -0,0 +1,513 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"time-tracker/models"
+)
+
+// MaxHistory is the number of mutations kept for undo
+const MaxHistory = 50
+
+var (
+	ErrNothingToUndo    = errors.New("nothing to undo")
+	ErrNothingToRedo    = errors.New("nothing to redo")
+	ErrHistoryDisabled  = errors.New("undo history is not enabled")
+	errHistoryOutOfSync = errors.New("the data has changed since")
+)
+
+// EntryChange is one entry before and after a mutation; nil for added or removed entries
+type EntryChange struct {
+	Before *models.V9Entry `json:"before,omitempty"`
+	After  *models.V9Entry `json:"after,omitempty"`
+}
+
+// HistoryRecord describes a single TaskManager mutation and how to invert it
+type HistoryRecord struct {
+	Description     string              `json:"description"`
+	At              time.Time           `json:"at"`
+	Entries         []EntryChange       `json:"entries,omitempty"`
+	ProjectsChanged bool                `json:"projects-changed,omitempty"`
+	ProjectsBefore  []models.V14Project `json:"projects-before,omitempty"`
+	ProjectsAfter   []models.V14Project `json:"projects-after,omitempty"`
+	TasksChanged    bool                `json:"tasks-changed,omitempty"`
+	TasksBefore     []models.V8Task     `json:"tasks-before,omitempty"`
+	TasksAfter      []models.V8Task     `json:"tasks-after,omitempty"`
+}
+
+// History holds the undo and redo stacks, most recent record last
+type History struct {
+	Version int             `json:"version"`
+	Undo    []HistoryRecord `json:"undo"`
+	Redo    []HistoryRecord `json:"redo"`
+}
+
+// HistoryStore persists the undo history
+type HistoryStore interface {
+	LoadHistory() (History, error)
+	SaveHistory(History) error
+}
+
+// FileHistoryStore implements HistoryStore using a JSON file, so undo works across CLI invocations
+type FileHistoryStore struct {
+	FilePath string
+}
+
+func NewFileHistoryStore(filePath string) *FileHistoryStore {
+	return &FileHistoryStore{FilePath: filePath}
+}
+
+func (hs *FileHistoryStore) LoadHistory() (History, error) {
+	jsonData, err := os.ReadFile(hs.FilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return History{}, nil
+	}
+	if err != nil {
+		return History{}, fmt.Errorf("failed to read history file: %w", err)
+	}
+
+	var history History
+	if err := json.Unmarshal(jsonData, &history); err != nil {
+		return History{}, fmt.Errorf("failed to parse history file: %w", err)
+	}
+
+	// Records store entries in the data format of their time; older history is dropped
+	if history.Version != models.CurrentVersion {
+		return History{}, nil
+	}
+	return history, nil
+}
+
+func (hs *FileHistoryStore) SaveHistory(history History) error {
+	if err := os.MkdirAll(filepath.Dir(hs.FilePath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	jsonData, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal history: %w", err)
+	}
+	return writeFileAtomic(hs.FilePath, jsonData)
+}
+
+// MemoryHistoryStore implements HistoryStore in memory for testing
+type MemoryHistoryStore struct {
+	history History
+}
+
+func NewMemoryHistoryStore() *MemoryHistoryStore {
+	return &MemoryHistoryStore{}
+}
+
+func (hs *MemoryHistoryStore) LoadHistory() (History, error) {
+	return hs.history, nil
+}
+
+func (hs *MemoryHistoryStore) SaveHistory(history History) error {
+	hs.history = history
+	return nil
+}
+
+// historyRecorder stands in for the storage during a recorded mutation
+type historyRecorder struct {
+	Storage
+
+	entriesBefore  []models.V9Entry
+	entriesAfter   []models.V9Entry
+	entriesLoaded  bool
+	entriesSaved   bool
+	projectsBefore []models.V14Project
+	projectsSaved  bool
+	tasksBefore    []models.V8Task
+	tasksSaved     bool
+}
+
+func (r *historyRecorder) Load() ([]models.TimeEntry, error) {
+	entries, err := r.Storage.Load()
+	if err == nil && !r.entriesLoaded {
+		r.entriesBefore = toSortedV9Entries(entries)
+		r.entriesLoaded = true
+	}
+	return entries, err
+}
+
+func (r *historyRecorder) Save(entries []models.TimeEntry) error {
+	if !r.entriesLoaded {
+		if _, err := r.Load(); err != nil {
+			return err
+		}
+	}
+	if err := r.Storage.Save(entries); err != nil {
+		return err
+	}
+	r.entriesAfter = toSortedV9Entries(entries)
+	r.entriesSaved = true
+	return nil
+}
+
+// SaveProjects keeps the stored projects, without the ones added for entries, before the first save
+func (r *historyRecorder) SaveProjects(projects []models.Project) error {
+	if !r.projectsSaved {
+		before, err := r.Storage.LoadStoredProjects()
+		if err != nil {
+			return err
+		}
+		r.projectsBefore = toV14Projects(before)
+	}
+	if err := r.Storage.SaveProjects(projects); err != nil {
+		return err
+	}
+	r.projectsSaved = true
+	return nil
+}
+
+func (r *historyRecorder) SaveTasks(tasks []models.Task) error {
+	if !r.tasksSaved {
+		before, err := r.Storage.LoadTasks()
+		if err != nil {
+			return err
+		}
+		r.tasksBefore = toV8Tasks(before)
+	}
+	if err := r.Storage.SaveTasks(tasks); err != nil {
+		return err
+	}
+	r.tasksSaved = true
+	return nil
+}
+
+func (r *historyRecorder) SaveAll(entries []models.TimeEntry, projects []models.Project, tasks []models.Task) error {
+	if !r.entriesLoaded {
+		if _, err := r.Load(); err != nil {
+			return err
+		}
+	}
+	if !r.projectsSaved {
+		before, err := r.Storage.LoadStoredProjects()
+		if err != nil {
+			return err
+		}
+		r.projectsBefore = toV14Projects(before)
+	}
+	if !r.tasksSaved {
+		before, err := r.Storage.LoadTasks()
+		if err != nil {
+			return err
+		}
+		r.tasksBefore = toV8Tasks(before)
+	}
+	if err := r.Storage.SaveAll(entries, projects, tasks); err != nil {
+		return err
+	}
+	r.entriesAfter = toSortedV9Entries(entries)
+	r.entriesSaved, r.projectsSaved, r.tasksSaved = true, true, true
+	return nil
+}
+
+// changes builds the record of everything the mutation saved
+func (r *historyRecorder) changes() (HistoryRecord, error) {
+	var record HistoryRecord
+	if r.entriesSaved {
+		record.Entries = diffEntries(r.entriesBefore, r.entriesAfter)
+	}
+
+	if r.projectsSaved {
+		after, err := r.Storage.LoadStoredProjects()
+		if err != nil {
+			return HistoryRecord{}, err
+		}
+		projectsAfter := toV14Projects(after)
+		if !slices.EqualFunc(r.projectsBefore, projectsAfter, sameV14Project) {
+			record.ProjectsChanged = true
+			record.ProjectsBefore = r.projectsBefore
+			record.ProjectsAfter = projectsAfter
+		}
+	}
+
+	if r.tasksSaved {
+		after, err := r.Storage.LoadTasks()
+		if err != nil {
+			return HistoryRecord{}, err
+		}
+		tasksAfter := toV8Tasks(after)
+		if !slices.EqualFunc(r.tasksBefore, tasksAfter, sameV8Task) {
+			record.TasksChanged = true
+			record.TasksBefore = r.tasksBefore
+			record.TasksAfter = tasksAfter
+		}
+	}
+
+	return record, nil
+}
+
+// record routes the storage of a mutation through a historyRecorder and pushes its record
+func (tm *TaskManager) record(description string) func(*error) {
+	endOperation := tm.beginOperation(description)
+	if tm.history == nil {
+		return func(*error) { endOperation() }
+	}
+	if _, nested := tm.storage.(*historyRecorder); nested {
+		return func(*error) { endOperation() }
+	}
+
+	recorder := &historyRecorder{Storage: tm.storage}
+	tm.storage = recorder
+	return func(errp *error) {
+		defer endOperation()
+		tm.storage = recorder.Storage
+		if *errp != nil {
+			return
+		}
+
+		record, err := recorder.changes()
+		if err != nil {
+			*errp = fmt.Errorf("change saved, but failed to record undo history: %w", err)
+			return
+		}
+		if len(record.Entries) == 0 && !record.ProjectsChanged && !record.TasksChanged {
+			return
+		}
+		record.Description = description
+		record.At = time.Now()
+
+		if err := tm.pushUndo(record); err != nil {
+			*errp = fmt.Errorf("change saved, but failed to record undo history: %w", err)
+		}
+	}
+}
+
+// beginOperation names the writes of a change for storages that keep the name, like JournalStorage
+func (tm *TaskManager) beginOperation(name string) func() {
+	if operations, ok := tm.backingStorage().(interface{ BeginOperation(string) func() }); ok {
+		return operations.BeginOperation(name)
+	}
+	return func() {}
+}
+
+// backingStorage is the storage of the TaskManager, unwrapped while a mutation is recorded
+func (tm *TaskManager) backingStorage() Storage {
+	if recorder, ok := tm.storage.(*historyRecorder); ok {
+		return recorder.Storage
+	}
+	return tm.storage
+}
+
+func (tm *TaskManager) pushUndo(record HistoryRecord) error {
+	history, err := tm.history.LoadHistory()
+	if err != nil {
+		return err
+	}
+
+	history.Version = models.CurrentVersion
+	history.Undo = append(history.Undo, record)
+	if len(history.Undo) > MaxHistory {
+		history.Undo = history.Undo[len(history.Undo)-MaxHistory:]
+	}
+	// A new mutation invalidates everything that could be redone
+	history.Redo = nil
+
+	return tm.history.SaveHistory(history)
+}
+
+// diffEntries lists the entries added, changed, and removed, matched by ID
+func diffEntries(before, after []models.V9Entry) []EntryChange {
+	var changes []EntryChange
+
+	beforeByID := entriesByID(before)
+	afterByID := entriesByID(after)
+
+	for _, key := range sortedKeys(beforeByID) {
+		previous := beforeByID[key]
+		current, ok := afterByID[key]
+		switch {
+		case !ok:
+			changes = append(changes, EntryChange{Before: &previous})
+		case !sameV9Entry(previous, current):
+			changes = append(changes, EntryChange{Before: &previous, After: &current})
+		}
+	}
+	for _, key := range sortedKeys(afterByID) {
+		if _, ok := beforeByID[key]; !ok {
+			current := afterByID[key]
+			changes = append(changes, EntryChange{After: &current})
+		}
+	}
+
+	return changes
+}
+
+func entriesByID(entries []models.V9Entry) map[string]models.V9Entry {
+	byID := make(map[string]models.V9Entry, len(entries))
+	for _, entry := range entries {
+		byID[entry.ID] = entry
+	}
+	return byID
+}
+
+// Undo reverts the most recent recorded mutation and returns its record
+func (tm *TaskManager) Undo() (*HistoryRecord, error) {
+	return tm.step(true)
+}
+
+// Redo re-applies the most recently undone mutation and returns its record
+func (tm *TaskManager) Redo() (*HistoryRecord, error) {
+	return tm.step(false)
+}
+
+func (tm *TaskManager) step(undo bool) (*HistoryRecord, error) {
+	if tm.history == nil {
+		return nil, ErrHistoryDisabled
+	}
+
+	history, err := tm.history.LoadHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := &history.Undo, &history.Redo
+	if !undo {
+		from, to = &history.Redo, &history.Undo
+	}
+	if len(*from) == 0 {
+		if undo {
+			return nil, ErrNothingToUndo
+		}
+		return nil, ErrNothingToRedo
+	}
+
+	record := (*from)[len(*from)-1]
+	verb := "redo"
+	if undo {
+		verb = "undo"
+	}
+	endOperation := tm.beginOperation(verb + " " + record.Description)
+	err = tm.applyRecord(record, undo)
+	endOperation()
+	if err != nil {
+		return nil, fmt.Errorf("cannot %s %q: %w", verb, record.Description, err)
+	}
+
+	*from = (*from)[:len(*from)-1]
+	*to = append(*to, record)
+	history.Version = models.CurrentVersion
+	if err := tm.history.SaveHistory(history); err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// applyRecord moves the data from one side of a record to the other
+func (tm *TaskManager) applyRecord(record HistoryRecord, undo bool) error {
+	var byID map[string]models.V9Entry
+	if len(record.Entries) > 0 {
+		entries, err := tm.storage.Load()
+		if err != nil {
+			return err
+		}
+		byID = entriesByID(toSortedV9Entries(entries))
+	}
+
+	for _, change := range record.Entries {
+		expected, replacement := change.After, change.Before
+		if !undo {
+			expected, replacement = change.Before, change.After
+		}
+
+		var key string
+		if expected != nil {
+			key = expected.ID
+		} else {
+			key = replacement.ID
+		}
+
+		existing, ok := byID[key]
+		if expected == nil && ok || expected != nil && (!ok || !sameV9Entry(existing, *expected)) {
+			return errHistoryOutOfSync
+		}
+
+		if replacement == nil {
+			delete(byID, key)
+		} else {
+			byID[key] = *replacement
+		}
+	}
+
+	expectedProjects, replacementProjects := record.ProjectsAfter, record.ProjectsBefore
+	if !undo {
+		expectedProjects, replacementProjects = record.ProjectsBefore, record.ProjectsAfter
+	}
+	if record.ProjectsChanged {
+		current, err := tm.storage.LoadStoredProjects()
+		if err != nil {
+			return err
+		}
+		if !slices.EqualFunc(toV14Projects(current), expectedProjects, sameV14Project) {
+			return errHistoryOutOfSync
+		}
+	}
+
+	expectedTasks, replacementTasks := record.TasksAfter, record.TasksBefore
+	if !undo {
+		expectedTasks, replacementTasks = record.TasksBefore, record.TasksAfter
+	}
+	if record.TasksChanged {
+		current, err := tm.storage.LoadTasks()
+		if err != nil {
+			return err
+		}
+		if !slices.EqualFunc(toV8Tasks(current), expectedTasks, sameV8Task) {
+			return errHistoryOutOfSync
+		}
+	}
+
+	if len(record.Entries) > 0 {
+		entries := make([]models.TimeEntry, 0, len(byID))
+		for _, key := range sortedKeys(byID) {
+			entries = append(entries, fromV9Entry(byID[key]))
+		}
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Start.Before(entries[j].Start)
+		})
+		if err := tm.storage.Save(entries); err != nil {
+			return err
+		}
+	}
+
+	if record.ProjectsChanged {
+		if err := tm.storage.SaveProjects(fromV14Projects(replacementProjects)); err != nil {
+			return err
+		}
+	}
+
+	if record.TasksChanged {
+		if err := tm.storage.SaveTasks(fromV8Tasks(replacementTasks)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// describeEntry names an entry in history descriptions
+func describeEntry(project, title string) string {
+	project, title = strings.TrimSpace(project), strings.TrimSpace(title)
+	switch {
+	case project == "" && title == "":
+		return "blank entry"
+	case title == "":
+		return project
+	case project == "":
+		return title
+	default:
+		return project + ": " + title
+	}
+}
@@ -0,0 +1,225 @@
+package utils
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+)
+
+func newHistoryTaskManager() (*MemoryStorage, *TaskManager) {
+	storage := NewMemoryStorage()
+	tm := NewTaskManager(storage)
+	tm.SetHistory(NewMemoryHistoryStore())
+	return storage, tm
+}
+
+func TestTaskManager_UndoRedoStartAndStop(t *testing.T) {
+	storage, tm := newHistoryTaskManager()
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	if _, err := tm.StartEntryAt("Alpha", "Design", start); err != nil {
+		t.Fatalf("StartEntryAt failed: %v", err)
+	}
+	if _, err := tm.StopEntry(); err != nil {
+		t.Fatalf("StopEntry failed: %v", err)
+	}
+
+	record, err := tm.Undo()
+	if err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if record.Description != "stop tracking" {
+		t.Fatalf("unexpected description %q", record.Description)
+	}
+	entries, _ := storage.Load()
+	if len(entries) != 1 || !entries[0].IsRunning() || entries[0].Project != "Alpha" {
+		t.Fatalf("expected the running entry back, got %+v", entries)
+	}
+
+	if _, err := tm.Undo(); err != nil {
+		t.Fatalf("second Undo failed: %v", err)
+	}
+	entries, _ = storage.Load()
+	if len(entries) != 0 {
+		t.Fatalf("expected no entries after undoing start, got %+v", entries)
+	}
+	if _, err := tm.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Fatalf("expected ErrNothingToUndo, got %v", err)
+	}
+
+	record, err = tm.Redo()
+	if err != nil {
+		t.Fatalf("Redo failed: %v", err)
+	}
+	if record.Description != "start Alpha: Design" {
+		t.Fatalf("unexpected description %q", record.Description)
+	}
+	entries, _ = storage.Load()
+	if len(entries) != 1 || !entries[0].Start.Equal(start) {
+		t.Fatalf("expected the started entry back, got %+v", entries)
+	}
+}
+
+func TestTaskManager_UndoDeleteEntryAndRemoveProject(t *testing.T) {
+	storage, tm := newHistoryTaskManager()
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	if _, err := tm.StartEntryAt("Alpha", "Design", start, "ux"); err != nil {
+		t.Fatalf("StartEntryAt failed: %v", err)
+	}
+	if err := tm.DeleteEntry(0); err != nil {
+		t.Fatalf("DeleteEntry failed: %v", err)
+	}
+	if _, err := tm.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	entries, _ := storage.Load()
+	if entries[0].Title != "Design" || len(entries[0].Tags) != 1 {
+		t.Fatalf("expected deleted entry to be restored, got %+v", entries[0])
+	}
+
+	if _, err := tm.AddProject("Beta", "B-1", "Client"); err != nil {
+		t.Fatalf("AddProject failed: %v", err)
+	}
+	if err := tm.RemoveProject("Beta"); err != nil {
+		t.Fatalf("RemoveProject failed: %v", err)
+	}
+	if _, err := tm.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	projects, _ := storage.LoadProjects()
+	found := false
+	for _, project := range projects {
+		if project.Name == "Beta" && project.Code == "B-1" && project.Category == "Client" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected removed project to be restored, got %+v", projects)
+	}
+}
+
+func TestTaskManager_UndoKeepsEntriesWithTheSameStart(t *testing.T) {
+	storage, tm := newHistoryTaskManager()
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	seed := []models.TimeEntry{
+		{Start: start, Project: "Alpha", Title: "Standup"},
+		{Start: start, Project: "Alpha", Title: "Design"},
+		{Start: start.Add(time.Hour), Project: "Beta", Title: "Review"},
+	}
+	if err := storage.Save(seed); err != nil {
+		t.Fatalf("failed to seed entries: %v", err)
+	}
+
+	if err := tm.DeleteEntry(0); err != nil {
+		t.Fatalf("DeleteEntry failed: %v", err)
+	}
+	if _, err := tm.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+
+	entries, _ := storage.Load()
+	titles := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		titles = append(titles, entry.Title)
+	}
+	slices.Sort(titles)
+	if !slices.Equal(titles, []string{"Design", "Review", "Standup"}) {
+		t.Fatalf("expected every entry back, got %+v", entries)
+	}
+}
+
+func TestTaskManager_UndoDoesNotStoreEntryProjects(t *testing.T) {
+	storage, tm := newHistoryTaskManager()
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	if err := storage.Save([]models.TimeEntry{{Start: start, Project: "Beta", Title: "Review"}}); err != nil {
+		t.Fatalf("failed to seed entries: %v", err)
+	}
+
+	if _, err := tm.AddProject("Alpha", "", ""); err != nil {
+		t.Fatalf("AddProject failed: %v", err)
+	}
+	if _, err := tm.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if stored, _ := storage.LoadStoredProjects(); len(stored) != 0 {
+		t.Fatalf("expected no stored projects after undo, got %+v", stored)
+	}
+}
+
+func TestTaskManager_NewMutationClearsRedo(t *testing.T) {
+	_, tm := newHistoryTaskManager()
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	if _, err := tm.StartEntryAt("Alpha", "Design", start); err != nil {
+		t.Fatalf("StartEntryAt failed: %v", err)
+	}
+	if _, err := tm.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if _, err := tm.StartEntryAt("Beta", "Review", start); err != nil {
+		t.Fatalf("StartEntryAt failed: %v", err)
+	}
+	if _, err := tm.Redo(); !errors.Is(err, ErrNothingToRedo) {
+		t.Fatalf("expected ErrNothingToRedo, got %v", err)
+	}
+}
+
+func TestTaskManager_UndoRefusesWhenDataChangedSince(t *testing.T) {
+	storage, tm := newHistoryTaskManager()
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	if _, err := tm.StartEntryAt("Alpha", "Design", start); err != nil {
+		t.Fatalf("StartEntryAt failed: %v", err)
+	}
+
+	// Another process edits the entry without going through the history
+	other := NewTaskManager(storage)
+	if err := other.UpdateEntry(0, "Alpha", "Renamed", start, nil, ""); err != nil {
+		t.Fatalf("UpdateEntry failed: %v", err)
+	}
+
+	_, err := tm.Undo()
+	if err == nil || !strings.Contains(err.Error(), "has changed since") {
+		t.Fatalf("expected out-of-sync error, got %v", err)
+	}
+	entries, _ := storage.Load()
+	if len(entries) != 1 || entries[0].Title != "Renamed" {
+		t.Fatalf("expected data to be left untouched, got %+v", entries)
+	}
+}
+
+func TestTaskManager_UndoWithoutHistory(t *testing.T) {
+	tm := NewTaskManager(NewMemoryStorage())
+	if _, err := tm.Undo(); !errors.Is(err, ErrHistoryDisabled) {
+		t.Fatalf("expected ErrHistoryDisabled, got %v", err)
+	}
+}
+
+func TestFileHistoryStore_PersistsAcrossInstances(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	storage := NewMemoryStorage()
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	tm := NewTaskManager(storage)
+	tm.SetHistory(NewFileHistoryStore(path))
+	if _, err := tm.StartEntryAt("Alpha", "Design", start); err != nil {
+		t.Fatalf("StartEntryAt failed: %v", err)
+	}
+
+	// A later CLI invocation undoes the change
+	later := NewTaskManager(storage)
+	later.SetHistory(NewFileHistoryStore(path))
+	if _, err := later.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	entries, _ := storage.Load()
+	if len(entries) != 0 {
+		t.Fatalf("expected the entry to be undone, got %+v", entries)
+	}
+}
@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"time-tracker/models"
+)
+
+func seedInsertEntries(t *testing.T, storage *MemoryStorage) time.Time {
+	t.Helper()
+
+	// Acme 09:00-12:00, gap 12:00-13:00, Beta 13:00-15:00
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	noon := start.Add(3 * time.Hour)
+	beta := start.Add(4 * time.Hour)
+	end := start.Add(6 * time.Hour)
+	err := storage.Save([]models.TimeEntry{
+		{Start: start, End: &noon, Project: "Acme", Title: "Build", Tags: []string{"dev"}, Notes: "compiled"},
+		{Start: noon, End: &beta},
+		{Start: beta, End: &end, Project: "Beta", Title: "Review"},
+	})
+	if err != nil {
+		t.Fatalf("failed to seed entries: %v", err)
+	}
+	return start
+}
+
+func assertTimeline(t *testing.T, entries []models.TimeEntry, want []string, starts []time.Time) {
+	t.Helper()
+
+	if len(entries) != len(want) {
+		t.Fatalf("expected %d entries, got %+v", len(want), entries)
+	}
+	for i, entry := range entries {
+		if got := describeEntry(entry.Project, entry.Title); got != want[i] || !entry.Start.Equal(starts[i]) {
+			t.Fatalf("entry %d: expected %s at %s, got %s at %s", i, want[i], starts[i].Format("15:04"), got, entry.Start.Format("15:04"))
+		}
+		if i < len(entries)-1 && (entry.End == nil || !entry.End.Equal(entries[i+1].Start)) {
+			t.Fatalf("entry %d: expected to end at the next start, got %+v", i, entry)
+		}
+	}
+}
+
+func TestInsertEntry_SplitsEntryItOverlaps(t *testing.T) {
+	storage, tm := newHistoryTaskManager()
+	start := seedInsertEntries(t, storage)
+
+	inserted, err := tm.InsertEntry("Gamma", "Call", start.Add(time.Hour), start.Add(90*time.Minute), "meeting", "meeting")
+	if err != nil {
+		t.Fatalf("InsertEntry failed: %v", err)
+	}
+	if inserted.End == nil || inserted.Duration() != 30*time.Minute || FormatTags(inserted.Tags) != "meeting" {
+		t.Fatalf("unexpected inserted entry %+v", inserted)
+	}
+
+	entries, _ := storage.Load()
+	assertTimeline(t, entries,
+		[]string{"Acme: Build", "Gamma: Call", "Acme: Build", "blank entry", "Beta: Review"},
+		[]time.Time{start, start.Add(time.Hour), start.Add(90 * time.Minute), start.Add(3 * time.Hour), start.Add(4 * time.Hour)})
+	if entries[0].Notes != "compiled" || entries[2].Notes != "" || FormatTags(entries[2].Tags) != "dev" {
+		t.Fatalf("expected the resumed half to keep the tags but not the notes, got %+v", entries[2])
+	}
+	if entries[4].End == nil || !entries[4].End.Equal(start.Add(6*time.Hour)) {
+		t.Fatalf("expected the last entry to keep its end, got %+v", entries[4])
+	}
+
+	record, err := tm.Undo()
+	if err != nil || record.Description != "add Gamma: Call" {
+		t.Fatalf("expected to undo the insert, got %+v (%v)", record, err)
+	}
+	entries, _ = storage.Load()
+	if len(entries) != 3 {
+		t.Fatalf("expected the insert to be undone, got %+v", entries)
+	}
+}
+
+func TestInsertEntry_TrimsAcrossEntriesAndGaps(t *testing.T) {
+	storage, tm := newHistoryTaskManager()
+	start := seedInsertEntries(t, storage)
+
+	// 11:00-13:30 covers the end of Acme, the whole gap, and the start of Beta
+	if _, err := tm.InsertEntry("Gamma", "Call", start.Add(2*time.Hour), start.Add(270*time.Minute)); err != nil {
+		t.Fatalf("InsertEntry failed: %v", err)
+	}
+
+	entries, _ := storage.Load()
+	assertTimeline(t, entries,
+		[]string{"Acme: Build", "Gamma: Call", "Beta: Review"},
+		[]time.Time{start, start.Add(2 * time.Hour), start.Add(270 * time.Minute)})
+}
+
+func TestInsertEntry_EndingInGapRestoresGap(t *testing.T) {
+	storage, tm := newHistoryTaskManager()
+	start := seedInsertEntries(t, storage)
+
+	if _, err := tm.InsertEntry("Gamma", "Call", start.Add(150*time.Minute), start.Add(210*time.Minute)); err != nil {
+		t.Fatalf("InsertEntry failed: %v", err)
+	}
+
+	entries, _ := storage.Load()
+	assertTimeline(t, entries,
+		[]string{"Acme: Build", "Gamma: Call", "blank entry", "Beta: Review"},
+		[]time.Time{start, start.Add(150 * time.Minute), start.Add(210 * time.Minute), start.Add(4 * time.Hour)})
+}
+
+func TestInsertEntry_BeforeAllEntriesLeavesGap(t *testing.T) {
+	storage, tm := newHistoryTaskManager()
+	start := seedInsertEntries(t, storage)
+
+	if _, err := tm.InsertEntry("Gamma", "Call", start.Add(-2*time.Hour), start.Add(-time.Hour)); err != nil {
+		t.Fatalf("InsertEntry failed: %v", err)
+	}
+
+	entries, _ := storage.Load()
+	assertTimeline(t, entries,
+		[]string{"Gamma: Call", "blank entry", "Acme: Build", "blank entry", "Beta: Review"},
+		[]time.Time{start.Add(-2 * time.Hour), start.Add(-time.Hour), start, start.Add(3 * time.Hour), start.Add(4 * time.Hour)})
+}
+
+func TestInsertEntry_InsideRunningEntryKeepsItRunning(t *testing.T) {
+	storage, tm := newHistoryTaskManager()
+	start := time.Now().Add(-3 * time.Hour).Truncate(time.Minute)
+	if err := storage.Save([]models.TimeEntry{{Start: start, Project: "Acme", Title: "Build"}}); err != nil {
+		t.Fatalf("failed to seed entries: %v", err)
+	}
+
+	if _, err := tm.InsertEntry("Gamma", "Call", start.Add(time.Hour), start.Add(2*time.Hour)); err != nil {
+		t.Fatalf("InsertEntry failed: %v", err)
+	}
+
+	entries, _ := storage.Load()
+	assertTimeline(t, entries,
+		[]string{"Acme: Build", "Gamma: Call", "Acme: Build"},
+		[]time.Time{start, start.Add(time.Hour), start.Add(2 * time.Hour)})
+	if !entries[2].IsRunning() {
+		t.Fatalf("expected the resumed entry to keep running, got %+v", entries[2])
+	}
+}
+
+func TestInsertEntry_RejectsInvalidIntervals(t *testing.T) {
+	storage, tm := newHistoryTaskManager()
+	start := seedInsertEntries(t, storage)
+
+	tests := []struct {
+		name     string
+		project  string
+		from, to time.Time
+	}{
+		{"missing project", "", start, start.Add(time.Hour)},
+		{"end before start", "Gamma", start.Add(time.Hour), start},
+		{"empty interval", "Gamma", start, start},
+		{"future end", "Gamma", time.Now().Add(-time.Hour), time.Now().Add(time.Hour)},
+	}
+	for _, tt := range tests {
+		if _, err := tm.InsertEntry(tt.project, "Call", tt.from, tt.to); err == nil {
+			t.Errorf("%s: expected InsertEntry to fail", tt.name)
+		}
+	}
+
+	entries, _ := storage.Load()
+	if len(entries) != 3 {
+		t.Fatalf("expected entries to be unchanged, got %+v", entries)
+	}
+}
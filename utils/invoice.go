@@ -0,0 +1,202 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+)
+
+// InvoiceLine is a single billable (project, date) line item
+type InvoiceLine struct {
+	Date        time.Time
+	Project     string
+	ProjectCode string
+	Description string
+	Duration    time.Duration
+	HourlyRate  int64 // In cents
+	Currency    string
+	Amount      int64 // In cents
+}
+
+// InvoiceTotal is the invoice total for a single currency
+type InvoiceTotal struct {
+	Currency string
+	Duration time.Duration
+	Amount   int64 // In cents, the sum of the line amounts
+}
+
+// Invoice is a line-itemized invoice for billable projects over a date range
+type Invoice struct {
+	Client string
+	From   time.Time
+	To     time.Time
+	Lines  []InvoiceLine
+	Totals []InvoiceTotal // One total per currency, sorted by currency
+}
+
+// BuildInvoice creates an invoice of the billable entries dated from through to
+func BuildInvoice(entries []ProjectDateEntry, client string, from, to time.Time) Invoice {
+	fromDate := truncateToDate(from)
+	toDate := truncateToDate(to)
+
+	invoice := Invoice{Client: client, From: fromDate, To: toDate}
+	totals := make(map[string]*InvoiceTotal)
+
+	for _, entry := range entries {
+		if !entry.ProjectBillable {
+			continue
+		}
+		if entry.Date.Before(fromDate) || entry.Date.After(toDate) {
+			continue
+		}
+
+		amount := BillableAmount(entry.Duration, entry.ProjectRateCents)
+		invoice.Lines = append(invoice.Lines, InvoiceLine{
+			Date:        entry.Date,
+			Project:     entry.Project,
+			ProjectCode: entry.ProjectCode,
+			Description: strings.Join(entry.Tasks, ", "),
+			Duration:    entry.Duration,
+			HourlyRate:  entry.ProjectRateCents,
+			Currency:    entry.ProjectCurrency,
+			Amount:      amount,
+		})
+
+		if totals[entry.ProjectCurrency] == nil {
+			totals[entry.ProjectCurrency] = &InvoiceTotal{Currency: entry.ProjectCurrency}
+		}
+		totals[entry.ProjectCurrency].Duration += entry.Duration
+		totals[entry.ProjectCurrency].Amount += amount
+	}
+
+	for _, total := range totals {
+		invoice.Totals = append(invoice.Totals, *total)
+	}
+	sort.Slice(invoice.Totals, func(i, j int) bool {
+		return invoice.Totals[i].Currency < invoice.Totals[j].Currency
+	})
+
+	return invoice
+}
+
+// RenderInvoiceMarkdown renders the invoice as a Markdown document
+func RenderInvoiceMarkdown(invoice Invoice) string {
+	var out strings.Builder
+
+	out.WriteString("# Invoice\n\n")
+	if invoice.Client != "" {
+		fmt.Fprintf(&out, "**Client:** %s  \n", escapeMarkdownCell(invoice.Client))
+	}
+	fmt.Fprintf(&out, "**Period:** %s to %s\n\n", invoice.From.Format("2006-01-02"), invoice.To.Format("2006-01-02"))
+
+	if len(invoice.Lines) == 0 {
+		out.WriteString("No billable time in this period.\n")
+		return out.String()
+	}
+
+	out.WriteString("| Date | Project | Code | Description | Hours | Rate | Amount |\n")
+	out.WriteString("| --- | --- | --- | --- | ---: | ---: | ---: |\n")
+	for _, line := range invoice.Lines {
+		fmt.Fprintf(&out, "| %s | %s | %s | %s | %s | %s | %s |\n",
+			line.Date.Format("2006-01-02"),
+			escapeMarkdownCell(line.Project),
+			escapeMarkdownCell(line.ProjectCode),
+			escapeMarkdownCell(line.Description),
+			FormatHours(line.Duration),
+			formatMoney(line.HourlyRate, line.Currency),
+			formatMoney(line.Amount, line.Currency),
+		)
+	}
+
+	out.WriteString("\n## Totals\n\n")
+	out.WriteString("| Currency | Hours | Amount |\n")
+	out.WriteString("| --- | ---: | ---: |\n")
+	for _, total := range invoice.Totals {
+		fmt.Fprintf(&out, "| %s | %s | %s |\n",
+			currencyLabel(total.Currency),
+			FormatHours(total.Duration),
+			formatMoney(total.Amount, total.Currency),
+		)
+	}
+
+	return out.String()
+}
+
+var invoiceHTMLTemplate = template.Must(template.New("invoice").Funcs(template.FuncMap{
+	"date":  func(t time.Time) string { return t.Format("2006-01-02") },
+	"hours": FormatHours,
+	"money": formatMoney,
+	"label": currencyLabel,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Invoice {{date .From}} to {{date .To}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.4em 0.6em; text-align: left; }
+td.num, th.num { text-align: right; }
+</style>
+</head>
+<body>
+<h1>Invoice</h1>
+{{if .Client}}<p><strong>Client:</strong> {{.Client}}</p>
+{{end}}<p><strong>Period:</strong> {{date .From}} to {{date .To}}</p>
+{{if .Lines}}<table>
+<thead><tr><th>Date</th><th>Project</th><th>Code</th><th>Description</th><th class="num">Hours</th><th class="num">Rate</th><th class="num">Amount</th></tr></thead>
+<tbody>
+{{range .Lines}}<tr><td>{{date .Date}}</td><td>{{.Project}}</td><td>{{.ProjectCode}}</td><td>{{.Description}}</td><td class="num">{{hours .Duration}}</td><td class="num">{{money .HourlyRate .Currency}}</td><td class="num">{{money .Amount .Currency}}</td></tr>
+{{end}}</tbody>
+</table>
+<h2>Totals</h2>
+<table>
+<thead><tr><th>Currency</th><th class="num">Hours</th><th class="num">Amount</th></tr></thead>
+<tbody>
+{{range .Totals}}<tr><td>{{label .Currency}}</td><td class="num">{{hours .Duration}}</td><td class="num">{{money .Amount .Currency}}</td></tr>
+{{end}}</tbody>
+</table>
+{{else}}<p>No billable time in this period.</p>
+{{end}}</body>
+</html>
+`))
+
+// RenderInvoiceHTML renders the invoice as a standalone HTML document
+func RenderInvoiceHTML(invoice Invoice) (string, error) {
+	var buf bytes.Buffer
+	if err := invoiceHTMLTemplate.Execute(&buf, invoice); err != nil {
+		return "", fmt.Errorf("failed to render invoice: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// FormatHours formats a duration as decimal hours with two decimals (e.g., "1.50")
+func FormatHours(d time.Duration) string {
+	return fmt.Sprintf("%.2f", d.Hours())
+}
+
+func formatMoney(cents int64, currency string) string {
+	if currency == "" {
+		return FormatMoney(cents)
+	}
+	return FormatMoney(cents) + " " + currency
+}
+
+func currencyLabel(currency string) string {
+	if currency == "" {
+		return "(none)"
+	}
+	return currency
+}
+
+func escapeMarkdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
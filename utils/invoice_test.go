@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildInvoice_IncludesOnlyBillableEntriesInPeriod(t *testing.T) {
+	entries := []ProjectDateEntry{
+		{Date: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), Project: "Alpha", Duration: 90 * time.Minute, Tasks: []string{"Build"}, ProjectRateCents: 10000, ProjectCurrency: "USD", ProjectBillable: true},
+		{Date: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), Project: "Internal", Duration: time.Hour, Tasks: []string{"Meeting"}, ProjectRateCents: 5000, ProjectCurrency: "USD"},
+		{Date: time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC), Project: "Beta", Duration: 20 * time.Minute, Tasks: []string{"Review", "Docs"}, ProjectRateCents: 9000, ProjectCurrency: "EUR", ProjectBillable: true},
+		{Date: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), Project: "Alpha", Duration: time.Hour, ProjectRateCents: 10000, ProjectCurrency: "USD", ProjectBillable: true},
+	}
+
+	invoice := BuildInvoice(entries, "Client", time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC), time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC))
+
+	if len(invoice.Lines) != 2 {
+		t.Fatalf("expected 2 invoice lines, got %d: %+v", len(invoice.Lines), invoice.Lines)
+	}
+	if invoice.Lines[0].Project != "Alpha" || invoice.Lines[0].Amount != 15000 {
+		t.Fatalf("expected Alpha line of 150.00, got %+v", invoice.Lines[0])
+	}
+	if invoice.Lines[1].Description != "Review, Docs" {
+		t.Fatalf("expected joined tasks as description, got %q", invoice.Lines[1].Description)
+	}
+	if invoice.Lines[1].Amount != 3000 {
+		t.Fatalf("expected 20 minutes at 90/h to be 30.00, got %s", FormatMoney(invoice.Lines[1].Amount))
+	}
+
+	if len(invoice.Totals) != 2 {
+		t.Fatalf("expected one total per currency, got %+v", invoice.Totals)
+	}
+	if invoice.Totals[0].Currency != "EUR" || invoice.Totals[1].Currency != "USD" {
+		t.Fatalf("expected totals sorted by currency, got %+v", invoice.Totals)
+	}
+	if invoice.Totals[1].Amount != 15000 || invoice.Totals[1].Duration != 90*time.Minute {
+		t.Fatalf("unexpected USD total: %+v", invoice.Totals[1])
+	}
+}
+
+func TestBuildInvoice_RoundsAmountsToCents(t *testing.T) {
+	entries := []ProjectDateEntry{
+		{Date: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), Project: "Alpha", Duration: 10 * time.Minute, ProjectRateCents: 10000, ProjectBillable: true},
+	}
+
+	invoice := BuildInvoice(entries, "", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	if len(invoice.Lines) != 1 {
+		t.Fatalf("expected 1 line, got %d", len(invoice.Lines))
+	}
+	if invoice.Lines[0].Amount != 1667 {
+		t.Fatalf("expected 16.67, got %s", FormatMoney(invoice.Lines[0].Amount))
+	}
+}
+
+func TestBuildInvoice_TotalIsTheSumOfTheLines(t *testing.T) {
+	var entries []ProjectDateEntry
+	for day := 1; day <= 3; day++ {
+		entries = append(entries, ProjectDateEntry{Date: time.Date(2026, 3, day, 0, 0, 0, 0, time.UTC), Project: "Alpha",
+			Duration: 10 * time.Minute, ProjectRateCents: 10000, ProjectBillable: true})
+	}
+
+	invoice := BuildInvoice(entries, "", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC))
+
+	// Three lines of 16.67 add up to 50.01, not the 50.00 of 30 minutes at 100/h
+	if len(invoice.Lines) != 3 || len(invoice.Totals) != 1 || invoice.Totals[0].Amount != 5001 {
+		t.Fatalf("expected a total of 50.01 over three lines, got %+v", invoice)
+	}
+}
+
+func TestRenderInvoiceMarkdown(t *testing.T) {
+	invoice := Invoice{
+		Client: "Client",
+		From:   time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+		Lines: []InvoiceLine{
+			{Date: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), Project: "Alpha", ProjectCode: "A1", Description: "Build | test", Duration: 90 * time.Minute, HourlyRate: 10000, Currency: "USD", Amount: 15000},
+		},
+		Totals: []InvoiceTotal{{Currency: "USD", Duration: 90 * time.Minute, Amount: 15000}},
+	}
+
+	rendered := RenderInvoiceMarkdown(invoice)
+
+	for _, want := range []string{
+		"**Client:** Client",
+		"**Period:** 2026-03-01 to 2026-03-31",
+		"| 2026-03-02 | Alpha | A1 | Build \\| test | 1.50 | 100.00 USD | 150.00 USD |",
+		"| USD | 1.50 | 150.00 USD |",
+	} {
+		if !strings.Contains(rendered, want) {
+			t.Fatalf("expected markdown to contain %q, got:\n%s", want, rendered)
+		}
+	}
+}
+
+func TestRenderInvoiceMarkdown_NoLines(t *testing.T) {
+	rendered := RenderInvoiceMarkdown(Invoice{})
+
+	if !strings.Contains(rendered, "No billable time in this period.") {
+		t.Fatalf("expected empty invoice message, got:\n%s", rendered)
+	}
+	if strings.Contains(rendered, "## Totals") {
+		t.Fatalf("did not expect totals for empty invoice, got:\n%s", rendered)
+	}
+}
+
+func TestRenderInvoiceHTML_EscapesValues(t *testing.T) {
+	invoice := Invoice{
+		Lines: []InvoiceLine{
+			{Date: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), Project: "<Alpha>", Duration: time.Hour, HourlyRate: 8000, Amount: 8000},
+		},
+		Totals: []InvoiceTotal{{Duration: time.Hour, Amount: 8000}},
+	}
+
+	rendered, err := RenderInvoiceHTML(invoice)
+	if err != nil {
+		t.Fatalf("RenderInvoiceHTML returned error: %v", err)
+	}
+
+	if !strings.Contains(rendered, "&lt;Alpha&gt;") {
+		t.Fatalf("expected escaped project name, got:\n%s", rendered)
+	}
+	if !strings.Contains(rendered, "<td>(none)</td>") {
+		t.Fatalf("expected missing currency label, got:\n%s", rendered)
+	}
+}
@@ -0,0 +1,575 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"time-tracker/models"
+)
+
+// Journal event operations, appended as the difference to the previous state
+const (
+	journalOpPutEntry      = "put-entry"
+	journalOpDeleteEntry   = "delete-entry"
+	journalOpPutProject    = "put-project"
+	journalOpDeleteProject = "delete-project"
+	journalOpPutTask       = "put-task"
+	journalOpDeleteTask    = "delete-task"
+)
+
+// journalEvent is a single line of the journal file
+type journalEvent struct {
+	Op        string          `json:"op"`
+	At        time.Time       `json:"at"`
+	Operation string          `json:"operation,omitempty"` // The change that appended the event, e.g. "start Acme: Build"
+	Entry     *models.V9Entry `json:"entry,omitempty"`
+	Start     *time.Time      `json:"start,omitempty"`
+	Project   *journalProject `json:"project,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Task      *models.V8Task  `json:"task,omitempty"`
+	ID        string          `json:"id,omitempty"`
+}
+
+// journalProject is a journaled project; ones journaled before v14 have a decimal hourlyRate
+type journalProject struct {
+	models.V14Project
+	HourlyRate float64 `json:"hourlyRate,omitempty"`
+}
+
+// journalState is the result of replaying a journal, see journalEntryKey
+type journalState struct {
+	entries     map[string]models.V9Entry
+	projects    map[string]models.V14Project
+	tasks       map[string]models.V8Task
+	events      int
+	validLength int64 // Byte length of the complete lines; a torn final line is ignored
+}
+
+// JournalStorage implements Storage using an append-only JSON Lines event journal
+type JournalStorage struct {
+	FilePath  string
+	now       func() time.Time
+	operation string // Name of the change under way, see BeginOperation
+
+	loadedLength int64 // Length of the baseline journal, see Storage
+	hasBaseline  bool
+}
+
+// NewJournalStorage creates a new journal-based storage, initializing the file if needed
+func NewJournalStorage(filePath string) (*JournalStorage, error) {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	info, err := os.Stat(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		if err := os.WriteFile(filePath, nil, 0644); err != nil {
+			return nil, fmt.Errorf("failed to create journal file: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to stat journal file: %w", err)
+	} else if info.IsDir() {
+		return nil, errors.New("provided path must be a file, not a directory")
+	}
+
+	return &JournalStorage{FilePath: filePath, now: time.Now}, nil
+}
+
+func (js *JournalStorage) Load() ([]models.TimeEntry, error) {
+	state, err := js.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return state.sortedEntries(), nil
+}
+
+func (js *JournalStorage) Save(entries []models.TimeEntry) error {
+	return js.update(func(state *journalState, at time.Time) []journalEvent {
+		return state.entryEvents(entries, at)
+	})
+}
+
+// entryEvents returns the events that turn the stored entries into entries
+func (state *journalState) entryEvents(entries []models.TimeEntry, at time.Time) []journalEvent {
+	saved := toSortedV9Entries(entries)
+	next := make(map[string]models.V9Entry, len(saved))
+	for _, entry := range saved {
+		next[entry.ID] = entry
+	}
+
+	var events []journalEvent
+	for _, key := range sortedKeys(state.entries) {
+		if _, ok := next[key]; ok {
+			continue
+		}
+		// Entries journaled before IDs existed are deleted by start, like they were put
+		event := journalEvent{Op: journalOpDeleteEntry, At: at, ID: key}
+		if previous := state.entries[key]; previous.ID == "" {
+			event.ID, event.Start = "", &previous.Start
+		}
+		events = append(events, event)
+	}
+	for _, entry := range saved {
+		previous, ok := state.entries[entry.ID]
+		if ok && sameV9Entry(previous, entry) {
+			continue
+		}
+		entry := entry
+		events = append(events, journalEvent{Op: journalOpPutEntry, At: at, Entry: &entry})
+	}
+	return events
+}
+
+// ResetBaseline makes the next load the baseline of later saves, see Storage
+func (js *JournalStorage) ResetBaseline() {
+	js.hasBaseline = false
+}
+
+// BeginOperation names the events appended until the returned function is called, unless a
+// change is already under way
+func (js *JournalStorage) BeginOperation(name string) func() {
+	if js.operation != "" {
+		return func() {}
+	}
+	js.operation = name
+	return func() { js.operation = "" }
+}
+
+// load replays the journal, taking its length as the baseline unless there already is one
+func (js *JournalStorage) load() (*journalState, error) {
+	state, err := js.replay()
+	if err != nil {
+		return nil, err
+	}
+	if !js.hasBaseline {
+		js.loadedLength, js.hasBaseline = state.validLength, true
+	}
+	return state, nil
+}
+
+// update appends the events derived from the current state under an exclusive lock
+func (js *JournalStorage) update(diff func(state *journalState, at time.Time) []journalEvent) error {
+	unlock, err := lockFile(js.FilePath + ".lock")
+	if err != nil {
+		return fmt.Errorf("failed to lock journal file: %w", err)
+	}
+	defer unlock()
+
+	state, err := js.replay()
+	if err != nil {
+		return err
+	}
+	if js.hasBaseline && js.loadedLength != state.validLength {
+		js.hasBaseline = false
+		return &ConflictError{FilePath: js.FilePath}
+	}
+
+	events := diff(state, js.now())
+	for i := range events {
+		events[i].Operation = js.operation
+	}
+	length, err := js.appendEvents(state, events)
+	if err != nil {
+		return err
+	}
+	js.loadedLength, js.hasBaseline = length, true
+	return nil
+}
+
+// LoadStoredProjects returns the saved projects, without the ones only named by entries
+func (js *JournalStorage) LoadStoredProjects() ([]models.Project, error) {
+	state, err := js.load()
+	if err != nil {
+		return nil, err
+	}
+	return state.storedProjects(), nil
+}
+
+func (js *JournalStorage) LoadProjects() ([]models.Project, error) {
+	state, err := js.load()
+	if err != nil {
+		return nil, err
+	}
+
+	projects := state.storedProjects()
+
+	byName := make(map[string]struct{}, len(projects))
+	for _, project := range projects {
+		byName[project.Name] = struct{}{}
+	}
+
+	for _, entry := range state.sortedEntries() {
+		projectName := strings.TrimSpace(entry.Project)
+		if projectName == "" {
+			continue
+		}
+		if _, ok := byName[projectName]; ok {
+			continue
+		}
+		projects = append(projects, models.Project{Name: projectName})
+		byName[projectName] = struct{}{}
+	}
+
+	return normalizeProjects(projects), nil
+}
+
+func (js *JournalStorage) SaveProjects(projects []models.Project) error {
+	return js.update(func(state *journalState, at time.Time) []journalEvent {
+		return state.projectEvents(projects, at)
+	})
+}
+
+// projectEvents returns the events that turn the stored projects into projects
+func (state *journalState) projectEvents(projects []models.Project, at time.Time) []journalEvent {
+	saved := toV14Projects(normalizeProjects(projects))
+	next := make(map[string]models.V14Project, len(saved))
+	for _, project := range saved {
+		next[strings.ToLower(project.Name)] = project
+	}
+
+	var events []journalEvent
+	for _, key := range sortedKeys(state.projects) {
+		if _, ok := next[key]; !ok {
+			events = append(events, journalEvent{Op: journalOpDeleteProject, At: at, Name: state.projects[key].Name})
+		}
+	}
+	for _, project := range saved {
+		if previous, ok := state.projects[strings.ToLower(project.Name)]; ok && sameV14Project(previous, project) {
+			continue
+		}
+		events = append(events, journalEvent{Op: journalOpPutProject, At: at, Project: &journalProject{V14Project: project}})
+	}
+	return events
+}
+
+func (js *JournalStorage) LoadTasks() ([]models.Task, error) {
+	state, err := js.load()
+	if err != nil {
+		return nil, err
+	}
+
+	stored := make([]models.V8Task, 0, len(state.tasks))
+	for _, key := range sortedKeys(state.tasks) {
+		stored = append(stored, state.tasks[key])
+	}
+	return sortTasks(fromV8Tasks(stored)), nil
+}
+
+func (js *JournalStorage) SaveTasks(tasks []models.Task) error {
+	return js.update(func(state *journalState, at time.Time) []journalEvent {
+		return state.taskEvents(tasks, at)
+	})
+}
+
+// taskEvents returns the events that turn the stored tasks into tasks
+func (state *journalState) taskEvents(tasks []models.Task, at time.Time) []journalEvent {
+	saved := toV8Tasks(tasks)
+	next := make(map[string]models.V8Task, len(saved))
+	for _, task := range saved {
+		next[task.ID] = task
+	}
+
+	var events []journalEvent
+	for _, key := range sortedKeys(state.tasks) {
+		if _, ok := next[key]; !ok {
+			events = append(events, journalEvent{Op: journalOpDeleteTask, At: at, ID: key})
+		}
+	}
+	for _, task := range saved {
+		if previous, ok := state.tasks[task.ID]; ok && sameV8Task(previous, task) {
+			continue
+		}
+		task := task
+		events = append(events, journalEvent{Op: journalOpPutTask, At: at, Task: &task})
+	}
+	return events
+}
+
+// SaveAll appends the changes to the entries, projects, and tasks in one batch
+func (js *JournalStorage) SaveAll(entries []models.TimeEntry, projects []models.Project, tasks []models.Task) error {
+	return js.update(func(state *journalState, at time.Time) []journalEvent {
+		events := state.entryEvents(entries, at)
+		events = append(events, state.projectEvents(projects, at)...)
+		return append(events, state.taskEvents(tasks, at)...)
+	})
+}
+
+// Compact rewrites the journal as a snapshot, returning the number of events before and after
+func (js *JournalStorage) Compact() (int, int, error) {
+	unlock, err := lockFile(js.FilePath + ".lock")
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to lock journal file: %w", err)
+	}
+	defer unlock()
+
+	state, err := js.replay()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	at := js.now()
+	var events []journalEvent
+	for _, key := range sortedKeys(state.projects) {
+		events = append(events, journalEvent{Op: journalOpPutProject, At: at, Project: &journalProject{V14Project: state.projects[key]}})
+	}
+	for _, key := range sortedKeys(state.tasks) {
+		task := state.tasks[key]
+		events = append(events, journalEvent{Op: journalOpPutTask, At: at, Task: &task})
+	}
+	for _, key := range sortedKeys(state.entries) {
+		entry := state.entries[key]
+		events = append(events, journalEvent{Op: journalOpPutEntry, At: at, Entry: &entry})
+	}
+
+	data, err := encodeJournalEvents(events)
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := writeFileAtomic(js.FilePath, data); err != nil {
+		return 0, 0, err
+	}
+
+	// The snapshot holds the same data, so a caller up to date stays so; a stale one still conflicts
+	if js.hasBaseline && js.loadedLength == state.validLength {
+		js.loadedLength = int64(len(data))
+	} else if js.hasBaseline {
+		js.loadedLength = -1
+	}
+
+	return state.events, len(events), nil
+}
+
+func (js *JournalStorage) replay() (*journalState, error) {
+	data, err := os.ReadFile(js.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal file: %w", err)
+	}
+
+	state := &journalState{
+		entries:  make(map[string]models.V9Entry),
+		projects: make(map[string]models.V14Project),
+		tasks:    make(map[string]models.V8Task),
+	}
+
+	lineNumber := 0
+	var offset int64
+	for len(data) > 0 {
+		lineNumber++
+		line := data
+		complete := false
+		if idx := bytes.IndexByte(data, '\n'); idx >= 0 {
+			line = data[:idx]
+			data = data[idx+1:]
+			complete = true
+		} else {
+			data = nil
+		}
+
+		if len(bytes.TrimSpace(line)) == 0 {
+			if complete {
+				offset += int64(len(line)) + 1
+			}
+			continue
+		}
+
+		var event journalEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			if !complete {
+				// A crash mid-append leaves a torn final line; it is dropped on the next append
+				break
+			}
+			return nil, fmt.Errorf("failed to parse journal line %d: %w", lineNumber, err)
+		}
+		if err := state.apply(event); err != nil {
+			return nil, fmt.Errorf("invalid journal line %d: %w", lineNumber, err)
+		}
+
+		state.events++
+		offset += int64(len(line))
+		if complete {
+			offset++
+		}
+	}
+	state.validLength = offset
+
+	return state, nil
+}
+
+func (state *journalState) apply(event journalEvent) error {
+	switch event.Op {
+	case journalOpPutEntry:
+		if event.Entry == nil {
+			return errors.New("put-entry event without entry")
+		}
+		state.entries[journalEntryKey(*event.Entry)] = *event.Entry
+	case journalOpDeleteEntry:
+		switch {
+		case event.ID != "":
+			delete(state.entries, event.ID)
+		case event.Start != nil:
+			delete(state.entries, journalEntryKey(models.V9Entry{Start: *event.Start}))
+		default:
+			return errors.New("delete-entry event without id or start")
+		}
+	case journalOpPutProject:
+		if event.Project == nil {
+			return errors.New("put-project event without project")
+		}
+		project := event.Project.V14Project
+		if project.HourlyRateCents == 0 {
+			project.HourlyRateCents = centsFromRate(event.Project.HourlyRate)
+		}
+		state.projects[strings.ToLower(project.Name)] = project
+	case journalOpDeleteProject:
+		delete(state.projects, strings.ToLower(event.Name))
+	case journalOpPutTask:
+		if event.Task == nil {
+			return errors.New("put-task event without task")
+		}
+		state.tasks[event.Task.ID] = *event.Task
+	case journalOpDeleteTask:
+		delete(state.tasks, event.ID)
+	default:
+		return fmt.Errorf("unknown journal operation %q", event.Op)
+	}
+	return nil
+}
+
+func (state *journalState) storedProjects() []models.Project {
+	stored := make([]models.V14Project, 0, len(state.projects))
+	for _, project := range state.projects {
+		stored = append(stored, project)
+	}
+	return normalizeProjects(fromV14Projects(stored))
+}
+
+// journalEntryKey keys entries by ID, or by start when they were journaled without one
+func journalEntryKey(entry models.V9Entry) string {
+	if entry.ID != "" {
+		return entry.ID
+	}
+	return "@" + strconv.FormatInt(entry.Start.UnixNano(), 10)
+}
+
+func (state *journalState) sortedEntries() []models.TimeEntry {
+	entries := make([]models.TimeEntry, 0, len(state.entries))
+	for _, key := range sortedKeys(state.entries) {
+		entries = append(entries, fromV9Entry(state.entries[key]))
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Start.Before(entries[j].Start)
+	})
+	// Entries journaled before IDs existed get the same IDs on every replay until saved
+	assignEntryIDs(entries)
+
+	// Reconstruct End times from next entry's Start time, same as FileStorage
+	for i := 0; i < len(entries)-1; i++ {
+		next := entries[i+1].Start
+		entries[i].End = &next
+	}
+
+	return entries
+}
+
+// appendEvents appends the events after the complete lines and returns the new length
+func (js *JournalStorage) appendEvents(state *journalState, events []journalEvent) (int64, error) {
+	if len(events) == 0 {
+		return state.validLength, nil
+	}
+
+	data, err := encodeJournalEvents(events)
+	if err != nil {
+		return 0, err
+	}
+
+	file, err := os.OpenFile(js.FilePath, os.O_RDWR, 0644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open journal file: %w", err)
+	}
+	defer file.Close()
+
+	// Drop a torn final line (or a missing trailing newline) before appending
+	if err := file.Truncate(state.validLength); err != nil {
+		return 0, fmt.Errorf("failed to truncate journal file: %w", err)
+	}
+	if _, err := file.WriteAt(data, state.validLength); err != nil {
+		return 0, fmt.Errorf("failed to append to journal file: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		return 0, fmt.Errorf("failed to sync journal file: %w", err)
+	}
+
+	return state.validLength + int64(len(data)), nil
+}
+
+func encodeJournalEvents(events []journalEvent) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, event := range events {
+		line, err := json.Marshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal journal event: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+func sameV9Entry(a, b models.V9Entry) bool {
+	return a.ID == b.ID &&
+		a.Start.Equal(b.Start) &&
+		a.Project == b.Project &&
+		a.Title == b.Title &&
+		a.Notes == b.Notes &&
+		a.Task == b.Task &&
+		slices.Equal(a.Tags, b.Tags)
+}
+
+func sameV14Project(a, b models.V14Project) bool {
+	return a.Name == b.Name &&
+		a.Code == b.Code &&
+		a.Category == b.Category &&
+		a.HourlyRateCents == b.HourlyRateCents &&
+		a.Currency == b.Currency &&
+		a.Billable == b.Billable &&
+		a.Archived == b.Archived &&
+		a.Parent == b.Parent &&
+		a.Budget == b.Budget &&
+		sameDate(a.BudgetFrom, b.BudgetFrom) &&
+		sameDate(a.BudgetTo, b.BudgetTo) &&
+		slices.Equal(a.Aliases, b.Aliases)
+}
+
+// sameDate reports whether two optional times are both unset or equal
+func sameDate(a, b *time.Time) bool {
+	return (a == nil) == (b == nil) && (a == nil || a.Equal(*b))
+}
+
+func sameV8Task(a, b models.V8Task) bool {
+	return a.ID == b.ID &&
+		a.Project == b.Project &&
+		a.Name == b.Name &&
+		a.Created.Equal(b.Created) &&
+		(a.Completed == nil) == (b.Completed == nil) &&
+		(a.Completed == nil || a.Completed.Equal(*b.Completed))
+}
+
+func sortedKeys[K int64 | string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
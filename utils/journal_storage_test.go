@@ -0,0 +1,392 @@
+package utils
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+)
+
+func newTestJournalStorage(t *testing.T) *JournalStorage {
+	t.Helper()
+
+	storage, err := NewJournalStorage(filepath.Join(t.TempDir(), "data.journal"))
+	if err != nil {
+		t.Fatalf("Failed to create journal storage: %v", err)
+	}
+	return storage
+}
+
+func journalLines(t *testing.T, storage *JournalStorage) []string {
+	t.Helper()
+
+	data, err := os.ReadFile(storage.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to read journal: %v", err)
+	}
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+func TestJournalStorage_SaveAndLoadEntries(t *testing.T) {
+	storage := newTestJournalStorage(t)
+
+	nineAM := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	tenAM := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)
+
+	if err := storage.Save([]models.TimeEntry{
+		{ID: "b2222", Start: tenAM},
+		{ID: "a1111", Start: nineAM, Project: "Alpha", Title: "Build", Tags: []string{"review"}, Notes: "Done"},
+	}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	entries, err := storage.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	want := []models.TimeEntry{
+		{ID: "a1111", Start: nineAM, End: &tenAM, Project: "Alpha", Title: "Build", Tags: []string{"review"}, Notes: "Done"},
+		{ID: "b2222", Start: tenAM},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Fatalf("Expected %+v, got %+v", want, entries)
+	}
+}
+
+func TestJournalStorage_SaveAppendsOnlyChanges(t *testing.T) {
+	storage := newTestJournalStorage(t)
+	tm := NewTaskManager(storage)
+
+	if _, err := tm.StartEntry("Alpha", "Build"); err != nil {
+		t.Fatalf("StartEntry returned error: %v", err)
+	}
+	if lines := journalLines(t, storage); len(lines) != 1 {
+		t.Fatalf("Expected 1 event after start, got %d: %v", len(lines), lines)
+	}
+
+	if _, err := tm.StopEntry(); err != nil {
+		t.Fatalf("StopEntry returned error: %v", err)
+	}
+	lines := journalLines(t, storage)
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 events after stop, got %d: %v", len(lines), lines)
+	}
+	if !strings.Contains(lines[1], `"op":"put-entry"`) {
+		t.Fatalf("Expected stop to append a put-entry event, got %s", lines[1])
+	}
+
+	entries, err := tm.ListEntries()
+	if err != nil {
+		t.Fatalf("ListEntries returned error: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Project != "Alpha" || !entries[1].IsBlank() {
+		t.Fatalf("Expected running entry followed by blank entry, got %+v", entries)
+	}
+}
+
+func TestJournalStorage_RecordsTheOperationOfEachChange(t *testing.T) {
+	storage := newTestJournalStorage(t)
+	tm := NewTaskManager(storage)
+
+	if _, err := tm.StartEntry("Acme", "Build"); err != nil {
+		t.Fatalf("StartEntry returned error: %v", err)
+	}
+	if _, err := tm.StopEntry(); err != nil {
+		t.Fatalf("StopEntry returned error: %v", err)
+	}
+
+	lines := journalLines(t, storage)
+	if len(lines) != 2 || !strings.Contains(lines[0], `"operation":"start Acme: Build"`) || !strings.Contains(lines[1], `"operation":"stop tracking"`) {
+		t.Fatalf("Expected each change to be named in the journal, got %q", lines)
+	}
+
+	// Writes outside a TaskManager change are not named
+	if err := storage.SaveProjects([]models.Project{{Name: "Acme"}}); err != nil {
+		t.Fatalf("SaveProjects returned error: %v", err)
+	}
+	if lines := journalLines(t, storage); strings.Contains(lines[len(lines)-1], `"operation"`) {
+		t.Fatalf("Expected a direct save to be unnamed, got %q", lines[len(lines)-1])
+	}
+}
+
+func TestJournalStorage_SaveRecordsDeletes(t *testing.T) {
+	storage := newTestJournalStorage(t)
+
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	if err := storage.Save([]models.TimeEntry{
+		{Start: start, Project: "Alpha", Title: "Build"},
+		{Start: start.Add(time.Hour), Project: "Beta", Title: "Docs"},
+	}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if err := storage.Save([]models.TimeEntry{{Start: start, Project: "Alpha", Title: "Build"}}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	lines := journalLines(t, storage)
+	if len(lines) != 3 || !strings.Contains(lines[2], `"op":"delete-entry"`) {
+		t.Fatalf("Expected a single delete-entry event to be appended, got %v", lines)
+	}
+
+	entries, err := storage.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Project != "Alpha" {
+		t.Fatalf("Expected only Alpha entry, got %+v", entries)
+	}
+}
+
+func TestJournalStorage_KeepsEntriesWithTheSameStart(t *testing.T) {
+	storage := newTestJournalStorage(t)
+
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	if err := storage.Save([]models.TimeEntry{
+		{ID: "a1111", Start: start, Project: "Alpha", Title: "Standup"},
+		{ID: "b2222", Start: start, Project: "Alpha", Title: "Build"},
+	}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if err := storage.Save([]models.TimeEntry{{ID: "b2222", Start: start, Project: "Alpha", Title: "Build"}}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	entries, err := storage.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].ID != "b2222" {
+		t.Fatalf("Expected only the entry that was kept, got %+v", entries)
+	}
+}
+
+func TestJournalStorage_ReplaysEntriesJournaledWithoutIDs(t *testing.T) {
+	storage := newTestJournalStorage(t)
+
+	journal := `{"op":"put-entry","at":"2026-03-16T09:00:00Z","entry":{"start":"2026-03-16T09:00:00Z","project":"Alpha","title":"Build"}}
+{"op":"put-entry","at":"2026-03-16T10:00:00Z","entry":{"start":"2026-03-16T10:00:00Z","project":"Beta","title":"Docs"}}
+{"op":"delete-entry","at":"2026-03-16T11:00:00Z","start":"2026-03-16T10:00:00Z"}
+`
+	if err := os.WriteFile(storage.FilePath, []byte(journal), 0644); err != nil {
+		t.Fatalf("Failed to write journal: %v", err)
+	}
+
+	entries, err := storage.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Project != "Alpha" || entries[0].ID == "" {
+		t.Fatalf("Expected the Alpha entry with a derived ID, got %+v", entries)
+	}
+
+	// Saving replaces the entry keyed by its start with the one keyed by its ID
+	if err := storage.Save(entries); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	reloaded, err := storage.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(reloaded) != 1 || reloaded[0].ID != entries[0].ID {
+		t.Fatalf("Expected the entry to keep its ID, got %+v", reloaded)
+	}
+}
+
+func TestJournalStorage_SaveDetectsConcurrentAppend(t *testing.T) {
+	first := newTestJournalStorage(t)
+	second, err := NewJournalStorage(first.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to create journal storage: %v", err)
+	}
+
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	if _, err := first.Load(); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if err := second.Save([]models.TimeEntry{{Start: start, Project: "Other", Title: "Shell"}}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	err = first.Save([]models.TimeEntry{{Start: start.Add(time.Hour), Project: "TUI", Title: "Stale"}})
+	if !IsConflict(err) {
+		t.Fatalf("Expected conflict error, got: %v", err)
+	}
+
+	// After reloading, saving succeeds again and keeps the other process's entry
+	entries, err := first.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if err := first.Save(append(entries, models.TimeEntry{Start: start.Add(time.Hour), Project: "TUI", Title: "Fresh"})); err != nil {
+		t.Fatalf("Expected save after reload to succeed, got: %v", err)
+	}
+	if entries, _ := second.Load(); len(entries) != 2 {
+		t.Fatalf("Expected both entries, got %+v", entries)
+	}
+}
+
+func TestJournalStorage_SaveAndLoadProjects(t *testing.T) {
+	storage := newTestJournalStorage(t)
+
+	if err := storage.Save([]models.TimeEntry{{Start: time.Now(), Project: "Gamma", Title: "Support"}}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if err := storage.SaveProjects([]models.Project{
+		{Name: "Acme", Code: "ACM", Billable: true, HourlyRateCents: 10000},
+		{Name: "Internal"},
+	}); err != nil {
+		t.Fatalf("SaveProjects returned error: %v", err)
+	}
+	if err := storage.SaveProjects([]models.Project{{Name: "Acme", Code: "ACM", Billable: true, HourlyRateCents: 10000}}); err != nil {
+		t.Fatalf("SaveProjects returned error: %v", err)
+	}
+
+	projects, err := storage.LoadProjects()
+	if err != nil {
+		t.Fatalf("LoadProjects returned error: %v", err)
+	}
+
+	want := []models.Project{{Name: "Acme", Code: "ACM", Billable: true, HourlyRateCents: 10000}, {Name: "Gamma"}}
+	if !reflect.DeepEqual(projects, want) {
+		t.Fatalf("Expected %+v, got %+v", want, projects)
+	}
+}
+
+func TestJournalStorage_ReplaysDecimalHourlyRates(t *testing.T) {
+	storage := newTestJournalStorage(t)
+
+	journal := `{"op":"put-project","at":"2026-03-16T09:00:00Z","project":{"name":"Acme","code":"ACM","category":"","hourlyRate":95.35,"billable":true}}
+`
+	if err := os.WriteFile(storage.FilePath, []byte(journal), 0644); err != nil {
+		t.Fatalf("Failed to write journal: %v", err)
+	}
+
+	projects, err := storage.LoadStoredProjects()
+	if err != nil {
+		t.Fatalf("LoadStoredProjects returned error: %v", err)
+	}
+	if len(projects) != 1 || projects[0].HourlyRateCents != 9535 {
+		t.Fatalf("Expected the rate to be read as 9535 cents, got %+v", projects)
+	}
+}
+
+func TestJournalStorage_SaveAndLoadTasks(t *testing.T) {
+	storage := newTestJournalStorage(t)
+
+	created := time.Date(2026, 3, 16, 8, 0, 0, 0, time.UTC)
+	completed := created.Add(time.Hour)
+	if err := storage.SaveTasks([]models.Task{
+		{ID: "1", Project: "Acme", Name: "Build", Created: created},
+		{ID: "2", Project: "Acme", Name: "Review", Created: created},
+	}); err != nil {
+		t.Fatalf("SaveTasks returned error: %v", err)
+	}
+	if err := storage.SaveTasks([]models.Task{{ID: "1", Project: "Acme", Name: "Build", Created: created, Completed: &completed}}); err != nil {
+		t.Fatalf("SaveTasks returned error: %v", err)
+	}
+
+	lines := journalLines(t, storage)
+	if len(lines) != 4 || !strings.Contains(lines[2], `"op":"delete-task"`) || !strings.Contains(lines[3], `"op":"put-task"`) {
+		t.Fatalf("Expected two puts, a delete, and an update, got %q", lines)
+	}
+
+	tasks, err := storage.LoadTasks()
+	if err != nil {
+		t.Fatalf("LoadTasks returned error: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != "1" || tasks[0].Completed == nil || !tasks[0].Completed.Equal(completed) {
+		t.Fatalf("Expected the completed task, got %+v", tasks)
+	}
+}
+
+func TestJournalStorage_IgnoresTornFinalLine(t *testing.T) {
+	storage := newTestJournalStorage(t)
+
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	if err := storage.Save([]models.TimeEntry{{Start: start, Project: "Alpha", Title: "Build"}}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	// Simulate a crash in the middle of appending an event
+	file, err := os.OpenFile(storage.FilePath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("Failed to open journal: %v", err)
+	}
+	if _, err := file.WriteString(`{"op":"put-entry","entry":{"sta`); err != nil {
+		t.Fatalf("Failed to write torn line: %v", err)
+	}
+	_ = file.Close()
+
+	entries, err := storage.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected torn line to be ignored, got %+v", entries)
+	}
+
+	if err := storage.Save(append(entries, models.TimeEntry{Start: start.Add(time.Hour)})); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	data, err := os.ReadFile(storage.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to read journal: %v", err)
+	}
+	if bytes.Contains(data, []byte(`"sta{`)) || bytes.Count(data, []byte("\n")) != 2 {
+		t.Fatalf("Expected torn line to be replaced by the next append, got:\n%s", data)
+	}
+}
+
+func TestJournalStorage_RejectsCorruptLine(t *testing.T) {
+	storage := newTestJournalStorage(t)
+
+	if err := os.WriteFile(storage.FilePath, []byte("{\"op\":\"explode\"}\n"), 0644); err != nil {
+		t.Fatalf("Failed to write journal: %v", err)
+	}
+
+	if _, err := storage.Load(); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Fatalf("Expected error naming the invalid line, got: %v", err)
+	}
+}
+
+func TestJournalStorage_CompactKeepsStateAndDropsHistory(t *testing.T) {
+	storage := newTestJournalStorage(t)
+	tm := NewTaskManager(storage)
+
+	if _, err := tm.StartEntry("Alpha", "Build"); err != nil {
+		t.Fatalf("StartEntry returned error: %v", err)
+	}
+	if _, err := tm.StartEntry("Beta", "Docs"); err != nil {
+		t.Fatalf("StartEntry returned error: %v", err)
+	}
+	if err := tm.DeleteEntry(1); err != nil {
+		t.Fatalf("DeleteEntry returned error: %v", err)
+	}
+
+	before, err := storage.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	eventsBefore, eventsAfter, err := storage.Compact()
+	if err != nil {
+		t.Fatalf("Compact returned error: %v", err)
+	}
+	if eventsBefore <= eventsAfter || eventsAfter != len(before) {
+		t.Fatalf("Expected compaction from more events to %d, got %d -> %d", len(before), eventsBefore, eventsAfter)
+	}
+
+	after, err := storage.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !reflect.DeepEqual(before, after) {
+		t.Fatalf("Expected compaction to preserve entries, got %+v, want %+v", after, before)
+	}
+}
@@ -10,12 +10,14 @@ import (
 type MemoryStorage struct {
 	data     []models.TimeEntry
 	projects []models.Project
+	tasks    []models.Task
 }
 
 func NewMemoryStorage() *MemoryStorage {
 	return &MemoryStorage{
 		data:     []models.TimeEntry{},
 		projects: []models.Project{},
+		tasks:    []models.Task{},
 	}
 }
 
@@ -27,15 +29,22 @@ func (ms *MemoryStorage) Load() ([]models.TimeEntry, error) {
 }
 
 func (ms *MemoryStorage) Save(entries []models.TimeEntry) error {
-	// Store a copy
+	// Store a copy, giving new entries an ID
 	ms.data = make([]models.TimeEntry, len(entries))
 	copy(ms.data, entries)
+	assignEntryIDs(ms.data)
 	return nil
 }
 
-func (ms *MemoryStorage) LoadProjects() ([]models.Project, error) {
+// LoadStoredProjects returns the saved projects, without the ones only named by entries
+func (ms *MemoryStorage) LoadStoredProjects() ([]models.Project, error) {
 	projects := make([]models.Project, len(ms.projects))
 	copy(projects, ms.projects)
+	return normalizeProjects(projects), nil
+}
+
+func (ms *MemoryStorage) LoadProjects() ([]models.Project, error) {
+	projects, _ := ms.LoadStoredProjects()
 
 	byName := make(map[string]struct{}, len(projects))
 	for _, project := range projects {
@@ -102,3 +111,21 @@ func (ms *MemoryStorage) SaveProjects(projects []models.Project) error {
 	copy(ms.projects, projects)
 	return nil
 }
+
+func (ms *MemoryStorage) LoadTasks() ([]models.Task, error) {
+	tasks := make([]models.Task, len(ms.tasks))
+	copy(tasks, ms.tasks)
+	return sortTasks(tasks), nil
+}
+
+func (ms *MemoryStorage) SaveTasks(tasks []models.Task) error {
+	ms.tasks = make([]models.Task, len(tasks))
+	copy(ms.tasks, tasks)
+	return nil
+}
+
+func (ms *MemoryStorage) SaveAll(entries []models.TimeEntry, projects []models.Project, tasks []models.Task) error {
+	_ = ms.Save(entries)
+	_ = ms.SaveProjects(projects)
+	return ms.SaveTasks(tasks)
+}
@@ -97,3 +97,88 @@ func TransformV3ToV4(entries []models.V3Entry) ([]models.V4Entry, error) {
 	}
 	return v4Entries, nil
 }
+
+func TransformV4ToV5(entries []models.V4Entry) ([]models.V5Entry, error) {
+	v5Entries := make([]models.V5Entry, len(entries))
+	for i, entry := range entries {
+		v5Entries[i] = models.V5Entry{
+			Start:   entry.Start,
+			Project: entry.Project,
+			Title:   entry.Title,
+		}
+	}
+	return v5Entries, nil
+}
+
+func TransformV5ToV6(entries []models.V5Entry) ([]models.V6Entry, error) {
+	v6Entries := make([]models.V6Entry, len(entries))
+	for i, entry := range entries {
+		v6Entries[i] = models.V6Entry{
+			Start:   entry.Start,
+			Project: entry.Project,
+			Title:   entry.Title,
+			Tags:    entry.Tags,
+		}
+	}
+	return v6Entries, nil
+}
+
+// TransformV7ToV8 converts entries, which kept the v6 format in v7, to the v8 format
+func TransformV7ToV8(entries []models.V6Entry) ([]models.V8Entry, error) {
+	v8Entries := make([]models.V8Entry, len(entries))
+	for i, entry := range entries {
+		v8Entries[i] = models.V8Entry{
+			Start:   entry.Start,
+			Project: entry.Project,
+			Title:   entry.Title,
+			Tags:    entry.Tags,
+			Notes:   entry.Notes,
+		}
+	}
+	return v8Entries, nil
+}
+
+// TransformV8ToV9 gives every entry a stable ID
+func TransformV8ToV9(entries []models.V8Entry) ([]models.V9Entry, error) {
+	withIDs := make([]models.TimeEntry, len(entries))
+	for i, entry := range entries {
+		withIDs[i] = models.TimeEntry{Start: entry.Start, Project: entry.Project, Title: entry.Title}
+	}
+	assignEntryIDs(withIDs)
+
+	v9Entries := make([]models.V9Entry, len(entries))
+	for i, entry := range entries {
+		v9Entries[i] = models.V9Entry{
+			ID:      withIDs[i].ID,
+			Start:   entry.Start,
+			Project: entry.Project,
+			Title:   entry.Title,
+			Tags:    entry.Tags,
+			Notes:   entry.Notes,
+			Task:    entry.Task,
+		}
+	}
+	return v9Entries, nil
+}
+
+// TransformV13ToV14 converts the decimal hourly rates of projects to cents
+func TransformV13ToV14(projects []models.V13Project) ([]models.V14Project, error) {
+	v14Projects := make([]models.V14Project, len(projects))
+	for i, project := range projects {
+		v14Projects[i] = models.V14Project{
+			Name:            project.Name,
+			Code:            project.Code,
+			Category:        project.Category,
+			HourlyRateCents: centsFromRate(project.HourlyRate),
+			Currency:        project.Currency,
+			Billable:        project.Billable,
+			Archived:        project.Archived,
+			Parent:          project.Parent,
+			Budget:          project.Budget,
+			BudgetFrom:      project.BudgetFrom,
+			BudgetTo:        project.BudgetTo,
+			Aliases:         project.Aliases,
+		}
+	}
+	return v14Projects, nil
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseMoney parses a non-negative amount with at most two decimals, e.g. "85.50", into cents
+func ParseMoney(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	whole, fraction, hasFraction := strings.Cut(value, ".")
+	if whole == "" || strings.Trim(whole, "0123456789") != "" || strings.Trim(fraction, "0123456789") != "" ||
+		len(fraction) > 2 || (hasFraction && fraction == "") {
+		return 0, fmt.Errorf("invalid amount %q: expected a number with at most two decimals, e.g. 85.50", value)
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units > math.MaxInt64/100-1 {
+		return 0, fmt.Errorf("amount %q is too large", value)
+	}
+	cents, _ := strconv.ParseInt((fraction + "00")[:2], 10, 64)
+	return units*100 + cents, nil
+}
+
+// FormatMoney formats an amount in cents with two decimals, e.g. "85.50"
+func FormatMoney(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// BillableAmount returns the amount in cents of a duration at an hourly rate in cents
+func BillableAmount(d time.Duration, rateCents int64) int64 {
+	hourMillis := time.Hour.Milliseconds()
+	hours := int64(d / time.Hour)
+	rest := (d % time.Hour).Milliseconds()
+	return hours*rateCents + (rest*rateCents+hourMillis/2)/hourMillis
+}
+
+// centsFromRate converts a rate stored as a decimal number, as before v14, to cents
+func centsFromRate(rate float64) int64 {
+	return int64(math.Round(rate * 100))
+}
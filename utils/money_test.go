@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := map[string]int64{
+		"85":      8500,
+		" 85.5 ":  8550,
+		"85.05":   8505,
+		"0.99":    99,
+		"":        0,
+		"1000000": 100000000,
+	}
+	for input, want := range tests {
+		if got, err := ParseMoney(input); err != nil || got != want {
+			t.Errorf("ParseMoney(%q) = %d, %v, want %d", input, got, err, want)
+		}
+	}
+
+	for _, input := range []string{"-5", "85.555", "85.", ".5", "1e3", "eighty", "99999999999999999999"} {
+		if _, err := ParseMoney(input); err == nil {
+			t.Errorf("expected ParseMoney(%q) to fail", input)
+		}
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	tests := map[int64]string{0: "0.00", 5: "0.05", 8550: "85.50", -1234: "-12.34"}
+	for cents, want := range tests {
+		if got := FormatMoney(cents); got != want {
+			t.Errorf("FormatMoney(%d) = %q, want %q", cents, got, want)
+		}
+	}
+}
+
+func TestBillableAmount(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		rate     int64
+		want     int64
+	}{
+		{90 * time.Minute, 10000, 15000},
+		{10 * time.Minute, 10000, 1667},
+		{time.Minute, 30, 1}, // Half a cent rounds up
+		{1000 * time.Hour, 99999999, 99999999000},
+	}
+	for _, tt := range tests {
+		if got := BillableAmount(tt.duration, tt.rate); got != tt.want {
+			t.Errorf("BillableAmount(%v, %d) = %d, want %d", tt.duration, tt.rate, got, tt.want)
+		}
+	}
+}
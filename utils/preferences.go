@@ -0,0 +1,32 @@
+package utils
+
+import "time"
+
+// Display and grouping preferences, set once from the configuration at startup
+var (
+	weekStart      = time.Monday
+	use12HourClock = false
+)
+
+// SetWeekStart sets the first day of the week used by weekly totals and separators
+func SetWeekStart(day time.Weekday) {
+	weekStart = day
+}
+
+// SetUse12HourClock switches displayed times between a 24-hour and a 12-hour clock
+func SetUse12HourClock(enabled bool) {
+	use12HourClock = enabled
+}
+
+// FormatDateTime formats t as a date and clock time, e.g. "2025-03-10 14:30" or "2025-03-10 2:30 PM"
+func FormatDateTime(t time.Time) string {
+	if use12HourClock {
+		return t.Format("2006-01-02 3:04 PM")
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+// weekStartOffset returns the number of days since the start of the week containing day
+func weekStartOffset(day time.Weekday) int {
+	return (int(day) - int(weekStart) + 7) % 7
+}
@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"time-tracker/models"
+)
+
+// ResolveProject returns the project named by input, or else the one with that code or alias
+func ResolveProject(projects []models.Project, input string) (string, error) {
+	name := strings.TrimSpace(input)
+	if name == "" {
+		return input, nil
+	}
+
+	// Names take precedence over codes and aliases, with an exact match before other cases
+	for _, project := range projects {
+		if project.Name == name {
+			return project.Name, nil
+		}
+	}
+	for _, project := range projects {
+		if strings.EqualFold(project.Name, name) {
+			return project.Name, nil
+		}
+	}
+
+	var candidates []string
+	var matched string
+	for _, project := range projects {
+		switch {
+		case strings.EqualFold(project.Code, name):
+			candidates = append(candidates, fmt.Sprintf("%s (code %s)", project.Name, project.Code))
+		case hasAlias(project, name):
+			candidates = append(candidates, fmt.Sprintf("%s (alias)", project.Name))
+		default:
+			continue
+		}
+		matched = project.Name
+	}
+
+	switch len(candidates) {
+	case 0:
+		return input, nil
+	case 1:
+		return matched, nil
+	}
+	sort.Strings(candidates)
+	return "", fmt.Errorf("%q matches several projects, use one of their names: %s", name, strings.Join(candidates, ", "))
+}
+
+// ResolveProject resolves the input to a stored project by name, code, or alias
+func (tm *TaskManager) ResolveProject(input string) (string, error) {
+	projects, err := tm.storage.LoadProjects()
+	if err != nil {
+		return "", err
+	}
+	return ResolveProject(projects, input)
+}
+
+func hasAlias(project models.Project, alias string) bool {
+	for _, a := range project.Aliases {
+		if strings.EqualFold(a, alias) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeAliases trims the aliases and drops empty ones and repeats, keeping their order
+func normalizeAliases(aliases []string) []string {
+	var normalized []string
+	seen := make(map[string]bool, len(aliases))
+	for _, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		key := strings.ToLower(alias)
+		if alias == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, alias)
+	}
+	return normalized
+}
+
+// SetProjectAliases replaces the aliases of a project, which cannot name another project
+func (tm *TaskManager) SetProjectAliases(name string, aliases []string) (_ *models.Project, err error) {
+	defer tm.record(fmt.Sprintf("set aliases of project %s", strings.TrimSpace(name)))(&err)
+
+	projects, projectIndex, err := tm.loadProject(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := setProjectAliases(projects, projectIndex, aliases); err != nil {
+		return nil, err
+	}
+
+	if err := tm.storage.SaveProjects(projects); err != nil {
+		return nil, err
+	}
+
+	updated := projects[projectIndex]
+	return &updated, nil
+}
+
+// setProjectAliases validates the aliases of projects[idx] and sets them, see SetProjectAliases
+func setProjectAliases(projects []models.Project, idx int, aliases []string) error {
+	aliases = normalizeAliases(aliases)
+	for _, alias := range aliases {
+		for i, project := range projects {
+			if i != idx && strings.EqualFold(project.Name, alias) {
+				return fmt.Errorf("alias %q is the name of project %q", alias, project.Name)
+			}
+		}
+	}
+
+	projects[idx].Aliases = aliases
+	return nil
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"time-tracker/models"
+)
+
+func TestResolveProject(t *testing.T) {
+	projects := []models.Project{
+		{Name: "Acme Website", Code: "ABC-12572", Aliases: []string{"web"}},
+		{Name: "Internal", Code: "INT", Aliases: []string{"ops", "shared"}},
+		{Name: "Support", Code: "SUP", Aliases: []string{"shared"}},
+		{Name: "INT"},
+	}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Acme Website", "Acme Website"},
+		{"acme website", "Acme Website"},
+		{"abc-12572", "Acme Website"},
+		{"WEB", "Acme Website"},
+		{"ops", "Internal"},
+		{"INT", "INT"}, // A name wins over a code
+		{"New Project", "New Project"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got, err := ResolveProject(projects, tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("ResolveProject(%q) = %q, %v, want %q", tt.input, got, err, tt.want)
+		}
+	}
+
+	_, err := ResolveProject(projects, "Shared")
+	if err == nil || !strings.Contains(err.Error(), "Internal (alias), Support (alias)") {
+		t.Fatalf("expected an error listing both candidates, got %v", err)
+	}
+}
+
+func TestSetProjectAliases(t *testing.T) {
+	storage := NewMemoryStorage()
+	tm := NewTaskManager(storage)
+	if err := storage.SaveProjects([]models.Project{{Name: "Acme"}, {Name: "Internal"}}); err != nil {
+		t.Fatalf("failed to seed projects: %v", err)
+	}
+
+	project, err := tm.SetProjectAliases("acme", []string{" web ", "", "Web", "site"})
+	if err != nil {
+		t.Fatalf("SetProjectAliases returned error: %v", err)
+	}
+	if !slices.Equal(project.Aliases, []string{"web", "site"}) {
+		t.Fatalf("expected trimmed aliases without repeats, got %q", project.Aliases)
+	}
+
+	if _, err := tm.SetProjectAliases("Acme", []string{"internal"}); err == nil {
+		t.Fatal("expected an error for an alias that is another project's name")
+	}
+
+	// Merging keeps the aliases of the merged project
+	if _, err := tm.EditProject("Acme", "Internal", "", ""); err != nil {
+		t.Fatalf("EditProject returned error: %v", err)
+	}
+	if name, err := tm.ResolveProject("site"); err != nil || name != "Internal" {
+		t.Fatalf("expected the alias to resolve to the merge target, got %q, %v", name, err)
+	}
+}
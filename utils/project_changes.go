@@ -0,0 +1,253 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"time-tracker/models"
+)
+
+// ProjectChanges holds the project fields to set; nil or unflagged fields are left unchanged
+type ProjectChanges struct {
+	Name          *string // New name; the name of another project merges the project into it
+	Code          *string
+	Category      *string
+	Parent        *string // Empty makes the project a top-level project
+	Aliases       []string
+	SetAliases    bool
+	HourlyRate    *int64 // In cents
+	Currency      *string
+	Billable      *bool
+	Budget        *float64 // Hours; zero removes the budget and its dates
+	BudgetFrom    *time.Time
+	SetBudgetFrom bool
+	BudgetTo      *time.Time
+	SetBudgetTo   bool
+}
+
+// IsEmpty reports whether the changes leave the project as it is
+func (c ProjectChanges) IsEmpty() bool {
+	return c.Name == nil && c.Code == nil && c.Category == nil && c.Parent == nil && !c.SetAliases &&
+		c.HourlyRate == nil && c.Currency == nil && c.Billable == nil &&
+		c.Budget == nil && !c.SetBudgetFrom && !c.SetBudgetTo
+}
+
+// CreateProject adds a project with the given metadata, validating every change first
+func (tm *TaskManager) CreateProject(name string, changes ProjectChanges) (_ *models.Project, err error) {
+	defer tm.record(fmt.Sprintf("add project %s", strings.TrimSpace(name)))(&err)
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("project name cannot be empty")
+	}
+
+	projects, err := tm.storage.LoadProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, project := range projects {
+		if strings.EqualFold(project.Name, name) {
+			return nil, fmt.Errorf("project %q already exists", name)
+		}
+	}
+
+	projects = append(projects, models.Project{Name: name})
+	if err := applyProjectChanges(projects, len(projects)-1, changes); err != nil {
+		return nil, err
+	}
+
+	if err := tm.storage.SaveProjects(projects); err != nil {
+		return nil, err
+	}
+
+	created := projects[len(projects)-1]
+	return &created, nil
+}
+
+// UpdateProject applies the changes in one save; renaming onto another project merges into it, ignoring the rest
+func (tm *TaskManager) UpdateProject(name string, changes ProjectChanges) (_ *ProjectMutationResult, err error) {
+	defer tm.record(fmt.Sprintf("edit project %s", strings.TrimSpace(name)))(&err)
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("project name cannot be empty")
+	}
+
+	projects, err := tm.storage.LoadProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	sourceIndex := -1
+	for i, project := range projects {
+		if strings.EqualFold(project.Name, name) {
+			sourceIndex = i
+			break
+		}
+	}
+	if sourceIndex < 0 {
+		return nil, fmt.Errorf("project %q not found", name)
+	}
+
+	source := projects[sourceIndex]
+	newName := source.Name
+	if changes.Name != nil {
+		newName = strings.TrimSpace(*changes.Name)
+		if newName == "" {
+			return nil, fmt.Errorf("project name cannot be empty")
+		}
+	}
+
+	targetIndex := -1
+	for i, project := range projects {
+		if i != sourceIndex && strings.EqualFold(project.Name, newName) {
+			targetIndex = i
+			break
+		}
+	}
+
+	if targetIndex >= 0 {
+		return tm.mergeProject(projects, sourceIndex, targetIndex)
+	}
+
+	renamed := source.Name != newName
+	projects[sourceIndex].Name = newName
+	if renamed {
+		reparentProjects(projects, source.Name, newName)
+	}
+	if err := applyProjectChanges(projects, sourceIndex, changes); err != nil {
+		return nil, err
+	}
+
+	rewrittenEntries := 0
+	if renamed {
+		entries, err := tm.storage.Load()
+		if err != nil {
+			return nil, err
+		}
+		tasks, err := tm.storage.LoadTasks()
+		if err != nil {
+			return nil, err
+		}
+		for i := range entries {
+			if entries[i].Project == source.Name {
+				entries[i].Project = newName
+				rewrittenEntries++
+			}
+		}
+		if err := tm.storage.SaveAll(entries, projects, moveProjectTasks(tasks, source.Name, newName)); err != nil {
+			return nil, err
+		}
+	} else if err := tm.storage.SaveProjects(projects); err != nil {
+		return nil, err
+	}
+
+	return &ProjectMutationResult{
+		RewrittenEntries: rewrittenEntries,
+		Renamed:          renamed,
+		SourceName:       source.Name,
+		TargetName:       newName,
+		Project:          projects[sourceIndex],
+	}, nil
+}
+
+// mergeProject moves everything of projects[sourceIndex] to projects[targetIndex] and removes it
+func (tm *TaskManager) mergeProject(projects []models.Project, sourceIndex, targetIndex int) (*ProjectMutationResult, error) {
+	source := projects[sourceIndex]
+	target := projects[targetIndex].Name
+
+	entries, err := tm.storage.Load()
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := tm.storage.LoadTasks()
+	if err != nil {
+		return nil, err
+	}
+	rewrittenEntries := 0
+	for i := range entries {
+		if entries[i].Project == source.Name {
+			entries[i].Project = target
+			rewrittenEntries++
+		}
+	}
+
+	// The target keeps answering to the aliases of the project merged into it
+	projects[targetIndex].Aliases = normalizeAliases(append(slices.Clone(projects[targetIndex].Aliases), source.Aliases...))
+	merged := projects[targetIndex]
+	projects = append(projects[:sourceIndex], projects[sourceIndex+1:]...)
+	reparentProjects(projects, source.Name, target)
+
+	if err := tm.storage.SaveAll(entries, projects, moveProjectTasks(tasks, source.Name, target)); err != nil {
+		return nil, err
+	}
+
+	return &ProjectMutationResult{
+		RewrittenEntries: rewrittenEntries,
+		Merged:           true,
+		Renamed:          true,
+		SourceName:       source.Name,
+		TargetName:       target,
+		Project:          merged,
+	}, nil
+}
+
+// applyProjectChanges validates the metadata changes and applies them to projects[idx]
+func applyProjectChanges(projects []models.Project, idx int, changes ProjectChanges) error {
+	project := &projects[idx]
+	if changes.Code != nil {
+		project.Code = strings.TrimSpace(*changes.Code)
+	}
+	if changes.Category != nil {
+		project.Category = strings.TrimSpace(*changes.Category)
+	}
+	if changes.SetAliases {
+		if err := setProjectAliases(projects, idx, changes.Aliases); err != nil {
+			return err
+		}
+	}
+	if changes.Parent != nil {
+		if err := setProjectParent(projects, idx, *changes.Parent); err != nil {
+			return err
+		}
+	}
+
+	if changes.HourlyRate != nil || changes.Currency != nil || changes.Billable != nil {
+		hourlyRate, currency, billable := project.HourlyRateCents, project.Currency, project.Billable
+		if changes.HourlyRate != nil {
+			hourlyRate = *changes.HourlyRate
+		}
+		if changes.Currency != nil {
+			currency = *changes.Currency
+		}
+		if changes.Billable != nil {
+			billable = *changes.Billable
+		}
+		if err := setProjectBilling(project, hourlyRate, currency, billable); err != nil {
+			return err
+		}
+	}
+
+	if changes.Budget != nil || changes.SetBudgetFrom || changes.SetBudgetTo {
+		hours, from, to := project.Budget, project.BudgetFrom, project.BudgetTo
+		if changes.Budget != nil {
+			hours = *changes.Budget
+		}
+		if changes.SetBudgetFrom {
+			from = changes.BudgetFrom
+		}
+		if changes.SetBudgetTo {
+			to = changes.BudgetTo
+		}
+		if hours == 0 && ((changes.SetBudgetFrom && from != nil) || (changes.SetBudgetTo && to != nil)) {
+			return fmt.Errorf("project %q has no budget: set its hours for the dates to count", project.Name)
+		}
+		if err := setProjectBudget(project, hours, from, to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
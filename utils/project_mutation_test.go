@@ -119,3 +119,232 @@ func TestTaskManager_RemoveProjectBlockedWhenReferenced(t *testing.T) {
 		t.Fatalf("expected 2 references, got %d", inUseErr.ReferenceCount)
 	}
 }
+
+func TestTaskManager_SetProjectBilling(t *testing.T) {
+	storage := NewMemoryStorage()
+	tm := NewTaskManager(storage)
+
+	if _, err := tm.AddProject("Acme", "A1", "Client"); err != nil {
+		t.Fatalf("AddProject failed: %v", err)
+	}
+
+	project, err := tm.SetProjectBilling(" acme ", 12000, " usd ", true)
+	if err != nil {
+		t.Fatalf("SetProjectBilling failed: %v", err)
+	}
+	if project.Name != "Acme" || project.HourlyRateCents != 12000 || project.Currency != "USD" || !project.Billable {
+		t.Fatalf("unexpected project after billing update: %+v", project)
+	}
+
+	projects, err := storage.LoadProjects()
+	if err != nil {
+		t.Fatalf("failed to load projects: %v", err)
+	}
+	if len(projects) != 1 || projects[0].Code != "A1" || projects[0].HourlyRateCents != 12000 {
+		t.Fatalf("expected billing to be persisted with existing metadata, got %+v", projects)
+	}
+
+	if _, err := tm.SetProjectBilling("Acme", -1, "", false); err == nil {
+		t.Fatal("expected error for negative hourly rate")
+	}
+	if _, err := tm.SetProjectBilling("Missing", 10, "", false); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got: %v", err)
+	}
+}
+
+func TestTaskManager_SetProjectArchived(t *testing.T) {
+	storage := NewMemoryStorage()
+	tm := NewTaskManager(storage)
+
+	// A project only used by entries can be archived too
+	if _, err := tm.StartEntryAt("Legacy", "Maintenance", time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("StartEntryAt failed: %v", err)
+	}
+
+	project, err := tm.SetProjectArchived(" legacy ", true)
+	if err != nil {
+		t.Fatalf("SetProjectArchived failed: %v", err)
+	}
+	if project.Name != "Legacy" || !project.Archived {
+		t.Fatalf("unexpected project after archiving: %+v", project)
+	}
+
+	projects, err := storage.LoadProjects()
+	if err != nil {
+		t.Fatalf("failed to load projects: %v", err)
+	}
+	if len(projects) != 1 || !projects[0].Archived {
+		t.Fatalf("expected the archived project to be persisted, got %+v", projects)
+	}
+
+	if project, err = tm.SetProjectArchived("Legacy", false); err != nil || project.Archived {
+		t.Fatalf("expected the project to be unarchived, got %+v (%v)", project, err)
+	}
+	if _, err := tm.SetProjectArchived("Missing", true); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got: %v", err)
+	}
+}
+
+func TestTaskManager_SetProjectParent(t *testing.T) {
+	storage := NewMemoryStorage()
+	tm := NewTaskManager(storage)
+
+	for _, name := range []string{"Acme", "Website", "Checkout"} {
+		if _, err := tm.AddProject(name, "", ""); err != nil {
+			t.Fatalf("AddProject failed: %v", err)
+		}
+	}
+	if _, err := tm.SetProjectParent("website", " acme "); err != nil {
+		t.Fatalf("SetProjectParent failed: %v", err)
+	}
+	project, err := tm.SetProjectParent("Checkout", "Website")
+	if err != nil {
+		t.Fatalf("SetProjectParent failed: %v", err)
+	}
+	if project.Parent != "Website" {
+		t.Fatalf("unexpected project: %+v", project)
+	}
+
+	if _, err := tm.SetProjectParent("Acme", "Checkout"); err == nil || !strings.Contains(err.Error(), "own sub-project") {
+		t.Fatalf("expected cycle error, got: %v", err)
+	}
+	if _, err := tm.SetProjectParent("Acme", "acme"); err == nil || !strings.Contains(err.Error(), "own parent") {
+		t.Fatalf("expected self-parent error, got: %v", err)
+	}
+	if _, err := tm.SetProjectParent("Acme", "Missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got: %v", err)
+	}
+
+	// Renaming keeps the sub-projects attached, removing moves them up a level
+	if _, err := tm.EditProject("Website", "Web", "", ""); err != nil {
+		t.Fatalf("EditProject failed: %v", err)
+	}
+	if err := tm.RemoveProject("Web"); err != nil {
+		t.Fatalf("RemoveProject failed: %v", err)
+	}
+	projects, err := storage.LoadProjects()
+	if err != nil {
+		t.Fatalf("failed to load projects: %v", err)
+	}
+	if len(projects) != 2 || projects[1].Name != "Checkout" || projects[1].Parent != "Acme" {
+		t.Fatalf("expected Checkout to move up to Acme, got %+v", projects)
+	}
+}
+
+func TestTaskManager_CreateProjectValidatesEveryChangeBeforeSaving(t *testing.T) {
+	storage, tm := newHistoryTaskManager()
+
+	parent, budget := "Missing", 10.0
+	if _, err := tm.CreateProject("Foo", ProjectChanges{Parent: &parent, Budget: &budget}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected the missing parent to fail, got %v", err)
+	}
+	if projects, _ := storage.LoadProjects(); len(projects) != 0 {
+		t.Fatalf("expected nothing saved after a failed change, got %+v", projects)
+	}
+
+	code, rate, aliases := "F-1", int64(8000), []string{"foo"}
+	project, err := tm.CreateProject("Foo", ProjectChanges{Code: &code, Budget: &budget, HourlyRate: &rate, Aliases: aliases, SetAliases: true})
+	if err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	if project.Code != "F-1" || project.Budget != 10 || project.HourlyRateCents != 8000 || len(project.Aliases) != 1 {
+		t.Fatalf("unexpected project %+v", project)
+	}
+
+	// The whole add is a single step to undo
+	if _, err := tm.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if projects, _ := storage.LoadProjects(); len(projects) != 0 {
+		t.Fatalf("expected the add to be undone in one step, got %+v", projects)
+	}
+}
+
+func TestTaskManager_UpdateProjectRenamesAndChangesMetadataAtOnce(t *testing.T) {
+	storage, tm := newHistoryTaskManager()
+	if err := storage.SaveProjects([]models.Project{{Name: "Acme"}, {Name: "Legacy", Code: "L"}}); err != nil {
+		t.Fatalf("failed to seed projects: %v", err)
+	}
+
+	name, parent, budget := "Website", "acme", 40.0
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	result, err := tm.UpdateProject("legacy", ProjectChanges{Name: &name, Parent: &parent, Budget: &budget, BudgetFrom: &from, SetBudgetFrom: true})
+	if err != nil {
+		t.Fatalf("UpdateProject failed: %v", err)
+	}
+	if !result.Renamed || result.Project.Name != "Website" || result.Project.Parent != "Acme" || result.Project.Code != "L" || result.Project.Budget != 40 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	zero := 0.0
+	if _, err := tm.UpdateProject("Website", ProjectChanges{Budget: &zero, BudgetFrom: &from, SetBudgetFrom: true}); err == nil || !strings.Contains(err.Error(), "no budget") {
+		t.Fatalf("expected budget dates without hours to fail, got %v", err)
+	}
+
+	if _, err := tm.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	projects, _ := storage.LoadProjects()
+	if len(projects) != 2 || projects[1].Name != "Legacy" || projects[1].Parent != "" || projects[1].Budget != 0 {
+		t.Fatalf("expected the edit to be undone in one step, got %+v", projects)
+	}
+}
+
+type writeCountingStorage struct {
+	*MemoryStorage
+	writes int
+}
+
+func (s *writeCountingStorage) Save(entries []models.TimeEntry) error {
+	s.writes++
+	return s.MemoryStorage.Save(entries)
+}
+
+func (s *writeCountingStorage) SaveProjects(projects []models.Project) error {
+	s.writes++
+	return s.MemoryStorage.SaveProjects(projects)
+}
+
+func (s *writeCountingStorage) SaveTasks(tasks []models.Task) error {
+	s.writes++
+	return s.MemoryStorage.SaveTasks(tasks)
+}
+
+func (s *writeCountingStorage) SaveAll(entries []models.TimeEntry, projects []models.Project, tasks []models.Task) error {
+	s.writes++
+	return s.MemoryStorage.SaveAll(entries, projects, tasks)
+}
+
+func TestTaskManager_RenameAndMergeWriteOnce(t *testing.T) {
+	storage := &writeCountingStorage{MemoryStorage: NewMemoryStorage()}
+	tm := NewTaskManager(storage)
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	if err := storage.MemoryStorage.SaveProjects([]models.Project{{Name: "Legacy"}, {Name: "Current"}}); err != nil {
+		t.Fatalf("failed to seed projects: %v", err)
+	}
+	if err := storage.MemoryStorage.Save([]models.TimeEntry{{ID: "a1b2c", Project: "Legacy", Title: "Fix", Task: "t1", Start: start}}); err != nil {
+		t.Fatalf("failed to seed entries: %v", err)
+	}
+	if err := storage.MemoryStorage.SaveTasks([]models.Task{{ID: "t1", Project: "Legacy", Name: "Fix", Created: start}}); err != nil {
+		t.Fatalf("failed to seed tasks: %v", err)
+	}
+
+	if _, err := tm.EditProject("Legacy", "Old", "", ""); err != nil {
+		t.Fatalf("rename failed: %v", err)
+	}
+	if storage.writes != 1 {
+		t.Fatalf("expected the rename to write once, got %d writes", storage.writes)
+	}
+
+	storage.writes = 0
+	if _, err := tm.EditProject("Old", "Current", "", ""); err != nil {
+		t.Fatalf("merge failed: %v", err)
+	}
+	if storage.writes != 1 {
+		t.Fatalf("expected the merge to write once, got %d writes", storage.writes)
+	}
+	tasks, _ := storage.LoadTasks()
+	if len(tasks) != 1 || tasks[0].Project != "Current" {
+		t.Fatalf("expected the task to move with the merge, got %+v", tasks)
+	}
+}
@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"time-tracker/models"
+)
+
+// ProjectTrendWeeks is the number of recent weeks in the weekly trend of a project summary
+const ProjectTrendWeeks = 8
+
+// untitledCategory groups entries without a title
+const untitledCategory = "(untitled)"
+
+// TitleTotal is the time tracked under a task title or title category
+type TitleTotal struct {
+	Name    string
+	Total   time.Duration
+	Entries int
+}
+
+// ProjectSummary describes how a project and its sub-projects were used over their lifetime
+type ProjectSummary struct {
+	Project    string
+	Total      time.Duration
+	Entries    int
+	FirstUsed  time.Time     // Start of the earliest entry, zero when nothing was tracked
+	LastUsed   time.Time     // Start of the latest entry, zero when nothing was tracked
+	Categories []TitleTotal  // Time per title category, longest first
+	Titles     []TitleTotal  // Time per title, longest first
+	Weeks      []WeeklyTotal // The last ProjectTrendWeeks weeks, oldest first
+}
+
+// SummarizeProject adds up the entries of the project and its sub-projects
+func SummarizeProject(project models.Project, projects []models.Project, entries []models.TimeEntry, now time.Time) ProjectSummary {
+	summary := ProjectSummary{Project: project.Name}
+
+	currentWeek := WeekStartOf(now)
+	firstWeek := currentWeek.AddDate(0, 0, -7*(ProjectTrendWeeks-1))
+	for i := range ProjectTrendWeeks {
+		summary.Weeks = append(summary.Weeks, WeeklyTotal{WeekStart: firstWeek.AddDate(0, 0, 7*i)})
+	}
+
+	tree := NewProjectTree(projects)
+	categories := newTitleTotals()
+	titles := newTitleTotals()
+	for _, entry := range entries {
+		if entry.IsBlank() || entry.Start.After(now) || !isProjectOrSubProject(tree, entry.Project, project.Name) {
+			continue
+		}
+
+		end := now
+		if entry.End != nil && entry.End.Before(now) {
+			end = *entry.End
+		}
+		duration := end.Sub(entry.Start)
+		summary.Total += duration
+		summary.Entries++
+		if summary.FirstUsed.IsZero() || entry.Start.Before(summary.FirstUsed) {
+			summary.FirstUsed = entry.Start
+		}
+		if entry.Start.After(summary.LastUsed) {
+			summary.LastUsed = entry.Start
+		}
+
+		title := strings.TrimSpace(entry.Title)
+		if title == "" {
+			title = untitledCategory
+		}
+		titles.add(title, duration)
+		categories.add(TitleCategory(entry.Title), duration)
+
+		if week := WeekStartOf(entry.Start); !week.Before(firstWeek) {
+			if i := int(week.Sub(firstWeek).Hours()/24+0.5) / 7; i < len(summary.Weeks) {
+				summary.Weeks[i].Total += duration
+			}
+		}
+	}
+
+	summary.Categories = categories.sorted()
+	summary.Titles = titles.sorted()
+	return summary
+}
+
+// TitleCategory returns the text of a title before its first colon, or else its first word
+func TitleCategory(title string) string {
+	title = strings.TrimSpace(title)
+	if prefix, _, found := strings.Cut(title, ":"); found && strings.TrimSpace(prefix) != "" {
+		return strings.TrimSpace(prefix)
+	}
+	if fields := strings.Fields(title); len(fields) > 0 {
+		return fields[0]
+	}
+	return untitledCategory
+}
+
+// titleTotals adds up time per name, case-insensitively, keeping the first spelling seen
+type titleTotals struct {
+	byKey map[string]*TitleTotal
+}
+
+func newTitleTotals() *titleTotals {
+	return &titleTotals{byKey: make(map[string]*TitleTotal)}
+}
+
+func (t *titleTotals) add(name string, duration time.Duration) {
+	key := strings.ToLower(name)
+	total, ok := t.byKey[key]
+	if !ok {
+		total = &TitleTotal{Name: name}
+		t.byKey[key] = total
+	}
+	total.Total += duration
+	total.Entries++
+}
+
+// sorted returns the totals longest first, ties by name
+func (t *titleTotals) sorted() []TitleTotal {
+	result := make([]TitleTotal, 0, len(t.byKey))
+	for _, total := range t.byKey {
+		result = append(result, *total)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Total != result[j].Total {
+			return result[i].Total > result[j].Total
+		}
+		return strings.ToLower(result[i].Name) < strings.ToLower(result[j].Name)
+	})
+	return result
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"time-tracker/models"
+)
+
+func TestSummarizeProject_AddsUpSubProjectsByTitleAndWeek(t *testing.T) {
+	now := time.Date(2026, 3, 29, 12, 0, 0, 0, time.UTC)
+	project := models.Project{Name: "Acme"}
+	projects := []models.Project{project, {Name: "Website", Parent: "Acme"}, {Name: "Internal"}}
+
+	var entries []models.TimeEntry
+	add := func(start time.Time, hours int, name, title string) {
+		end := start.Add(time.Duration(hours) * time.Hour)
+		entries = append(entries, models.TimeEntry{Start: start, End: &end, Project: name, Title: title})
+	}
+	add(time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC), 2, "Acme", "Meeting with Bob") // Before the trend
+	add(time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC), 3, "Website", "Review: checkout")
+	add(time.Date(2026, 3, 17, 9, 0, 0, 0, time.UTC), 5, "Internal", "Review: payroll")
+	add(time.Date(2026, 3, 18, 9, 0, 0, 0, time.UTC), 1, "acme", "review: checkout")
+	entries = append(entries, models.TimeEntry{Start: now.Add(-time.Hour), Project: "Acme", Title: "Meeting notes"})
+
+	summary := SummarizeProject(project, projects, entries, now)
+
+	if summary.Total != 7*time.Hour || summary.Entries != 4 {
+		t.Fatalf("expected 7h in 4 entries, got %v in %d", summary.Total, summary.Entries)
+	}
+	if !summary.FirstUsed.Equal(entries[0].Start) || !summary.LastUsed.Equal(entries[4].Start) {
+		t.Fatalf("unexpected first and last use: %v, %v", summary.FirstUsed, summary.LastUsed)
+	}
+
+	wantCategories := []TitleTotal{{Name: "Review", Total: 4 * time.Hour, Entries: 2}, {Name: "Meeting", Total: 3 * time.Hour, Entries: 2}}
+	if len(summary.Categories) != len(wantCategories) {
+		t.Fatalf("expected categories %+v, got %+v", wantCategories, summary.Categories)
+	}
+	for i, want := range wantCategories {
+		if summary.Categories[i] != want {
+			t.Fatalf("expected categories %+v, got %+v", wantCategories, summary.Categories)
+		}
+	}
+	if len(summary.Titles) != 3 || summary.Titles[0] != (TitleTotal{Name: "Review: checkout", Total: 4 * time.Hour, Entries: 2}) {
+		t.Fatalf("expected the checkout review as the top title, got %+v", summary.Titles)
+	}
+
+	if len(summary.Weeks) != ProjectTrendWeeks {
+		t.Fatalf("expected %d weeks, got %d", ProjectTrendWeeks, len(summary.Weeks))
+	}
+	last := summary.Weeks[len(summary.Weeks)-1]
+	previous := summary.Weeks[len(summary.Weeks)-2]
+	if !last.WeekStart.Equal(WeekStartOf(now)) || last.Total != time.Hour || previous.Total != 4*time.Hour {
+		t.Fatalf("unexpected weekly trend: %+v", summary.Weeks)
+	}
+}
+
+func TestTitleCategory(t *testing.T) {
+	tests := map[string]string{
+		"Review: checkout PR": "Review",
+		"Meeting with Bob":    "Meeting",
+		"  Bugfix  ":          "Bugfix",
+		": no prefix":         ":",
+		"":                    "(untitled)",
+	}
+	for title, want := range tests {
+		if got := TitleCategory(title); got != want {
+			t.Errorf("TitleCategory(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
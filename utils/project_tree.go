@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"time-tracker/models"
+)
+
+// ProjectPathSeparator joins the levels of a sub-project's path, e.g. "Acme/Website/Checkout"
+const ProjectPathSeparator = "/"
+
+// ProjectTree resolves projects to their ancestors through the parents they declare
+type ProjectTree struct {
+	byKey map[string]models.Project // Keyed by lowercase name
+}
+
+// NewProjectTree creates a tree of the given projects
+func NewProjectTree(projects []models.Project) *ProjectTree {
+	tree := &ProjectTree{byKey: make(map[string]models.Project, len(projects))}
+	for _, project := range projects {
+		tree.byKey[strings.ToLower(project.Name)] = project
+	}
+	return tree
+}
+
+// Path returns the names from the top-level ancestor down to the project itself
+func (t *ProjectTree) Path(name string) []string {
+	path := []string{name}
+	seen := map[string]bool{strings.ToLower(name): true}
+	for {
+		parent := strings.TrimSpace(t.byKey[strings.ToLower(path[0])].Parent)
+		key := strings.ToLower(parent)
+		if parent == "" || seen[key] {
+			return path
+		}
+		seen[key] = true
+		if project, ok := t.byKey[key]; ok {
+			parent = project.Name
+		}
+		path = append([]string{parent}, path...)
+	}
+}
+
+// Depth returns the number of levels of the deepest project path
+func (t *ProjectTree) Depth() int {
+	depth := 0
+	for _, project := range t.byKey {
+		depth = max(depth, len(t.Path(project.Name)))
+	}
+	return depth
+}
+
+// RollUp returns the path of the project's ancestor at depth, e.g. "Acme/Website" at depth 2
+func (t *ProjectTree) RollUp(name string, depth int) string {
+	if depth <= 0 || name == "" {
+		return name
+	}
+	return strings.Join(t.ancestorPath(name, depth), ProjectPathSeparator)
+}
+
+// ancestorPath returns the path of the project cut to the given depth
+func (t *ProjectTree) ancestorPath(name string, depth int) []string {
+	path := t.Path(name)
+	if len(path) > depth {
+		path = path[:depth]
+	}
+	return path
+}
+
+// RollUpTotals adds up durations per project under their ancestors at the given depth
+func RollUpTotals(totals map[string]time.Duration, tree *ProjectTree, depth int) map[string]time.Duration {
+	if depth <= 0 {
+		return totals
+	}
+	rolled := make(map[string]time.Duration, len(totals))
+	for name, duration := range totals {
+		rolled[tree.RollUp(name, depth)] += duration
+	}
+	return rolled
+}
+
+// RollUpProjectDates merges aggregated entries of sub-projects into their ancestors at depth
+func RollUpProjectDates(entries []ProjectDateEntry, tree *ProjectTree, depth int) []ProjectDateEntry {
+	if depth <= 0 {
+		return entries
+	}
+
+	byKey := make(map[string]*ProjectDateEntry)
+	var keys []string
+	for _, entry := range entries {
+		name := entry.Project
+		var ancestor string
+		if name != "" {
+			path := tree.ancestorPath(entry.Project, depth)
+			name = strings.Join(path, ProjectPathSeparator)
+			ancestor = path[len(path)-1]
+		}
+		key := entry.Date.Format("2006-01-02") + ":" + name
+		rolled, ok := byKey[key]
+		if !ok {
+			rolled = &ProjectDateEntry{Project: name, Date: entry.Date, Tasks: []string{}, Notes: []string{}}
+			if project, ok := tree.byKey[strings.ToLower(ancestor)]; ok {
+				rolled.ProjectCode = project.Code
+				rolled.ProjectCategory = project.Category
+				rolled.ProjectRateCents = project.HourlyRateCents
+				rolled.ProjectCurrency = project.Currency
+				rolled.ProjectBillable = project.Billable
+			}
+			byKey[key] = rolled
+			keys = append(keys, key)
+		}
+
+		rolled.Duration += entry.Duration
+		rolled.RawDuration += entry.RawDuration
+		for _, task := range entry.Tasks {
+			if !slices.Contains(rolled.Tasks, task) {
+				rolled.Tasks = append(rolled.Tasks, task)
+			}
+		}
+		for _, notes := range entry.Notes {
+			if !slices.Contains(rolled.Notes, notes) {
+				rolled.Notes = append(rolled.Notes, notes)
+			}
+		}
+	}
+
+	result := make([]ProjectDateEntry, 0, len(keys))
+	for _, key := range keys {
+		sort.Strings(byKey[key].Tasks)
+		result = append(result, *byKey[key])
+	}
+
+	// Same order as AggregateByProjectDate: by date, then project name
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].Date.Equal(result[j].Date) {
+			return result[i].Date.Before(result[j].Date)
+		}
+		return result[i].Project < result[j].Project
+	})
+	return result
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"time-tracker/models"
+)
+
+func newAcmeProjectTree() *ProjectTree {
+	return NewProjectTree([]models.Project{
+		{Name: "Acme", Code: "ACM", Category: "Client"},
+		{Name: "Website", Parent: "acme"},
+		{Name: "Checkout", Parent: "Website", Code: "CHK"},
+		{Name: "Internal"},
+	})
+}
+
+func TestProjectTree_PathAndRollUp(t *testing.T) {
+	tree := newAcmeProjectTree()
+
+	if got := tree.Path("Checkout"); len(got) != 3 || got[0] != "Acme" || got[1] != "Website" || got[2] != "Checkout" {
+		t.Fatalf("unexpected path: %v", got)
+	}
+	if got := tree.Depth(); got != 3 {
+		t.Fatalf("expected depth 3, got %d", got)
+	}
+
+	cases := []struct {
+		name  string
+		depth int
+		want  string
+	}{
+		{"Checkout", 0, "Checkout"},
+		{"Checkout", 1, "Acme"},
+		{"Checkout", 2, "Acme/Website"},
+		{"Checkout", 5, "Acme/Website/Checkout"},
+		{"Internal", 2, "Internal"},
+		{"Untracked", 1, "Untracked"},
+		{"", 1, ""},
+	}
+	for _, c := range cases {
+		if got := tree.RollUp(c.name, c.depth); got != c.want {
+			t.Errorf("RollUp(%q, %d) = %q, want %q", c.name, c.depth, got, c.want)
+		}
+	}
+}
+
+func TestProjectTree_PathStopsAtCycle(t *testing.T) {
+	tree := NewProjectTree([]models.Project{{Name: "A", Parent: "B"}, {Name: "B", Parent: "A"}})
+
+	if got := tree.Path("A"); len(got) != 2 || got[0] != "B" || got[1] != "A" {
+		t.Fatalf("unexpected path: %v", got)
+	}
+}
+
+func TestRollUpProjectDates_MergesSubProjectsPerDay(t *testing.T) {
+	tree := newAcmeProjectTree()
+	day := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+	entries := []ProjectDateEntry{
+		{Project: "Acme", Date: day, Duration: time.Hour, Tasks: []string{"Call"}},
+		{Project: "Checkout", Date: day, Duration: 2 * time.Hour, Tasks: []string{"Build"}, ProjectCode: "CHK"},
+		{Project: "Internal", Date: day, Duration: 30 * time.Minute},
+		{Project: "Website", Date: day.AddDate(0, 0, 1), Duration: time.Hour, Tasks: []string{"Call"}},
+	}
+
+	rolled := RollUpProjectDates(entries, tree, 1)
+	if len(rolled) != 3 {
+		t.Fatalf("expected 3 rolled-up entries, got %+v", rolled)
+	}
+	if rolled[0].Project != "Acme" || rolled[0].Duration != 3*time.Hour || rolled[0].ProjectCode != "ACM" ||
+		len(rolled[0].Tasks) != 2 || rolled[0].Tasks[0] != "Build" {
+		t.Fatalf("unexpected Acme entry: %+v", rolled[0])
+	}
+	if rolled[1].Project != "Internal" || rolled[2].Project != "Acme" || !rolled[2].Date.Equal(day.AddDate(0, 0, 1)) {
+		t.Fatalf("unexpected order: %+v", rolled)
+	}
+
+	rolled = RollUpProjectDates(entries, tree, 2)
+	if len(rolled) != 4 || rolled[1].Project != "Acme/Website" || rolled[1].ProjectCode != "" || rolled[1].Duration != 2*time.Hour {
+		t.Fatalf("unexpected entries at depth 2: %+v", rolled)
+	}
+}
+
+func TestRollUpTotals(t *testing.T) {
+	totals := map[string]time.Duration{"Checkout": time.Hour, "Website": time.Hour, "Internal": time.Hour}
+
+	rolled := RollUpTotals(totals, newAcmeProjectTree(), 1)
+	if len(rolled) != 2 || rolled["Acme"] != 2*time.Hour || rolled["Internal"] != time.Hour {
+		t.Fatalf("unexpected totals: %v", rolled)
+	}
+}
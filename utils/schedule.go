@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"time"
+
+	"time-tracker/models"
+)
+
+// Schedule is the expected working time per weekday, without public holidays and vacation days
+type Schedule struct {
+	Hours   map[time.Weekday]time.Duration
+	DaysOff map[string]bool // Dates as "2006-01-02"
+}
+
+// NewSchedule creates a schedule from the expected time per weekday and the days off
+func NewSchedule(hours map[time.Weekday]time.Duration, daysOff []time.Time) *Schedule {
+	schedule := &Schedule{Hours: hours, DaysOff: make(map[string]bool, len(daysOff))}
+	for _, day := range daysOff {
+		schedule.DaysOff[day.Format("2006-01-02")] = true
+	}
+	return schedule
+}
+
+// IsEmpty reports whether no working time is expected on any weekday
+func (s *Schedule) IsEmpty() bool {
+	for _, hours := range s.Hours {
+		if hours > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Target returns the working time expected on the day containing t
+func (s *Schedule) Target(t time.Time) time.Duration {
+	if s.DaysOff[t.Format("2006-01-02")] {
+		return 0
+	}
+	return s.Hours[t.Weekday()]
+}
+
+// TargetForDays returns the working time expected on the given number of days from first on
+func (s *Schedule) TargetForDays(first time.Time, days int) time.Duration {
+	var total time.Duration
+	for i := 0; i < days; i++ {
+		total += s.Target(first.AddDate(0, 0, i))
+	}
+	return total
+}
+
+// Balance compares the tracked time with the expected time over a span of days
+type Balance struct {
+	First    time.Time // First day counted
+	Last     time.Time // Last day counted
+	Tracked  time.Duration
+	Expected time.Duration
+}
+
+// Delta returns the overtime, or the undertime when negative
+func (b Balance) Delta() time.Duration {
+	return b.Tracked - b.Expected
+}
+
+// CalculateBalance compares the time tracked each day from first through last with the schedule
+func CalculateBalance(entries []models.TimeEntry, schedule *Schedule, first, last time.Time) Balance {
+	first = startOfDay(first)
+	last = startOfDay(last)
+	balance := Balance{First: first, Last: last}
+	if last.Before(first) {
+		return balance
+	}
+
+	for _, entry := range entries {
+		if entry.IsBlank() {
+			continue
+		}
+		day := startOfDay(entry.Start)
+		if !day.Before(first) && !day.After(last) {
+			balance.Tracked += entry.Duration()
+		}
+	}
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		balance.Expected += schedule.Target(day)
+	}
+	return balance
+}
+
+// FirstTrackedDay returns the day of the earliest non-blank entry, or false when there is none
+func FirstTrackedDay(entries []models.TimeEntry) (time.Time, bool) {
+	var first time.Time
+	found := false
+	for _, entry := range entries {
+		if !entry.IsBlank() && (!found || entry.Start.Before(first)) {
+			first = entry.Start
+			found = true
+		}
+	}
+	return startOfDay(first), found
+}
+
+// WeekStartOf returns the start of the week containing t, following the configured week start
+func WeekStartOf(t time.Time) time.Time {
+	return startOfDay(t).AddDate(0, 0, -weekStartOffset(t.Weekday()))
+}
+
+// FormatDelta formats an overtime or undertime with its sign, e.g. "+1h 30m" or "-45m"
+func FormatDelta(d time.Duration) string {
+	if d < 0 {
+		return "-" + FormatDuration(-d)
+	}
+	return "+" + FormatDuration(d)
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
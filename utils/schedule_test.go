@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"time-tracker/models"
+)
+
+func testSchedule() *Schedule {
+	hours := map[time.Weekday]time.Duration{
+		time.Monday: 8 * time.Hour, time.Tuesday: 8 * time.Hour, time.Wednesday: 8 * time.Hour,
+		time.Thursday: 8 * time.Hour, time.Friday: 6 * time.Hour,
+	}
+	// Wednesday 2026-03-18 is a holiday
+	return NewSchedule(hours, []time.Time{time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC)})
+}
+
+func TestSchedule_TargetSkipsWeekendsAndDaysOff(t *testing.T) {
+	schedule := testSchedule()
+	monday := time.Date(2026, 3, 16, 14, 0, 0, 0, time.UTC)
+
+	if got := schedule.Target(monday); got != 8*time.Hour {
+		t.Fatalf("expected 8h on Monday, got %v", got)
+	}
+	if got := schedule.Target(monday.AddDate(0, 0, 2)); got != 0 {
+		t.Fatalf("expected no hours on the holiday, got %v", got)
+	}
+	if got := schedule.TargetForDays(monday, 7); got != 30*time.Hour {
+		t.Fatalf("expected 30h in the week with a holiday, got %v", got)
+	}
+	if schedule.IsEmpty() || !NewSchedule(nil, nil).IsEmpty() {
+		t.Fatal("expected only a schedule without hours to be empty")
+	}
+}
+
+func TestCalculateBalance_ComparesTrackedWithExpectedDays(t *testing.T) {
+	monday := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	at := func(day, hour int) *time.Time {
+		t := monday.AddDate(0, 0, day).Add(time.Duration(hour) * time.Hour)
+		return &t
+	}
+	entries := []models.TimeEntry{
+		{Start: monday, End: at(0, 9), Project: "Acme", Title: "Build"},    // Monday 9h
+		{Start: *at(0, 9), End: at(1, 0)},                                  // gap
+		{Start: *at(1, 0), End: at(1, 7), Project: "Acme", Title: "Build"}, // Tuesday 7h
+		{Start: *at(1, 7), End: at(2, 0)},                                  // gap
+		{Start: *at(2, 0), End: at(2, 2), Project: "Acme", Title: "Build"}, // holiday 2h
+		{Start: *at(2, 2)},
+	}
+
+	balance := CalculateBalance(entries, testSchedule(), monday, monday.AddDate(0, 0, 2))
+	if balance.Tracked != 18*time.Hour || balance.Expected != 16*time.Hour || balance.Delta() != 2*time.Hour {
+		t.Fatalf("unexpected balance %+v", balance)
+	}
+	if FormatDelta(balance.Delta()) != "+2h" || FormatDelta(-90*time.Minute) != "-1h 30m" {
+		t.Fatalf("unexpected deltas %q and %q", FormatDelta(balance.Delta()), FormatDelta(-90*time.Minute))
+	}
+
+	if first, ok := FirstTrackedDay(entries); !ok || !first.Equal(time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected the first tracked day to be Monday, got %v (%v)", first, ok)
+	}
+}
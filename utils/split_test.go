@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+)
+
+func seedSplitEntries(t *testing.T, storage *MemoryStorage) time.Time {
+	t.Helper()
+
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Hour)
+	err := storage.Save([]models.TimeEntry{
+		{Start: start, End: &end, Project: "Acme", Title: "Build", Tags: []string{"dev"}, Notes: "compiled", Task: "1"},
+		{Start: end, Project: "Beta", Title: "Review"},
+	})
+	if err != nil {
+		t.Fatalf("failed to seed entries: %v", err)
+	}
+	return start
+}
+
+func TestSplitEntry_InsertsSecondHalf(t *testing.T) {
+	storage, tm := newHistoryTaskManager()
+	start := seedSplitEntries(t, storage)
+	at := start.Add(2 * time.Hour)
+
+	second, err := tm.SplitEntry(0, at, "", "")
+	if err != nil {
+		t.Fatalf("SplitEntry failed: %v", err)
+	}
+	if !second.Start.Equal(at) || second.Project != "Acme" || second.Title != "Build" || second.Task != "1" || second.Notes != "" {
+		t.Fatalf("unexpected second half %+v", second)
+	}
+
+	entries, _ := storage.Load()
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %+v", entries)
+	}
+	if entries[0].End == nil || !entries[0].End.Equal(at) || entries[0].Notes != "compiled" {
+		t.Fatalf("expected the first half to end at the split and keep the notes, got %+v", entries[0])
+	}
+	if entries[1].End == nil || !entries[1].End.Equal(start.Add(3*time.Hour)) || FormatTags(entries[1].Tags) != "dev" {
+		t.Fatalf("expected the second half to keep the end and tags, got %+v", entries[1])
+	}
+
+	record, err := tm.Undo()
+	if err != nil || record.Description != "split entry" {
+		t.Fatalf("expected to undo the split, got %+v (%v)", record, err)
+	}
+	entries, _ = storage.Load()
+	if len(entries) != 2 {
+		t.Fatalf("expected the split to be undone, got %+v", entries)
+	}
+}
+
+func TestSplitEntry_MovesSecondHalfInOneStep(t *testing.T) {
+	storage, tm := newHistoryTaskManager()
+	start := seedSplitEntries(t, storage)
+
+	second, err := tm.SplitEntry(0, start.Add(time.Hour), "Beta", "Review")
+	if err != nil {
+		t.Fatalf("SplitEntry failed: %v", err)
+	}
+	if second.Project != "Beta" || second.Title != "Review" || second.Task != "" {
+		t.Fatalf("expected the second half in the new project without the task, got %+v", second)
+	}
+
+	// A single undo restores the unsplit entry
+	if _, err := tm.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	entries, _ := storage.Load()
+	if len(entries) != 2 || entries[0].Project != "Acme" || entries[1].Project != "Beta" {
+		t.Fatalf("expected the split to be undone in one step, got %+v", entries)
+	}
+}
+
+func TestSplitEntry_RejectsInvalidTimes(t *testing.T) {
+	storage, tm := newHistoryTaskManager()
+	start := seedSplitEntries(t, storage)
+
+	cases := map[string]time.Time{
+		"after the start":  start,
+		"before the end":   start.Add(3 * time.Hour),
+		"is in the future": time.Now().Add(time.Hour),
+	}
+	for want, at := range cases {
+		idx := 0
+		if want == "is in the future" {
+			idx = 1
+		}
+		if _, err := tm.SplitEntry(idx, at, "", ""); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error containing %q, got %v", want, err)
+		}
+	}
+
+	if _, err := tm.SplitEntry(5, start, "", ""); err == nil || !strings.Contains(err.Error(), "invalid entry index") {
+		t.Errorf("expected invalid index error, got %v", err)
+	}
+}
+
+func TestSplitEntry_RejectsBlankEntry(t *testing.T) {
+	storage, tm := newHistoryTaskManager()
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	if err := storage.Save([]models.TimeEntry{{Start: start}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tm.SplitEntry(0, start.Add(time.Hour), "", ""); err == nil || !strings.Contains(err.Error(), "blank") {
+		t.Fatalf("expected blank entry error, got %v", err)
+	}
+}
+
+func TestEntryIndexAt(t *testing.T) {
+	storage := NewMemoryStorage()
+	start := seedSplitEntries(t, storage)
+	entries, _ := storage.Load()
+
+	if got := EntryIndexAt(entries, start.Add(time.Hour)); got != 0 {
+		t.Fatalf("expected entry 0, got %d", got)
+	}
+	if got := EntryIndexAt(entries, start.Add(5*time.Hour)); got != 1 {
+		t.Fatalf("expected the running entry, got %d", got)
+	}
+	if got := EntryIndexAt(entries, start.Add(-time.Hour)); got != -1 {
+		t.Fatalf("expected no entry before the first start, got %d", got)
+	}
+}
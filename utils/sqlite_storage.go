@@ -0,0 +1,677 @@
+package utils
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"time-tracker/models"
+
+	_ "modernc.org/sqlite"
+)
+
+// sqliteSchemaVersion is stored in PRAGMA user_version and needs incremented when the schema changes
+const sqliteSchemaVersion = 9
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS time_entries (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	start      TEXT    NOT NULL,
+	start_unix INTEGER NOT NULL,
+	project    TEXT    NOT NULL DEFAULT '',
+	title      TEXT    NOT NULL DEFAULT '',
+	tags       TEXT    NOT NULL DEFAULT '',
+	notes      TEXT    NOT NULL DEFAULT '',
+	task       TEXT    NOT NULL DEFAULT '',
+	entry_id   TEXT    NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS idx_time_entries_start_unix ON time_entries (start_unix);
+CREATE INDEX IF NOT EXISTS idx_time_entries_project ON time_entries (project);
+CREATE INDEX IF NOT EXISTS idx_time_entries_entry_id ON time_entries (entry_id);
+
+CREATE TABLE IF NOT EXISTS projects (
+	name        TEXT    NOT NULL PRIMARY KEY COLLATE NOCASE,
+	code        TEXT    NOT NULL DEFAULT '',
+	category    TEXT    NOT NULL DEFAULT '',
+	currency    TEXT    NOT NULL DEFAULT '',
+	billable    INTEGER NOT NULL DEFAULT 0,
+	archived    INTEGER NOT NULL DEFAULT 0,
+	parent       TEXT    NOT NULL DEFAULT '',
+	budget_hours REAL    NOT NULL DEFAULT 0,
+	budget_from  TEXT    NOT NULL DEFAULT '',
+	budget_to    TEXT    NOT NULL DEFAULT '',
+	aliases      TEXT    NOT NULL DEFAULT '',
+	hourly_rate_cents INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS tasks (
+	id        TEXT NOT NULL PRIMARY KEY,
+	project   TEXT NOT NULL DEFAULT '',
+	name      TEXT NOT NULL DEFAULT '',
+	created   TEXT NOT NULL,
+	completed TEXT NOT NULL DEFAULT ''
+);
+
+-- Counts the saves that changed anything, so a save can tell whether another process saved since
+CREATE TABLE IF NOT EXISTS revision (
+	id    INTEGER PRIMARY KEY CHECK (id = 1),
+	value INTEGER NOT NULL
+);
+INSERT OR IGNORE INTO revision (id, value) VALUES (1, 0);
+`
+
+// sqliteMigrations upgrade a database from the schema version at their index + 1
+var sqliteMigrations = []string{
+	// 1 -> 2: entries reference tasks
+	`ALTER TABLE time_entries ADD COLUMN task TEXT NOT NULL DEFAULT ''`,
+	// 2 -> 3: entries have stable IDs, assigned on load until they are saved
+	`ALTER TABLE time_entries ADD COLUMN entry_id TEXT NOT NULL DEFAULT ''`,
+	// 3 -> 4: projects can be archived
+	`ALTER TABLE projects ADD COLUMN archived INTEGER NOT NULL DEFAULT 0`,
+	// 4 -> 5: projects can have a parent project
+	`ALTER TABLE projects ADD COLUMN parent TEXT NOT NULL DEFAULT ''`,
+	// 5 -> 6: projects can have an hour budget over an optional date window
+	`ALTER TABLE projects ADD COLUMN budget_hours REAL NOT NULL DEFAULT 0;
+	ALTER TABLE projects ADD COLUMN budget_from TEXT NOT NULL DEFAULT '';
+	ALTER TABLE projects ADD COLUMN budget_to TEXT NOT NULL DEFAULT ''`,
+	// 6 -> 7: projects can have aliases to start them by
+	`ALTER TABLE projects ADD COLUMN aliases TEXT NOT NULL DEFAULT ''`,
+	// 7 -> 8: saves look entries up by ID to write only the changed ones
+	`CREATE INDEX IF NOT EXISTS idx_time_entries_entry_id ON time_entries (entry_id)`,
+	// 8 -> 9: hourly rates are stored in cents
+	`ALTER TABLE projects ADD COLUMN hourly_rate_cents INTEGER NOT NULL DEFAULT 0;
+	UPDATE projects SET hourly_rate_cents = CAST(ROUND(hourly_rate * 100) AS INTEGER);
+	ALTER TABLE projects DROP COLUMN hourly_rate`,
+}
+
+// SQLiteStorage implements Storage using a SQLite database
+type SQLiteStorage struct {
+	FilePath string
+	db       *sql.DB
+
+	loadedRevision int64 // Revision of the baseline, see Storage
+	hasBaseline    bool
+}
+
+// sqliteQuerier runs queries on the database or in a transaction
+type sqliteQuerier interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// NewSQLiteStorage opens the SQLite database at filePath, creating it and its schema if needed
+func NewSQLiteStorage(filePath string) (*SQLiteStorage, error) {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	if info, err := os.Stat(filePath); err == nil && info.IsDir() {
+		return nil, errors.New("provided path must be a file, not a directory")
+	}
+
+	// Transactions take the write lock when they begin, so a save cannot be overtaken between
+	// checking the revision and writing
+	db, err := sql.Open("sqlite", filePath+"?_txlock=immediate")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	// A single connection keeps the per-connection pragmas below in effect for every query
+	db.SetMaxOpenConns(1)
+
+	storage := &SQLiteStorage{FilePath: filePath, db: db}
+	if err := storage.init(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return storage, nil
+}
+
+func (s *SQLiteStorage) init() error {
+	if _, err := s.db.Exec("PRAGMA busy_timeout = 5000"); err != nil {
+		return fmt.Errorf("failed to configure database: %w", err)
+	}
+
+	// Every migration runs in one transaction, so a failed upgrade leaves the previous schema intact
+	return s.inTransaction(func(tx *sql.Tx) error {
+		var version int
+		if err := tx.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+			return fmt.Errorf("failed to read database schema version: %w", err)
+		}
+		if version > sqliteSchemaVersion {
+			return fmt.Errorf("unknown database schema version: %d", version)
+		}
+
+		if version > 0 {
+			for v := version; v < sqliteSchemaVersion; v++ {
+				if _, err := tx.Exec(sqliteMigrations[v-1]); err != nil {
+					return fmt.Errorf("failed to migrate database schema from version %d: %w", v, err)
+				}
+			}
+		}
+		if _, err := tx.Exec(sqliteSchema); err != nil {
+			return fmt.Errorf("failed to create database schema: %w", err)
+		}
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", sqliteSchemaVersion)); err != nil {
+			return fmt.Errorf("failed to set database schema version: %w", err)
+		}
+		return nil
+	})
+}
+
+// Close releases the database handle
+func (s *SQLiteStorage) Close() error {
+	return s.db.Close()
+}
+
+// ResetBaseline makes the next load the baseline of later saves, see Storage
+func (s *SQLiteStorage) ResetBaseline() {
+	s.hasBaseline = false
+}
+
+// remember takes the current revision, read before the data, as the baseline unless there is one
+func (s *SQLiteStorage) remember() error {
+	if s.hasBaseline {
+		return nil
+	}
+	if err := s.db.QueryRow("SELECT value FROM revision WHERE id = 1").Scan(&s.loadedRevision); err != nil {
+		return fmt.Errorf("failed to read database revision: %w", err)
+	}
+	s.hasBaseline = true
+	return nil
+}
+
+// update runs save in a transaction if nobody saved since the baseline, counting changed revisions
+func (s *SQLiteStorage) update(save func(tx *sql.Tx) (bool, error)) error {
+	var revision int64
+	err := s.inTransaction(func(tx *sql.Tx) error {
+		if err := tx.QueryRow("SELECT value FROM revision WHERE id = 1").Scan(&revision); err != nil {
+			return fmt.Errorf("failed to read database revision: %w", err)
+		}
+		if s.hasBaseline && revision != s.loadedRevision {
+			s.hasBaseline = false
+			return &ConflictError{FilePath: s.FilePath}
+		}
+
+		changed, err := save(tx)
+		if err != nil || !changed {
+			return err
+		}
+		revision++
+		if _, err := tx.Exec("UPDATE revision SET value = ? WHERE id = 1", revision); err != nil {
+			return fmt.Errorf("failed to update database revision: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.loadedRevision, s.hasBaseline = revision, true
+	return nil
+}
+
+const sqliteEntryColumns = "entry_id, start, project, title, tags, notes, task"
+
+func (s *SQLiteStorage) Load() ([]models.TimeEntry, error) {
+	if err := s.remember(); err != nil {
+		return nil, err
+	}
+
+	entries, err := queryEntries(s.db, "SELECT "+sqliteEntryColumns+" FROM time_entries ORDER BY start_unix, id")
+	if err != nil {
+		return nil, err
+	}
+	assignEntryIDs(entries)
+
+	// Reconstruct End times from next entry's Start time, same as FileStorage
+	for i := 0; i < len(entries)-1; i++ {
+		next := entries[i+1].Start
+		entries[i].End = &next
+	}
+
+	return entries, nil
+}
+
+// LoadRange returns the entries overlapping [from, to), reading only their rows
+func (s *SQLiteStorage) LoadRange(from, to time.Time) ([]models.TimeEntry, error) {
+	if err := s.remember(); err != nil {
+		return nil, err
+	}
+
+	entries, err := queryEntries(s.db, "SELECT "+sqliteEntryColumns+` FROM time_entries
+		WHERE start_unix >= COALESCE((SELECT MAX(start_unix) FROM time_entries WHERE start_unix <= ?1), ?1)
+		AND start_unix <= COALESCE((SELECT MIN(start_unix) FROM time_entries WHERE start_unix >= ?2), ?2)
+		ORDER BY start_unix, id`, from.UnixNano(), to.UnixNano())
+	if err != nil {
+		return nil, err
+	}
+	assignEntryIDs(entries)
+
+	for i := 0; i < len(entries)-1; i++ {
+		next := entries[i+1].Start
+		entries[i].End = &next
+	}
+	for len(entries) > 0 && !entries[len(entries)-1].Start.Before(to) {
+		entries = entries[:len(entries)-1]
+	}
+
+	return entries, nil
+}
+
+func queryEntries(q sqliteQuerier, query string, args ...any) ([]models.TimeEntry, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []models.TimeEntry{}
+	for rows.Next() {
+		var start, tags string
+		var entry models.TimeEntry
+		if err := rows.Scan(&entry.ID, &start, &entry.Project, &entry.Title, &tags, &entry.Notes, &entry.Task); err != nil {
+			return nil, fmt.Errorf("failed to read entry: %w", err)
+		}
+
+		entry.Start, err = time.Parse(time.RFC3339Nano, start)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse entry start %q: %w", start, err)
+		}
+
+		if tags != "" {
+			if err := json.Unmarshal([]byte(tags), &entry.Tags); err != nil {
+				return nil, fmt.Errorf("failed to parse entry tags: %w", err)
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read entries: %w", err)
+	}
+	return entries, nil
+}
+
+// Save writes only the entries that were added, changed, or removed, matched by ID
+func (s *SQLiteStorage) Save(entries []models.TimeEntry) error {
+	return s.update(func(tx *sql.Tx) (bool, error) {
+		return saveSQLiteEntries(tx, entries)
+	})
+}
+
+// saveSQLiteEntries writes the changed entries in tx and reports whether there were any
+func saveSQLiteEntries(tx *sql.Tx, entries []models.TimeEntry) (bool, error) {
+	saved := toSortedV9Entries(entries)
+
+	stored, err := queryEntries(tx, "SELECT "+sqliteEntryColumns+" FROM time_entries WHERE entry_id != ''")
+	if err != nil {
+		return false, err
+	}
+	current := entriesByID(toSortedV9Entries(stored))
+	next := entriesByID(saved)
+
+	// Rows stored before IDs existed are replaced by the same entries under their IDs
+	result, err := tx.Exec("DELETE FROM time_entries WHERE entry_id = ''")
+	if err != nil {
+		return false, fmt.Errorf("failed to delete entries without ID: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete entries without ID: %w", err)
+	}
+	changed := deleted > 0
+
+	for _, id := range sortedKeys(current) {
+		if _, ok := next[id]; ok {
+			continue
+		}
+		if _, err := tx.Exec("DELETE FROM time_entries WHERE entry_id = ?", id); err != nil {
+			return false, fmt.Errorf("failed to delete entry %s: %w", id, err)
+		}
+		changed = true
+	}
+
+	for _, entry := range saved {
+		previous, ok := current[entry.ID]
+		if ok && sameV9Entry(previous, entry) {
+			continue
+		}
+
+		tags := ""
+		if len(entry.Tags) > 0 {
+			encoded, err := json.Marshal(entry.Tags)
+			if err != nil {
+				return false, fmt.Errorf("failed to encode entry tags: %w", err)
+			}
+			tags = string(encoded)
+		}
+
+		query := "INSERT INTO time_entries (start, start_unix, project, title, tags, notes, task, entry_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+		if ok {
+			query = "UPDATE time_entries SET start = ?, start_unix = ?, project = ?, title = ?, tags = ?, notes = ?, task = ? WHERE entry_id = ?"
+		}
+		if _, err := tx.Exec(query,
+			entry.Start.Format(time.RFC3339Nano),
+			entry.Start.UnixNano(),
+			entry.Project,
+			entry.Title,
+			tags,
+			entry.Notes,
+			entry.Task,
+			entry.ID,
+		); err != nil {
+			return false, fmt.Errorf("failed to save entry %s: %w", entry.ID, err)
+		}
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// LoadStoredProjects returns the saved projects, without the ones only named by entries
+func (s *SQLiteStorage) LoadStoredProjects() ([]models.Project, error) {
+	if err := s.remember(); err != nil {
+		return nil, err
+	}
+	return queryStoredProjects(s.db)
+}
+
+func queryStoredProjects(q sqliteQuerier) ([]models.Project, error) {
+	rows, err := q.Query("SELECT name, code, category, hourly_rate_cents, currency, billable, archived, parent, budget_hours, budget_from, budget_to, aliases FROM projects")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query projects: %w", err)
+	}
+	defer rows.Close()
+
+	projects := []models.Project{}
+	for rows.Next() {
+		var project models.Project
+		var budgetFrom, budgetTo, aliases string
+		if err := rows.Scan(&project.Name, &project.Code, &project.Category, &project.HourlyRateCents, &project.Currency, &project.Billable, &project.Archived, &project.Parent,
+			&project.Budget, &budgetFrom, &budgetTo, &aliases); err != nil {
+			return nil, fmt.Errorf("failed to read project: %w", err)
+		}
+		if project.BudgetFrom, err = parseOptionalTime(budgetFrom); err != nil {
+			return nil, fmt.Errorf("failed to parse budget start of project %q: %w", project.Name, err)
+		}
+		if project.BudgetTo, err = parseOptionalTime(budgetTo); err != nil {
+			return nil, fmt.Errorf("failed to parse budget end of project %q: %w", project.Name, err)
+		}
+		if aliases != "" {
+			if err := json.Unmarshal([]byte(aliases), &project.Aliases); err != nil {
+				return nil, fmt.Errorf("failed to parse aliases of project %q: %w", project.Name, err)
+			}
+		}
+		projects = append(projects, project)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read projects: %w", err)
+	}
+	return normalizeProjects(projects), nil
+}
+
+func (s *SQLiteStorage) LoadProjects() ([]models.Project, error) {
+	projects, err := s.LoadStoredProjects()
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]struct{}, len(projects))
+	for _, project := range projects {
+		byName[project.Name] = struct{}{}
+	}
+
+	// Projects referenced only by entries are listed too, without loading every entry
+	entryRows, err := s.db.Query("SELECT DISTINCT project FROM time_entries WHERE project != '' ORDER BY project")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query entry projects: %w", err)
+	}
+	defer entryRows.Close()
+
+	for entryRows.Next() {
+		var name string
+		if err := entryRows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to read entry project: %w", err)
+		}
+		projectName := strings.TrimSpace(name)
+		if projectName == "" {
+			continue
+		}
+		if _, ok := byName[projectName]; ok {
+			continue
+		}
+		projects = append(projects, models.Project{Name: projectName})
+		byName[projectName] = struct{}{}
+	}
+	if err := entryRows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read entry projects: %w", err)
+	}
+
+	return normalizeProjects(projects), nil
+}
+
+// SaveProjects writes only the projects that were added, changed, or removed
+func (s *SQLiteStorage) SaveProjects(projects []models.Project) error {
+	return s.update(func(tx *sql.Tx) (bool, error) {
+		return saveSQLiteProjects(tx, projects)
+	})
+}
+
+// saveSQLiteProjects writes the changed projects in tx and reports whether there were any
+func saveSQLiteProjects(tx *sql.Tx, projects []models.Project) (bool, error) {
+	saved := toV14Projects(normalizeProjects(projects))
+
+	stored, err := queryStoredProjects(tx)
+	if err != nil {
+		return false, err
+	}
+	current := make(map[string]models.V14Project, len(stored))
+	for _, project := range toV14Projects(stored) {
+		current[strings.ToLower(project.Name)] = project
+	}
+	next := make(map[string]models.V14Project, len(saved))
+	for _, project := range saved {
+		next[strings.ToLower(project.Name)] = project
+	}
+
+	changed := false
+	for _, key := range sortedKeys(current) {
+		if _, ok := next[key]; ok {
+			continue
+		}
+		if _, err := tx.Exec("DELETE FROM projects WHERE name = ?", current[key].Name); err != nil {
+			return false, fmt.Errorf("failed to delete project %q: %w", current[key].Name, err)
+		}
+		changed = true
+	}
+
+	// Names are unique regardless of case, so a project renamed to another case is updated
+	for _, project := range saved {
+		if previous, ok := current[strings.ToLower(project.Name)]; ok && sameV14Project(previous, project) {
+			continue
+		}
+
+		aliases := ""
+		if len(project.Aliases) > 0 {
+			encoded, err := json.Marshal(project.Aliases)
+			if err != nil {
+				return false, fmt.Errorf("failed to encode aliases of project %q: %w", project.Name, err)
+			}
+			aliases = string(encoded)
+		}
+		if _, err := tx.Exec(`INSERT INTO projects (name, code, category, hourly_rate_cents, currency, billable, archived, parent, budget_hours, budget_from, budget_to, aliases)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (name) DO UPDATE SET name = excluded.name, code = excluded.code, category = excluded.category,
+			hourly_rate_cents = excluded.hourly_rate_cents, currency = excluded.currency, billable = excluded.billable,
+			archived = excluded.archived, parent = excluded.parent, budget_hours = excluded.budget_hours,
+			budget_from = excluded.budget_from, budget_to = excluded.budget_to, aliases = excluded.aliases`,
+			project.Name, project.Code, project.Category, project.HourlyRateCents, project.Currency, project.Billable, project.Archived, project.Parent,
+			project.Budget, formatOptionalTime(project.BudgetFrom), formatOptionalTime(project.BudgetTo), aliases); err != nil {
+			return false, fmt.Errorf("failed to save project %q: %w", project.Name, err)
+		}
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// formatOptionalTime stores an unset time as an empty string
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (s *SQLiteStorage) LoadTasks() ([]models.Task, error) {
+	if err := s.remember(); err != nil {
+		return nil, err
+	}
+	tasks, err := queryTasks(s.db)
+	if err != nil {
+		return nil, err
+	}
+	return sortTasks(tasks), nil
+}
+
+func queryTasks(q sqliteQuerier) ([]models.Task, error) {
+	rows, err := q.Query("SELECT id, project, name, created, completed FROM tasks")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := []models.Task{}
+	for rows.Next() {
+		var task models.Task
+		var created, completed string
+		if err := rows.Scan(&task.ID, &task.Project, &task.Name, &created, &completed); err != nil {
+			return nil, fmt.Errorf("failed to read task: %w", err)
+		}
+
+		task.Created, err = time.Parse(time.RFC3339Nano, created)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse task created time %q: %w", created, err)
+		}
+		if completed != "" {
+			completedAt, err := time.Parse(time.RFC3339Nano, completed)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse task completed time %q: %w", completed, err)
+			}
+			task.Completed = &completedAt
+		}
+
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read tasks: %w", err)
+	}
+	return tasks, nil
+}
+
+// SaveTasks writes only the tasks that were added, changed, or removed
+func (s *SQLiteStorage) SaveTasks(tasks []models.Task) error {
+	return s.update(func(tx *sql.Tx) (bool, error) {
+		return saveSQLiteTasks(tx, tasks)
+	})
+}
+
+// saveSQLiteTasks writes the changed tasks in tx and reports whether there were any
+func saveSQLiteTasks(tx *sql.Tx, tasks []models.Task) (bool, error) {
+	saved := toV8Tasks(tasks)
+
+	stored, err := queryTasks(tx)
+	if err != nil {
+		return false, err
+	}
+	current := make(map[string]models.V8Task, len(stored))
+	for _, task := range toV8Tasks(stored) {
+		current[task.ID] = task
+	}
+	next := make(map[string]models.V8Task, len(saved))
+	for _, task := range saved {
+		next[task.ID] = task
+	}
+
+	changed := false
+	for _, id := range sortedKeys(current) {
+		if _, ok := next[id]; ok {
+			continue
+		}
+		if _, err := tx.Exec("DELETE FROM tasks WHERE id = ?", id); err != nil {
+			return false, fmt.Errorf("failed to delete task %s: %w", id, err)
+		}
+		changed = true
+	}
+
+	for _, task := range saved {
+		if previous, ok := current[task.ID]; ok && sameV8Task(previous, task) {
+			continue
+		}
+
+		completed := ""
+		if task.Completed != nil {
+			completed = task.Completed.Format(time.RFC3339Nano)
+		}
+		if _, err := tx.Exec(`INSERT INTO tasks (id, project, name, created, completed) VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET project = excluded.project, name = excluded.name,
+			created = excluded.created, completed = excluded.completed`,
+			task.ID, task.Project, task.Name, task.Created.Format(time.RFC3339Nano), completed); err != nil {
+			return false, fmt.Errorf("failed to save task %s: %w", task.ID, err)
+		}
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// SaveAll writes the changed entries, projects, and tasks in one transaction
+func (s *SQLiteStorage) SaveAll(entries []models.TimeEntry, projects []models.Project, tasks []models.Task) error {
+	return s.update(func(tx *sql.Tx) (bool, error) {
+		entriesChanged, err := saveSQLiteEntries(tx, entries)
+		if err != nil {
+			return false, err
+		}
+		projectsChanged, err := saveSQLiteProjects(tx, projects)
+		if err != nil {
+			return false, err
+		}
+		tasksChanged, err := saveSQLiteTasks(tx, tasks)
+		if err != nil {
+			return false, err
+		}
+		return entriesChanged || projectsChanged || tasksChanged, nil
+	})
+}
+
+func (s *SQLiteStorage) inTransaction(fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,508 @@
+package utils
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+)
+
+func newTestSQLiteStorage(t *testing.T) *SQLiteStorage {
+	t.Helper()
+
+	storage, err := NewSQLiteStorage(filepath.Join(t.TempDir(), "data.db"))
+	if err != nil {
+		t.Fatalf("Failed to create SQLite storage: %v", err)
+	}
+	t.Cleanup(func() { _ = storage.Close() })
+	return storage
+}
+
+func TestSQLiteStorage_SaveAndLoadEntries(t *testing.T) {
+	storage := newTestSQLiteStorage(t)
+
+	nineAM := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	tenAM := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)
+	elevenAM := time.Date(2026, 3, 16, 11, 0, 0, 0, time.UTC)
+
+	// Saved out of order to verify entries are loaded sorted by start time
+	if err := storage.Save([]models.TimeEntry{
+		{ID: "c3333", Start: elevenAM, Project: "Beta", Title: "Docs"},
+		{ID: "a1111", Start: nineAM, Project: "Alpha", Title: "Build", Tags: []string{"deep-work", "review"}, Notes: "Line 1\nLine 2"},
+		{ID: "b2222", Start: tenAM},
+	}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	entries, err := storage.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	want := []models.TimeEntry{
+		{ID: "a1111", Start: nineAM, End: &tenAM, Project: "Alpha", Title: "Build", Tags: []string{"deep-work", "review"}, Notes: "Line 1\nLine 2"},
+		{ID: "b2222", Start: tenAM, End: &elevenAM},
+		{ID: "c3333", Start: elevenAM, Project: "Beta", Title: "Docs"},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Fatalf("Expected %+v, got %+v", want, entries)
+	}
+}
+
+func TestSQLiteStorage_SaveReplacesEntries(t *testing.T) {
+	storage := newTestSQLiteStorage(t)
+
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	if err := storage.Save([]models.TimeEntry{{Start: start, Project: "Alpha", Title: "Build"}}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if err := storage.Save([]models.TimeEntry{{Start: start, Project: "Beta", Title: "Docs"}}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	entries, err := storage.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Project != "Beta" {
+		t.Fatalf("Expected only the latest saved entry, got %+v", entries)
+	}
+}
+
+func TestSQLiteStorage_SaveWritesOnlyChangedRows(t *testing.T) {
+	storage := newTestSQLiteStorage(t)
+
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	entries := []models.TimeEntry{
+		{ID: "a1111", Start: start, Project: "Alpha", Title: "Build"},
+		{ID: "b2222", Start: start.Add(time.Hour), Project: "Beta", Title: "Docs"},
+		{ID: "c3333", Start: start.Add(2 * time.Hour), Project: "Gamma", Title: "Review"},
+	}
+	if err := storage.Save(entries); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	rowIDs := func() map[string]int64 {
+		rows, err := storage.db.Query("SELECT entry_id, id FROM time_entries")
+		if err != nil {
+			t.Fatalf("Failed to query rows: %v", err)
+		}
+		defer rows.Close()
+		ids := make(map[string]int64)
+		for rows.Next() {
+			var entryID string
+			var id int64
+			if err := rows.Scan(&entryID, &id); err != nil {
+				t.Fatalf("Failed to read row: %v", err)
+			}
+			ids[entryID] = id
+		}
+		return ids
+	}
+	before := rowIDs()
+
+	entries[1].Title = "Docs review"
+	if err := storage.Save(entries[1:]); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	after := rowIDs()
+	if _, ok := after["a1111"]; ok || len(after) != 2 {
+		t.Fatalf("Expected the first entry to be deleted, got rows %v", after)
+	}
+	if after["b2222"] != before["b2222"] || after["c3333"] != before["c3333"] {
+		t.Fatalf("Expected the other rows to be kept in place, got %v before and %v after", before, after)
+	}
+	loaded, err := storage.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(loaded) != 2 || loaded[0].Title != "Docs review" {
+		t.Fatalf("Expected the updated entry, got %+v", loaded)
+	}
+}
+
+func TestSQLiteStorage_SaveDetectsConcurrentModification(t *testing.T) {
+	first := newTestSQLiteStorage(t)
+	second, err := NewSQLiteStorage(first.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to open SQLite storage: %v", err)
+	}
+	defer second.Close()
+
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	if _, err := first.Load(); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if err := second.Save([]models.TimeEntry{{Start: start, Project: "Other", Title: "Shell"}}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	err = first.SaveProjects([]models.Project{{Name: "Stale"}})
+	if !IsConflict(err) {
+		t.Fatalf("Expected conflict error, got: %v", err)
+	}
+
+	// After reloading, saving succeeds again
+	if _, err := first.LoadProjects(); err != nil {
+		t.Fatalf("LoadProjects returned error: %v", err)
+	}
+	if err := first.SaveProjects([]models.Project{{Name: "Fresh"}}); err != nil {
+		t.Fatalf("Expected save after reload to succeed, got: %v", err)
+	}
+}
+
+func TestSQLiteStorage_LoadRangeReadsOverlappingEntries(t *testing.T) {
+	storage := newTestSQLiteStorage(t)
+
+	at := func(hour int) time.Time { return time.Date(2026, 3, 16, hour, 0, 0, 0, time.UTC) }
+	var entries []models.TimeEntry
+	for hour := 8; hour <= 12; hour++ {
+		entries = append(entries, models.TimeEntry{Start: at(hour), Project: "Alpha", Title: fmt.Sprintf("%d:00", hour)})
+	}
+	if err := storage.Save(entries); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	loaded, err := storage.LoadRange(at(9).Add(30*time.Minute), at(11))
+	if err != nil {
+		t.Fatalf("LoadRange returned error: %v", err)
+	}
+	if len(loaded) != 2 || loaded[0].Title != "9:00" || loaded[1].Title != "10:00" {
+		t.Fatalf("Expected the entries from 9:00 to 11:00, got %+v", loaded)
+	}
+	if loaded[1].End == nil || !loaded[1].End.Equal(at(11)) {
+		t.Fatalf("Expected the last entry to end when the next one starts, got %+v", loaded[1])
+	}
+
+	// The running entry has no end, and a range before any entry is empty
+	if loaded, err := storage.LoadRange(at(12), at(13)); err != nil || len(loaded) != 1 || loaded[0].End != nil {
+		t.Fatalf("Expected the running entry, got %+v (%v)", loaded, err)
+	}
+	if loaded, err := storage.LoadRange(at(6), at(7)); err != nil || len(loaded) != 0 {
+		t.Fatalf("Expected no entries, got %+v (%v)", loaded, err)
+	}
+}
+
+func TestSQLiteStorage_PreservesTimeZoneOffsets(t *testing.T) {
+	storage := newTestSQLiteStorage(t)
+
+	start := time.Date(2026, 3, 16, 9, 0, 0, 123, time.FixedZone("EST", -5*60*60))
+	if err := storage.Save([]models.TimeEntry{{Start: start, Project: "Alpha", Title: "Build"}}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	entries, err := storage.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !entries[0].Start.Equal(start) {
+		t.Fatalf("Expected start %v, got %v", start, entries[0].Start)
+	}
+	if _, offset := entries[0].Start.Zone(); offset != -5*60*60 {
+		t.Fatalf("Expected offset to be preserved, got %d", offset)
+	}
+}
+
+func TestSQLiteStorage_SaveAndLoadProjects(t *testing.T) {
+	storage := newTestSQLiteStorage(t)
+
+	if err := storage.Save([]models.TimeEntry{
+		{Start: time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC), Project: "Gamma", Title: "Support"},
+		{Start: time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC), Project: "acme", Title: "Build"},
+	}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	wantProjects := []models.Project{
+		{Name: "Acme", Code: "ACM", Category: "Client", HourlyRateCents: 9550, Currency: "EUR", Billable: true, Aliases: []string{"acme-web", "aw"}},
+		{Name: "Internal", Code: "INT", Category: "Ops", Archived: true, Parent: "Acme", Budget: 40,
+			BudgetFrom: timePtr(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)), BudgetTo: timePtr(time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC))},
+	}
+	if err := storage.SaveProjects(wantProjects); err != nil {
+		t.Fatalf("SaveProjects returned error: %v", err)
+	}
+
+	projects, err := storage.LoadProjects()
+	if err != nil {
+		t.Fatalf("LoadProjects returned error: %v", err)
+	}
+
+	want := []models.Project{wantProjects[0], {Name: "Gamma"}, wantProjects[1]}
+	if !reflect.DeepEqual(projects, want) {
+		t.Fatalf("Expected %+v, got %+v", want, projects)
+	}
+}
+
+func TestSQLiteStorage_PersistsAcrossReopen(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "data.db")
+
+	storage, err := NewSQLiteStorage(dbFile)
+	if err != nil {
+		t.Fatalf("Failed to create SQLite storage: %v", err)
+	}
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	if err := storage.Save([]models.TimeEntry{{Start: start, Project: "Alpha", Title: "Build"}}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if err := storage.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	reopened, err := NewSQLiteStorage(dbFile)
+	if err != nil {
+		t.Fatalf("Failed to reopen SQLite storage: %v", err)
+	}
+	defer reopened.Close()
+
+	entries, err := reopened.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Title != "Build" {
+		t.Fatalf("Expected persisted entry, got %+v", entries)
+	}
+}
+
+func TestSQLiteStorage_SaveAndLoadTasks(t *testing.T) {
+	storage := newTestSQLiteStorage(t)
+
+	created := time.Date(2026, 3, 16, 8, 0, 0, 0, time.UTC)
+	completed := created.Add(2 * time.Hour)
+	want := []models.Task{
+		{ID: "1", Project: "Alpha", Name: "Build", Created: created},
+		{ID: "2", Project: "Alpha", Name: "Ship", Created: created, Completed: &completed},
+	}
+	if err := storage.SaveTasks([]models.Task{want[1], want[0]}); err != nil {
+		t.Fatalf("SaveTasks returned error: %v", err)
+	}
+	if err := storage.Save([]models.TimeEntry{{Start: created, Project: "Alpha", Title: "Build", Task: "1"}}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	tasks, err := storage.LoadTasks()
+	if err != nil {
+		t.Fatalf("LoadTasks returned error: %v", err)
+	}
+	if !reflect.DeepEqual(tasks, want) {
+		t.Fatalf("Expected %+v, got %+v", want, tasks)
+	}
+
+	entries, err := storage.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Task != "1" {
+		t.Fatalf("Expected entry task reference to round-trip, got %+v", entries)
+	}
+}
+
+func TestSQLiteStorage_MigratesVersion1Schema(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "data.db")
+
+	db, err := sql.Open("sqlite", dbFile)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	for _, statement := range []string{
+		`CREATE TABLE time_entries (
+			id INTEGER PRIMARY KEY AUTOINCREMENT, start TEXT NOT NULL, start_unix INTEGER NOT NULL,
+			project TEXT NOT NULL DEFAULT '', title TEXT NOT NULL DEFAULT '',
+			tags TEXT NOT NULL DEFAULT '', notes TEXT NOT NULL DEFAULT '')`,
+		`CREATE TABLE projects (
+			name TEXT NOT NULL PRIMARY KEY COLLATE NOCASE, code TEXT NOT NULL DEFAULT '',
+			category TEXT NOT NULL DEFAULT '', hourly_rate REAL NOT NULL DEFAULT 0,
+			currency TEXT NOT NULL DEFAULT '', billable INTEGER NOT NULL DEFAULT 0)`,
+		`INSERT INTO time_entries (start, start_unix, project, title) VALUES ('2026-03-16T09:00:00Z', 0, 'Alpha', 'Build')`,
+		`INSERT INTO projects (name, code, hourly_rate) VALUES ('Alpha', 'ALP', 95.35)`,
+		`PRAGMA user_version = 1`,
+	} {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("Failed to create version 1 database: %v", err)
+		}
+	}
+	_ = db.Close()
+
+	storage, err := NewSQLiteStorage(dbFile)
+	if err != nil {
+		t.Fatalf("Failed to open version 1 database: %v", err)
+	}
+	defer storage.Close()
+
+	entries, err := storage.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Title != "Build" || entries[0].Task != "" || len(entries[0].ID) != 5 {
+		t.Fatalf("Expected migrated entry, got %+v", entries)
+	}
+	if err := storage.SaveTasks([]models.Task{{ID: "1", Project: "Alpha", Name: "Build", Created: time.Now()}}); err != nil {
+		t.Fatalf("SaveTasks returned error: %v", err)
+	}
+	projects, err := storage.LoadProjects()
+	if err != nil {
+		t.Fatalf("LoadProjects returned error: %v", err)
+	}
+	if len(projects) != 1 || projects[0].Code != "ALP" || projects[0].Archived || projects[0].HourlyRateCents != 9535 {
+		t.Fatalf("Expected migrated project, got %+v", projects)
+	}
+}
+
+func TestLoadEntriesBetween_MatchesAcrossBackends(t *testing.T) {
+	fileStorage, err := NewFileStorage(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("Failed to create file storage: %v", err)
+	}
+	sqliteStorage := newTestSQLiteStorage(t)
+
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	entries := []models.TimeEntry{
+		{Start: start, Project: "Alpha", Title: "Build"},
+		{Start: start.Add(time.Hour), Project: "Beta", Title: "Docs"},
+		{Start: start.Add(2 * time.Hour), Project: "Gamma", Title: "Review"},
+	}
+	for _, storage := range []Storage{fileStorage, sqliteStorage} {
+		if err := storage.Save(entries); err != nil {
+			t.Fatalf("Save returned error: %v", err)
+		}
+	}
+
+	want, err := LoadEntriesBetween(fileStorage, start.Add(30*time.Minute), start.Add(90*time.Minute))
+	if err != nil {
+		t.Fatalf("LoadEntriesBetween returned error: %v", err)
+	}
+	got, err := LoadEntriesBetween(sqliteStorage, start.Add(30*time.Minute), start.Add(90*time.Minute))
+	if err != nil {
+		t.Fatalf("LoadEntriesBetween returned error: %v", err)
+	}
+	if len(want) != 2 || !reflect.DeepEqual(got, want) {
+		t.Fatalf("Expected the Alpha and Beta entries from both backends, got %+v and %+v", want, got)
+	}
+}
+
+func TestSQLiteStorage_FailedMigrationKeepsPreviousSchema(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "data.db")
+
+	db, err := sql.Open("sqlite", dbFile)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	// A version 5 database that already has the aliases column version 7 adds
+	for _, statement := range []string{
+		`CREATE TABLE projects (name TEXT NOT NULL PRIMARY KEY COLLATE NOCASE, aliases TEXT NOT NULL DEFAULT '')`,
+		`PRAGMA user_version = 5`,
+	} {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("Failed to create version 5 database: %v", err)
+		}
+	}
+	_ = db.Close()
+
+	if _, err := NewSQLiteStorage(dbFile); err == nil {
+		t.Fatal("Expected the migration to fail")
+	}
+
+	db, err = sql.Open("sqlite", dbFile)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil || version != 5 {
+		t.Fatalf("Expected schema version 5 to be kept, got %d (%v)", version, err)
+	}
+	if _, err := db.Exec("SELECT budget_hours FROM projects"); err == nil {
+		t.Fatal("Expected the migrations before the failed one to be rolled back")
+	}
+}
+
+func TestSQLiteStorage_RejectsDirectoryPath(t *testing.T) {
+	if _, err := NewSQLiteStorage(t.TempDir()); err == nil {
+		t.Fatal("Expected error for directory path")
+	}
+}
+
+func TestOpenStorage_SelectsBackend(t *testing.T) {
+	dir := t.TempDir()
+	paths := StorageOptions{
+		DataFile:     filepath.Join(dir, "data.json"),
+		DatabaseFile: filepath.Join(dir, "data.db"),
+		JournalFile:  filepath.Join(dir, "data.journal"),
+	}
+
+	jsonStorage, err := OpenStorage(StorageBackendJSON, paths)
+	if err != nil {
+		t.Fatalf("OpenStorage(json) returned error: %v", err)
+	}
+	if _, ok := jsonStorage.(*FileStorage); !ok {
+		t.Fatalf("Expected *FileStorage, got %T", jsonStorage)
+	}
+
+	sqliteStorage, err := OpenStorage(StorageBackendSQLite, paths)
+	if err != nil {
+		t.Fatalf("OpenStorage(sqlite) returned error: %v", err)
+	}
+	if _, ok := sqliteStorage.(*SQLiteStorage); !ok {
+		t.Fatalf("Expected *SQLiteStorage, got %T", sqliteStorage)
+	}
+	_ = sqliteStorage.(*SQLiteStorage).Close()
+
+	journalStorage, err := OpenStorage(StorageBackendJournal, paths)
+	if err != nil {
+		t.Fatalf("OpenStorage(journal) returned error: %v", err)
+	}
+	if _, ok := journalStorage.(*JournalStorage); !ok {
+		t.Fatalf("Expected *JournalStorage, got %T", journalStorage)
+	}
+
+	if _, err := OpenStorage("postgres", paths); err == nil {
+		t.Fatal("Expected error for unknown backend")
+	}
+}
+
+func TestCopyStorage_ImportsFileStorageIntoSQLite(t *testing.T) {
+	source, err := NewFileStorage(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("Failed to create file storage: %v", err)
+	}
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	if err := source.Save([]models.TimeEntry{
+		{Start: start, Project: "Alpha", Title: "Build", Tags: []string{"review"}},
+		{Start: start.Add(time.Hour)},
+	}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if err := source.SaveProjects([]models.Project{{Name: "Alpha", Code: "A1"}, {Name: "Beta"}}); err != nil {
+		t.Fatalf("SaveProjects returned error: %v", err)
+	}
+
+	destination := newTestSQLiteStorage(t)
+
+	result, err := CopyStorage(source, destination, false)
+	if err != nil {
+		t.Fatalf("CopyStorage returned error: %v", err)
+	}
+	if result.Entries != 2 || result.Projects != 2 {
+		t.Fatalf("Unexpected import result: %+v", result)
+	}
+
+	wantEntries, _ := source.Load()
+	gotEntries, err := destination.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !reflect.DeepEqual(gotEntries, wantEntries) {
+		t.Fatalf("Expected %+v, got %+v", wantEntries, gotEntries)
+	}
+
+	if _, err := CopyStorage(source, destination, false); !errors.Is(err, ErrStorageNotEmpty) {
+		t.Fatalf("Expected ErrStorageNotEmpty on second import, got: %v", err)
+	}
+	if _, err := CopyStorage(source, destination, true); err != nil {
+		t.Fatalf("Expected overwrite to succeed, got: %v", err)
+	}
+}
@@ -3,6 +3,8 @@ package utils
 import (
 	"testing"
 	"time"
+
+	"time-tracker/models"
 )
 
 func TestStartStopScenario(t *testing.T) {
@@ -164,3 +166,28 @@ func TestDifferentTaskAllowed(t *testing.T) {
 		t.Errorf("Second entry should be running task2")
 	}
 }
+
+func TestRecentEntries(t *testing.T) {
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time { return start.Add(time.Duration(hours) * time.Hour) }
+	end := func(hours int) *time.Time { e := at(hours); return &e }
+	entries := []models.TimeEntry{
+		{Start: at(0), End: end(1), Project: "Acme", Title: "Build"},
+		{Start: at(1), End: end(2), Project: "Beta", Title: "Review"},
+		{Start: at(2), End: end(3), Project: "", Title: ""},
+		{Start: at(3), End: end(4), Project: "Acme", Title: "Build"},
+		{Start: at(4), Project: "Gamma", Title: "Plan"},
+	}
+
+	recent := RecentEntries(entries, 10)
+	if len(recent) != 2 {
+		t.Fatalf("expected 2 recent entries, got %d: %+v", len(recent), recent)
+	}
+	if recent[0].Project != "Acme" || !recent[0].Start.Equal(at(3)) || recent[1].Project != "Beta" {
+		t.Fatalf("unexpected recent entries: %+v", recent)
+	}
+
+	if recent := RecentEntries(entries, 1); len(recent) != 1 || recent[0].Project != "Acme" {
+		t.Fatalf("expected limit to apply, got %+v", recent)
+	}
+}
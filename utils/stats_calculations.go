@@ -12,7 +12,7 @@ type DailyTotal struct {
 	Projects map[string]time.Duration
 }
 
-// WeeklyTotal represents total time for a week starting on Monday
+// WeeklyTotal represents total time for a week starting on the configured week start day
 type WeeklyTotal struct {
 	WeekStart time.Time
 	Total     time.Duration
@@ -79,9 +79,8 @@ func CalculateWeeklyTotals(entries []models.TimeEntry, numWeeks int) []WeeklyTot
 
 		duration := entry.Duration()
 
-		// Find Monday of the week
-		offset := (int(entry.Start.Weekday()) + 6) % 7 // Monday -> 0, Sunday -> 6
-		weekStart := entry.Start.AddDate(0, 0, -offset)
+		// Find the start of the week
+		weekStart := entry.Start.AddDate(0, 0, -weekStartOffset(entry.Start.Weekday()))
 		weekStr := weekStart.Format("2006-01-02")
 
 		// Check if within past numWeeks weeks
@@ -96,12 +95,11 @@ func CalculateWeeklyTotals(entries []models.TimeEntry, numWeeks int) []WeeklyTot
 	}
 
 	var totals []WeeklyTotal
-	// Compute the base Monday once
-	offset := (int(now.Weekday()) + 6) % 7 // Monday -> 0, Sunday -> 6
-	baseMonday := now.AddDate(0, 0, -offset)
+	// Compute the start of the current week once
+	baseWeekStart := now.AddDate(0, 0, -weekStartOffset(now.Weekday()))
 	for i := numWeeks - 1; i >= 0; i-- {
-		// Find Monday i weeks ago
-		weekStart := baseMonday.AddDate(0, 0, -7*i)
+		// Find the start of the week i weeks ago
+		weekStart := baseWeekStart.AddDate(0, 0, -7*i)
 		weekStr := weekStart.Format("2006-01-02")
 		total := weeklyMap[weekStr]
 		projects := weeklyProjectsMap[weekStr]
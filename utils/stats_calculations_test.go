@@ -75,3 +75,30 @@ func TestCalculateWeeklyTotals(t *testing.T) {
 		t.Errorf("Project 'test' with 2 hours not found in any week")
 	}
 }
+
+func TestGetWeekStart_UsesConfiguredDay(t *testing.T) {
+	defer SetWeekStart(time.Monday)
+
+	wednesday := time.Date(2025, 3, 12, 10, 0, 0, 0, time.UTC)
+	if got := GetWeekStart(wednesday); got.Weekday() != time.Monday || got.Day() != 10 {
+		t.Fatalf("expected Monday March 10, got %v", got)
+	}
+
+	SetWeekStart(time.Sunday)
+	if got := GetWeekStart(wednesday); got.Weekday() != time.Sunday || got.Day() != 9 {
+		t.Fatalf("expected Sunday March 9, got %v", got)
+	}
+}
+
+func TestFormatDateTime_ClockFormat(t *testing.T) {
+	defer SetUse12HourClock(false)
+
+	at := time.Date(2025, 3, 12, 14, 5, 0, 0, time.UTC)
+	if got := FormatDateTime(at); got != "2025-03-12 14:05" {
+		t.Fatalf("unexpected 24h format %q", got)
+	}
+	SetUse12HourClock(true)
+	if got := FormatDateTime(at); got != "2025-03-12 2:05 PM" {
+		t.Fatalf("unexpected 12h format %q", got)
+	}
+}
@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"time-tracker/models"
+)
+
+// Storage backends selectable in the configuration
+const (
+	StorageBackendJSON    = "json"
+	StorageBackendSQLite  = "sqlite"
+	StorageBackendJournal = "journal"
+)
+
+// StorageOptions holds the file location of each storage backend and backend settings
+type StorageOptions struct {
+	DataFile     string
+	DatabaseFile string
+	JournalFile  string
+	MaxBackups   int // Backups kept by the JSON backend; 0 disables backups
+}
+
+// OpenStorage opens the given storage backend: the JSON data file, the SQLite database, or the event journal
+func OpenStorage(backend string, options StorageOptions) (Storage, error) {
+	switch backend {
+	case "", StorageBackendJSON:
+		storage, err := NewFileStorage(options.DataFile)
+		if err != nil {
+			return nil, err
+		}
+		storage.MaxBackups = options.MaxBackups
+		return storage, nil
+	case StorageBackendSQLite:
+		storage, err := NewSQLiteStorage(options.DatabaseFile)
+		if err != nil {
+			return nil, err
+		}
+		return storage, nil
+	case StorageBackendJournal:
+		storage, err := NewJournalStorage(options.JournalFile)
+		if err != nil {
+			return nil, err
+		}
+		return storage, nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q: must be %q, %q, or %q",
+			backend, StorageBackendJSON, StorageBackendSQLite, StorageBackendJournal)
+	}
+}
+
+// LoadEntriesBetween returns the entries overlapping [from, to), querying the range when possible
+func LoadEntriesBetween(storage interface {
+	Load() ([]models.TimeEntry, error)
+}, from, to time.Time) ([]models.TimeEntry, error) {
+	if ranged, ok := storage.(interface {
+		LoadRange(from, to time.Time) ([]models.TimeEntry, error)
+	}); ok {
+		return ranged.LoadRange(from, to)
+	}
+
+	entries, err := storage.Load()
+	if err != nil {
+		return nil, err
+	}
+	filtered := make([]models.TimeEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Start.Before(to) && (entry.End == nil || entry.End.After(from)) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered, nil
+}
+
+// ErrStorageNotEmpty is returned by CopyStorage when the destination already holds data
+var ErrStorageNotEmpty = errors.New("destination storage is not empty")
+
+// ImportResult summarizes what CopyStorage transferred
+type ImportResult struct {
+	Entries  int
+	Projects int
+	Tasks    int
+}
+
+// CopyStorage copies all data from src to dst, refusing a non-empty dst unless overwrite is set
+func CopyStorage(src, dst Storage, overwrite bool) (ImportResult, error) {
+	if !overwrite {
+		existing, err := dst.Load()
+		if err != nil {
+			return ImportResult{}, fmt.Errorf("failed to load destination entries: %w", err)
+		}
+		existingProjects, err := dst.LoadProjects()
+		if err != nil {
+			return ImportResult{}, fmt.Errorf("failed to load destination projects: %w", err)
+		}
+		if len(existing) > 0 || len(existingProjects) > 0 {
+			return ImportResult{}, fmt.Errorf("%w: %d entries and %d projects", ErrStorageNotEmpty, len(existing), len(existingProjects))
+		}
+	}
+
+	entries, err := src.Load()
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("failed to load entries: %w", err)
+	}
+	projects, err := src.LoadProjects()
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("failed to load projects: %w", err)
+	}
+	tasks, err := src.LoadTasks()
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("failed to load tasks: %w", err)
+	}
+
+	if err := dst.Save(entries); err != nil {
+		return ImportResult{}, fmt.Errorf("failed to save entries: %w", err)
+	}
+	if err := dst.SaveProjects(projects); err != nil {
+		return ImportResult{}, fmt.Errorf("failed to save projects: %w", err)
+	}
+	if err := dst.SaveTasks(tasks); err != nil {
+		return ImportResult{}, fmt.Errorf("failed to save tasks: %w", err)
+	}
+
+	return ImportResult{Entries: len(entries), Projects: len(projects), Tasks: len(tasks)}, nil
+}
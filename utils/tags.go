@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"strings"
+
+	"time-tracker/models"
+)
+
+// NormalizeTags trims tags and drops empty values and case-insensitive duplicates, keeping the order
+func NormalizeTags(tags []string) []string {
+	var normalized []string
+	seen := make(map[string]struct{}, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		key := strings.ToLower(tag)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+
+	return normalized
+}
+
+// ParseTags splits a comma-separated tag list (e.g. "meeting, review") into normalized tags
+func ParseTags(value string) []string {
+	return NormalizeTags(strings.Split(value, ","))
+}
+
+// FormatTags joins tags into the comma-separated form accepted by ParseTags
+func FormatTags(tags []string) string {
+	return strings.Join(tags, ", ")
+}
+
+// FilterEntriesByTags returns entries that carry every one of the given tags
+func FilterEntriesByTags(entries []models.TimeEntry, tags []string) []models.TimeEntry {
+	tags = NormalizeTags(tags)
+	if len(tags) == 0 {
+		return entries
+	}
+
+	filtered := make([]models.TimeEntry, 0, len(entries))
+	for _, entry := range entries {
+		matches := true
+		for _, tag := range tags {
+			if !entry.HasTag(tag) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			filtered = append(filtered, entry)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	got := NormalizeTags([]string{" meeting ", "", "Review", "MEETING", "oncall"})
+	expected := []string{"meeting", "Review", "oncall"}
+	if !slices.Equal(got, expected) {
+		t.Fatalf("NormalizeTags() = %v, expected %v", got, expected)
+	}
+
+	if got := NormalizeTags([]string{" ", ""}); got != nil {
+		t.Fatalf("expected nil for empty tags, got %v", got)
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	got := ParseTags("meeting, review,,  oncall ")
+	expected := []string{"meeting", "review", "oncall"}
+	if !slices.Equal(got, expected) {
+		t.Fatalf("ParseTags() = %v, expected %v", got, expected)
+	}
+
+	if formatted := FormatTags(got); formatted != "meeting, review, oncall" {
+		t.Fatalf("FormatTags() = %q", formatted)
+	}
+}
+
+func TestFilterEntriesByTags(t *testing.T) {
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	entries := []models.TimeEntry{
+		{Start: start, Project: "A", Title: "Standup", Tags: []string{"meeting"}},
+		{Start: start.Add(time.Hour), Project: "A", Title: "PR", Tags: []string{"review", "Meeting"}},
+		{Start: start.Add(2 * time.Hour), Project: "B", Title: "Build"},
+	}
+
+	if got := FilterEntriesByTags(entries, nil); len(got) != 3 {
+		t.Fatalf("expected no filtering without tags, got %d entries", len(got))
+	}
+
+	got := FilterEntriesByTags(entries, []string{"MEETING"})
+	if len(got) != 2 || got[0].Title != "Standup" || got[1].Title != "PR" {
+		t.Fatalf("unexpected meeting filter result: %+v", got)
+	}
+
+	got = FilterEntriesByTags(entries, []string{"meeting", "review"})
+	if len(got) != 1 || got[0].Title != "PR" {
+		t.Fatalf("expected all tags to be required, got %+v", got)
+	}
+}
+
+func TestStartEntryStoresNormalizedTags(t *testing.T) {
+	storage := NewMemoryStorage()
+	tm := NewTaskManager(storage)
+
+	entry, err := tm.StartEntry("Acme", "Sync", "meeting", " Meeting ", "oncall")
+	if err != nil {
+		t.Fatalf("StartEntry returned error: %v", err)
+	}
+	if !slices.Equal(entry.Tags, []string{"meeting", "oncall"}) {
+		t.Fatalf("unexpected tags on returned entry: %v", entry.Tags)
+	}
+
+	entries, err := storage.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if err := tm.UpdateEntry(0, "Acme", "Sync", entries[0].Start, []string{"review"}, ""); err != nil {
+		t.Fatalf("UpdateEntry returned error: %v", err)
+	}
+
+	entries, err = storage.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !slices.Equal(entries[0].Tags, []string{"review"}) {
+		t.Fatalf("expected tags to be replaced, got %v", entries[0].Tags)
+	}
+
+	if err := tm.DeleteEntry(0); err != nil {
+		t.Fatalf("DeleteEntry returned error: %v", err)
+	}
+	entries, err = storage.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if entries[0].Tags != nil {
+		t.Fatalf("expected deleted entry to drop its tags, got %v", entries[0].Tags)
+	}
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -9,16 +10,27 @@ import (
 	"time-tracker/models"
 )
 
-// Storage interface for abstracting data persistence
+// Storage interface for abstracting data persistence.
+//
+// Storages shared between processes check each save against a baseline and fail with a
+// ConflictError when another process saved since. The baseline is taken by the first load after
+// opening the storage, after a conflict, or after ResetBaseline, and by each save; loading again
+// inside a change keeps it. Callers that reload the data to show it again call ResetBaseline.
 type Storage interface {
 	Load() ([]models.TimeEntry, error)
 	Save([]models.TimeEntry) error
 	LoadProjects() ([]models.Project, error)
+	LoadStoredProjects() ([]models.Project, error)
 	SaveProjects([]models.Project) error
+	LoadTasks() ([]models.Task, error)
+	SaveTasks([]models.Task) error
+	// SaveAll saves entries, projects, and tasks at once, for changes that span them
+	SaveAll([]models.TimeEntry, []models.Project, []models.Task) error
 }
 
 type TaskManager struct {
 	storage Storage
+	history HistoryStore
 }
 
 type ProjectMutationResult struct {
@@ -27,6 +39,7 @@ type ProjectMutationResult struct {
 	Renamed          bool
 	SourceName       string
 	TargetName       string
+	Project          models.Project // The project as saved; the target of a merge
 }
 
 type ProjectInUseError struct {
@@ -42,11 +55,28 @@ func NewTaskManager(storage Storage) *TaskManager {
 	return &TaskManager{storage: storage}
 }
 
-func (tm *TaskManager) StartEntry(project, title string) (*models.TimeEntry, error) {
-	return tm.StartEntryAt(project, title, time.Now())
+// SetHistory enables undo/redo, recording every mutation in the given store
+func (tm *TaskManager) SetHistory(history HistoryStore) {
+	tm.history = history
 }
 
-func (tm *TaskManager) StartEntryAt(project, title string, startTime time.Time) (*models.TimeEntry, error) {
+func (tm *TaskManager) StartEntry(project, title string, tags ...string) (*models.TimeEntry, error) {
+	return tm.StartEntryAt(project, title, time.Now(), tags...)
+}
+
+func (tm *TaskManager) StartEntryAt(project, title string, startTime time.Time, tags ...string) (_ *models.TimeEntry, err error) {
+	defer tm.record(fmt.Sprintf("start %s", describeEntry(project, title)))(&err)
+
+	// An entry for an open task's project and name is tracked against that task
+	taskID, err := tm.linkedTaskID(project, title)
+	if err != nil {
+		return nil, err
+	}
+	return tm.startEntryAt(project, title, startTime, taskID, tags...)
+}
+
+// startEntryAt starts an entry referencing the given task, without recording history
+func (tm *TaskManager) startEntryAt(project, title string, startTime time.Time, taskID string, tags ...string) (*models.TimeEntry, error) {
 	entries, err := tm.storage.Load()
 	if err != nil {
 		return nil, err
@@ -60,6 +90,17 @@ func (tm *TaskManager) StartEntryAt(project, title string, startTime time.Time)
 		}
 	}
 
+	// Entries are contiguous, so a new entry cannot start before the previous one
+	if len(entries) > 0 {
+		lastEntry := entries[len(entries)-1]
+		if lastEntry.IsBlank() && startTime.Equal(lastEntry.Start) {
+			// Starting right when the last entry stopped leaves no gap
+			entries = entries[:len(entries)-1]
+		} else if !startTime.After(lastEntry.Start) {
+			return nil, fmt.Errorf("start time %s must be after the start of the previous entry (%s)", FormatDateTime(startTime), FormatDateTime(lastEntry.Start))
+		}
+	}
+
 	// Stop last entry
 	if len(entries) > 0 {
 		lastEntry := &entries[len(entries)-1]
@@ -74,6 +115,8 @@ func (tm *TaskManager) StartEntryAt(project, title string, startTime time.Time)
 		End:     nil,
 		Project: project,
 		Title:   title,
+		Tags:    NormalizeTags(tags),
+		Task:    taskID,
 	}
 
 	entries = append(entries, newEntry)
@@ -86,6 +129,13 @@ func (tm *TaskManager) StartEntryAt(project, title string, startTime time.Time)
 }
 
 func (tm *TaskManager) StopEntry() (*models.TimeEntry, error) {
+	return tm.StopEntryAt(time.Now())
+}
+
+// StopEntryAt stops the running entry at stopTime, which must be after the entry's start
+func (tm *TaskManager) StopEntryAt(stopTime time.Time) (_ *models.TimeEntry, err error) {
+	defer tm.record("stop tracking")(&err)
+
 	entries, err := tm.storage.Load()
 	if err != nil {
 		return nil, err
@@ -100,12 +150,14 @@ func (tm *TaskManager) StopEntry() (*models.TimeEntry, error) {
 		return nil, fmt.Errorf("no active time entry to stop")
 	}
 
-	now := time.Now()
-	entries[len(entries)-1].End = &now
+	if !stopTime.After(lastEntry.Start) {
+		return nil, fmt.Errorf("stop time %s must be after the start of the running entry (%s)", FormatDateTime(stopTime), FormatDateTime(lastEntry.Start))
+	}
+	entries[len(entries)-1].End = &stopTime
 
 	// Add a blank entry to represent the gap after the stopped entry
 	blankEntry := models.TimeEntry{
-		Start:   now,
+		Start:   stopTime,
 		End:     nil,
 		Project: "",
 		Title:   "",
@@ -119,6 +171,85 @@ func (tm *TaskManager) StopEntry() (*models.TimeEntry, error) {
 	return lastEntry, nil
 }
 
+// InsertEntry adds a closed entry from `from` to `to`, overwriting entries starting inside it
+func (tm *TaskManager) InsertEntry(project, title string, from, to time.Time, tags ...string) (_ *models.TimeEntry, err error) {
+	defer tm.record(fmt.Sprintf("add %s", describeEntry(project, title)))(&err)
+
+	if strings.TrimSpace(project) == "" || strings.TrimSpace(title) == "" {
+		return nil, fmt.Errorf("project and title are required")
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("end %s must be after the start %s", FormatDateTime(to), FormatDateTime(from))
+	}
+	if to.After(time.Now()) {
+		return nil, fmt.Errorf("end %s is in the future", FormatDateTime(to))
+	}
+
+	taskID, err := tm.linkedTaskID(project, title)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := tm.storage.Load()
+	if err != nil {
+		return nil, err
+	}
+	entries = sortedEntries(entries)
+
+	var before, inside, after []models.TimeEntry
+	for _, entry := range entries {
+		switch {
+		case entry.Start.Before(from):
+			before = append(before, entry)
+		case entry.Start.Before(to):
+			inside = append(inside, entry)
+		default:
+			after = append(after, entry)
+		}
+	}
+
+	// Whatever was tracked at `to` resumes there: an entry that started inside the interval
+	// is moved, an entry the interval splits continues without its notes, otherwise a gap
+	var resumed *models.TimeEntry
+	switch {
+	case len(after) > 0 && after[0].Start.Equal(to):
+	case len(inside) > 0:
+		resumed = &inside[len(inside)-1]
+	case len(before) > 0:
+		previous := before[len(before)-1]
+		resumed = &models.TimeEntry{Project: previous.Project, Title: previous.Title, Tags: slices.Clone(previous.Tags), Task: previous.Task}
+	default:
+		resumed = &models.TimeEntry{}
+	}
+
+	inserted := models.TimeEntry{Start: from, Project: project, Title: title, Tags: NormalizeTags(tags), Task: taskID}
+	result := append(before, inserted)
+	if resumed != nil {
+		resumed.Start = to
+		result = append(result, *resumed)
+	}
+	result = append(result, after...)
+
+	var lastEnd *time.Time
+	if len(entries) > 0 {
+		lastEnd = entries[len(entries)-1].End
+	}
+	for i := range result {
+		result[i].End = lastEnd
+		if i < len(result)-1 {
+			next := result[i+1].Start
+			result[i].End = &next
+		}
+	}
+
+	if err := tm.storage.Save(result); err != nil {
+		return nil, err
+	}
+
+	inserted.End = &to
+	return &inserted, nil
+}
+
 func (tm *TaskManager) ListEntries() ([]models.TimeEntry, error) {
 	entries, err := tm.storage.Load()
 	if err != nil {
@@ -133,26 +264,24 @@ func (tm *TaskManager) ListEntries() ([]models.TimeEntry, error) {
 	return entries, nil
 }
 
-// UpdateEntry updates an existing entry's project, title, and start time
-func (tm *TaskManager) UpdateEntry(idx int, project, title string, startTime time.Time) error {
+// FindEntry returns the index and the entry with the given ID
+func (tm *TaskManager) FindEntry(id string) (int, *models.TimeEntry, error) {
 	entries, err := tm.storage.Load()
 	if err != nil {
-		return err
+		return -1, nil, err
 	}
 
-	if idx < 0 || idx >= len(entries) {
-		return fmt.Errorf("invalid entry index: %d", idx)
+	idx, err := EntryIndexByID(entries, id)
+	if err != nil {
+		return -1, nil, err
 	}
-
-	entries[idx].Project = project
-	entries[idx].Title = title
-	entries[idx].Start = startTime
-
-	return tm.storage.Save(entries)
+	return idx, &entries[idx], nil
 }
 
-// DeleteEntry removes blank entries or converts non-blank entries to blank
-func (tm *TaskManager) DeleteEntry(idx int) error {
+// UpdateEntry updates an existing entry's project, title, start time, tags, and notes
+func (tm *TaskManager) UpdateEntry(idx int, project, title string, startTime time.Time, tags []string, notes string) (err error) {
+	defer tm.record(fmt.Sprintf("edit %s", describeEntry(project, title)))(&err)
+
 	entries, err := tm.storage.Load()
 	if err != nil {
 		return err
@@ -162,162 +291,190 @@ func (tm *TaskManager) DeleteEntry(idx int) error {
 		return fmt.Errorf("invalid entry index: %d", idx)
 	}
 
-	if entries[idx].IsBlank() {
-		// Remove blank entries from the slice
-		entries = append(entries[:idx], entries[idx+1:]...)
-	} else {
-		// Convert non-blank entries to blank
-		entries[idx].Project = ""
-		entries[idx].Title = ""
+	if entries[idx].Project != project || entries[idx].Title != title {
+		taskID, err := tm.linkedTaskID(project, title)
+		if err != nil {
+			return err
+		}
+		entries[idx].Task = taskID
 	}
 
+	entries[idx].Project = project
+	entries[idx].Title = title
+	entries[idx].Start = startTime
+	entries[idx].Tags = NormalizeTags(tags)
+	entries[idx].Notes = strings.TrimSpace(notes)
+
 	return tm.storage.Save(entries)
 }
 
-func (tm *TaskManager) AddProject(name, code, category string) (*models.Project, error) {
-	name = strings.TrimSpace(name)
-	code = strings.TrimSpace(code)
-	category = strings.TrimSpace(category)
-
-	if name == "" {
-		return nil, fmt.Errorf("project name cannot be empty")
-	}
+// SetEntryNotes replaces the notes of an existing entry
+func (tm *TaskManager) SetEntryNotes(idx int, notes string) (_ *models.TimeEntry, err error) {
+	defer tm.record("set notes")(&err)
 
-	projects, err := tm.storage.LoadProjects()
+	entries, err := tm.storage.Load()
 	if err != nil {
 		return nil, err
 	}
 
-	for _, project := range projects {
-		if strings.EqualFold(project.Name, name) {
-			return nil, fmt.Errorf("project %q already exists", name)
-		}
+	if idx < 0 || idx >= len(entries) {
+		return nil, fmt.Errorf("invalid entry index: %d", idx)
+	}
+	if entries[idx].IsBlank() {
+		return nil, fmt.Errorf("cannot add notes to a blank entry")
 	}
 
-	newProject := models.Project{Name: name, Code: code, Category: category}
-	projects = append(projects, newProject)
+	entries[idx].Notes = strings.TrimSpace(notes)
 
-	if err := tm.storage.SaveProjects(projects); err != nil {
+	if err := tm.storage.Save(entries); err != nil {
 		return nil, err
 	}
 
-	return &newProject, nil
+	return &entries[idx], nil
 }
 
-func (tm *TaskManager) EditProject(name, newName, code, category string) (*ProjectMutationResult, error) {
-	name = strings.TrimSpace(name)
-	newName = strings.TrimSpace(newName)
-	code = strings.TrimSpace(code)
-	category = strings.TrimSpace(category)
-
-	if name == "" {
-		return nil, fmt.Errorf("project name cannot be empty")
-	}
+// SplitEntry splits the entry at idx in two at `at`, in one save
+func (tm *TaskManager) SplitEntry(idx int, at time.Time, project, title string) (_ *models.TimeEntry, err error) {
+	defer tm.record("split entry")(&err)
 
-	projects, err := tm.storage.LoadProjects()
+	entries, err := tm.storage.Load()
 	if err != nil {
 		return nil, err
 	}
 
-	sourceIndex := -1
-	for i, project := range projects {
-		if strings.EqualFold(project.Name, name) {
-			sourceIndex = i
-			break
-		}
+	if idx < 0 || idx >= len(entries) {
+		return nil, fmt.Errorf("invalid entry index: %d", idx)
 	}
-	if sourceIndex < 0 {
-		return nil, fmt.Errorf("project %q not found", name)
+	entry := entries[idx]
+	if entry.IsBlank() {
+		return nil, fmt.Errorf("cannot split a blank entry")
 	}
 
-	source := projects[sourceIndex]
-	originalSourceName := source.Name
-	if newName == "" {
-		newName = source.Name
+	if !at.After(entry.Start) {
+		return nil, fmt.Errorf("split time %s must be after the start of the entry (%s)", FormatDateTime(at), FormatDateTime(entry.Start))
 	}
-
-	if newName == "" {
-		return nil, fmt.Errorf("project name cannot be empty")
+	if entry.End == nil && at.After(time.Now()) {
+		return nil, fmt.Errorf("split time %s is in the future", FormatDateTime(at))
+	}
+	if entry.End != nil && !at.Before(*entry.End) {
+		return nil, fmt.Errorf("split time %s must be before the end of the entry (%s)", FormatDateTime(at), FormatDateTime(*entry.End))
 	}
 
-	targetIndex := -1
-	for i, project := range projects {
-		if i != sourceIndex && strings.EqualFold(project.Name, newName) {
-			targetIndex = i
-			break
+	second := models.TimeEntry{
+		Start:   at,
+		End:     entry.End,
+		Project: entry.Project,
+		Title:   entry.Title,
+		Tags:    slices.Clone(entry.Tags),
+		Task:    entry.Task,
+	}
+	if project != "" && (project != entry.Project || title != entry.Title) {
+		taskID, err := tm.linkedTaskID(project, title)
+		if err != nil {
+			return nil, err
 		}
+		second.Project, second.Title, second.Task = project, title, taskID
 	}
+	entries[idx].End = &at
+	entries = slices.Insert(entries, idx+1, second)
 
-	entries, err := tm.storage.Load()
-	if err != nil {
+	if err := tm.storage.Save(entries); err != nil {
 		return nil, err
 	}
 
-	rewrittenEntries := 0
-	if targetIndex >= 0 {
-		targetName := projects[targetIndex].Name
-		for i := range entries {
-			if entries[i].Project == source.Name {
-				entries[i].Project = targetName
-				rewrittenEntries++
-			}
-		}
+	return &entries[idx+1], nil
+}
 
-		projects = append(projects[:sourceIndex], projects[sourceIndex+1:]...)
-		if err := tm.storage.Save(entries); err != nil {
-			return nil, err
+// EntryIndexAt returns the index of the non-blank entry running at the given time, or -1
+func EntryIndexAt(entries []models.TimeEntry, at time.Time) int {
+	for i, entry := range entries {
+		if entry.IsBlank() || at.Before(entry.Start) {
+			continue
 		}
-		if err := tm.storage.SaveProjects(projects); err != nil {
-			return nil, err
+		if entry.End == nil || at.Before(*entry.End) {
+			return i
 		}
+	}
+	return -1
+}
 
-		return &ProjectMutationResult{
-			RewrittenEntries: rewrittenEntries,
-			Merged:           true,
-			Renamed:          true,
-			SourceName:       originalSourceName,
-			TargetName:       targetName,
-		}, nil
-	}
-
-	renamed := source.Name != newName
-	if renamed {
-		for i := range entries {
-			if entries[i].Project == source.Name {
-				entries[i].Project = newName
-				rewrittenEntries++
-			}
+// LastNonBlankIndex returns the index of the most recent non-blank entry, or -1 if there is none
+func LastNonBlankIndex(entries []models.TimeEntry) int {
+	for i := len(entries) - 1; i >= 0; i-- {
+		if !entries[i].IsBlank() {
+			return i
 		}
 	}
+	return -1
+}
 
-	source.Name = newName
-	source.Code = code
-	source.Category = category
-	projects[sourceIndex] = source
-
-	if renamed {
-		if err := tm.storage.Save(entries); err != nil {
-			return nil, err
+// RecentEntries returns the newest entry of each project/title, skipping blank and running ones
+func RecentEntries(entries []models.TimeEntry, limit int) []models.TimeEntry {
+	type pair struct{ project, title string }
+	seen := make(map[pair]bool)
+
+	var recent []models.TimeEntry
+	for i := len(entries) - 1; i >= 0 && len(recent) < limit; i-- {
+		entry := entries[i]
+		key := pair{entry.Project, entry.Title}
+		if entry.IsBlank() || seen[key] {
+			continue
 		}
-		if err := tm.storage.SaveProjects(projects); err != nil {
-			return nil, err
+		seen[key] = true
+		if entry.IsRunning() {
+			continue
 		}
+		recent = append(recent, entry)
+	}
+	return recent
+}
+
+// DeleteEntry removes blank entries or converts non-blank entries to blank
+func (tm *TaskManager) DeleteEntry(idx int) (err error) {
+	defer tm.record("delete entry")(&err)
+
+	entries, err := tm.storage.Load()
+	if err != nil {
+		return err
+	}
+
+	if idx < 0 || idx >= len(entries) {
+		return fmt.Errorf("invalid entry index: %d", idx)
+	}
+
+	if entries[idx].IsBlank() {
+		// Remove blank entries from the slice
+		entries = append(entries[:idx], entries[idx+1:]...)
 	} else {
-		if err := tm.storage.SaveProjects(projects); err != nil {
-			return nil, err
-		}
+		// Convert non-blank entries to blank; the gap gets a new ID so the old one no longer resolves
+		entries[idx].ID = ""
+		entries[idx].Project = ""
+		entries[idx].Title = ""
+		entries[idx].Tags = nil
+		entries[idx].Notes = ""
+		entries[idx].Task = ""
 	}
 
-	return &ProjectMutationResult{
-		RewrittenEntries: rewrittenEntries,
-		Merged:           false,
-		Renamed:          renamed,
-		SourceName:       originalSourceName,
-		TargetName:       source.Name,
-	}, nil
+	return tm.storage.Save(entries)
+}
+
+// AddProject adds a project with a code and category, see CreateProject
+func (tm *TaskManager) AddProject(name, code, category string) (*models.Project, error) {
+	return tm.CreateProject(name, ProjectChanges{Code: &code, Category: &category})
 }
 
-func (tm *TaskManager) RemoveProject(name string) error {
+// EditProject renames a project and sets its code and category; renaming it to another merges them
+func (tm *TaskManager) EditProject(name, newName, code, category string) (*ProjectMutationResult, error) {
+	changes := ProjectChanges{Code: &code, Category: &category}
+	if strings.TrimSpace(newName) != "" {
+		changes.Name = &newName
+	}
+	return tm.UpdateProject(name, changes)
+}
+
+func (tm *TaskManager) RemoveProject(name string) (err error) {
+	defer tm.record(fmt.Sprintf("remove project %s", strings.TrimSpace(name)))(&err)
+
 	name = strings.TrimSpace(name)
 	if name == "" {
 		return fmt.Errorf("project name cannot be empty")
@@ -357,6 +514,166 @@ func (tm *TaskManager) RemoveProject(name string) error {
 		return &ProjectInUseError{ProjectName: projectName, ReferenceCount: referenceCount}
 	}
 
+	tasks, err := tm.storage.LoadTasks()
+	if err != nil {
+		return err
+	}
+
+	// Sub-projects move up to the removed project's parent
+	parent := projects[projectIndex].Parent
 	projects = append(projects[:projectIndex], projects[projectIndex+1:]...)
-	return tm.storage.SaveProjects(projects)
+	reparentProjects(projects, projectName, parent)
+
+	// With no entries, the project's tasks have no tracked time and go with it
+	return tm.storage.SaveAll(entries, projects, moveProjectTasks(tasks, projectName, ""))
+}
+
+// reparentProjects points the sub-projects of oldName to newParent
+func reparentProjects(projects []models.Project, oldName, newParent string) {
+	for i := range projects {
+		if !strings.EqualFold(projects[i].Parent, oldName) {
+			continue
+		}
+		projects[i].Parent = newParent
+		if strings.EqualFold(projects[i].Name, newParent) {
+			projects[i].Parent = ""
+		}
+	}
+}
+
+// moveProjectTasks moves the tasks of oldName to newName, or removes them when newName is empty
+func moveProjectTasks(tasks []models.Task, oldName, newName string) []models.Task {
+	kept := tasks[:0]
+	for _, task := range tasks {
+		if task.Project == oldName {
+			if newName == "" {
+				continue
+			}
+			task.Project = newName
+		}
+		kept = append(kept, task)
+	}
+	return kept
+}
+
+// SetProjectBilling updates the billing metadata (hourly rate in cents, currency, billable flag) of a project
+func (tm *TaskManager) SetProjectBilling(name string, hourlyRate int64, currency string, billable bool) (_ *models.Project, err error) {
+	defer tm.record(fmt.Sprintf("set billing of project %s", strings.TrimSpace(name)))(&err)
+
+	projects, projectIndex, err := tm.loadProject(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := setProjectBilling(&projects[projectIndex], hourlyRate, currency, billable); err != nil {
+		return nil, err
+	}
+
+	if err := tm.storage.SaveProjects(projects); err != nil {
+		return nil, err
+	}
+
+	updated := projects[projectIndex]
+	return &updated, nil
+}
+
+// setProjectBilling validates the billing metadata and sets it on the project
+func setProjectBilling(project *models.Project, hourlyRate int64, currency string, billable bool) error {
+	if hourlyRate < 0 {
+		return fmt.Errorf("hourly rate cannot be negative")
+	}
+	project.HourlyRateCents = hourlyRate
+	project.Currency = strings.ToUpper(strings.TrimSpace(currency))
+	project.Billable = billable
+	return nil
+}
+
+// SetProjectArchived archives or unarchives the named project
+func (tm *TaskManager) SetProjectArchived(name string, archived bool) (_ *models.Project, err error) {
+	action := "archive"
+	if !archived {
+		action = "unarchive"
+	}
+	defer tm.record(fmt.Sprintf("%s project %s", action, strings.TrimSpace(name)))(&err)
+
+	projects, projectIndex, err := tm.loadProject(name)
+	if err != nil {
+		return nil, err
+	}
+
+	projects[projectIndex].Archived = archived
+	if err := tm.storage.SaveProjects(projects); err != nil {
+		return nil, err
+	}
+
+	updated := projects[projectIndex]
+	return &updated, nil
+}
+
+// SetProjectParent makes the named project a sub-project of parent, or top-level when it is empty
+func (tm *TaskManager) SetProjectParent(name, parent string) (_ *models.Project, err error) {
+	defer tm.record(fmt.Sprintf("set parent of project %s", strings.TrimSpace(name)))(&err)
+
+	projects, projectIndex, err := tm.loadProject(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := setProjectParent(projects, projectIndex, parent); err != nil {
+		return nil, err
+	}
+
+	if err := tm.storage.SaveProjects(projects); err != nil {
+		return nil, err
+	}
+
+	updated := projects[projectIndex]
+	return &updated, nil
+}
+
+// setProjectParent validates the parent of projects[idx] and sets it, see SetProjectParent
+func setProjectParent(projects []models.Project, idx int, parent string) error {
+	parent = strings.TrimSpace(parent)
+	if parent != "" {
+		parentIndex := -1
+		for i, project := range projects {
+			if strings.EqualFold(project.Name, parent) {
+				parentIndex = i
+				break
+			}
+		}
+		if parentIndex < 0 {
+			return fmt.Errorf("parent project %q not found", parent)
+		}
+		parent = projects[parentIndex].Name
+		if parentIndex == idx {
+			return fmt.Errorf("project %q cannot be its own parent", projects[idx].Name)
+		}
+		for _, ancestor := range NewProjectTree(projects).Path(parent) {
+			if strings.EqualFold(ancestor, projects[idx].Name) {
+				return fmt.Errorf("project %q cannot be a sub-project of its own sub-project %q", projects[idx].Name, parent)
+			}
+		}
+	}
+
+	projects[idx].Parent = parent
+	return nil
+}
+
+// loadProject loads the projects and finds the named one, case-insensitively
+func (tm *TaskManager) loadProject(name string) ([]models.Project, int, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, -1, fmt.Errorf("project name cannot be empty")
+	}
+
+	projects, err := tm.storage.LoadProjects()
+	if err != nil {
+		return nil, -1, err
+	}
+
+	for i, project := range projects {
+		if strings.EqualFold(project.Name, name) {
+			return projects, i, nil
+		}
+	}
+	return nil, -1, fmt.Errorf("project %q not found", name)
 }
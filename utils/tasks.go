@@ -0,0 +1,253 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"time-tracker/models"
+)
+
+// ListTasks returns all tasks ordered by ID, with their status and accumulated time
+func (tm *TaskManager) ListTasks() ([]models.Task, error) {
+	tasks, err := tm.storage.LoadTasks()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := tm.storage.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	return SummarizeTasks(tasks, entries), nil
+}
+
+// SummarizeTasks fills in the status and accumulated time of each task from the entries
+func SummarizeTasks(tasks []models.Task, entries []models.TimeEntry) []models.Task {
+	index := make(map[string]int, len(tasks))
+	for i := range tasks {
+		index[tasks[i].ID] = i
+		tasks[i].AccumulatedTime = 0
+		tasks[i].Status = models.StatusNotStarted
+	}
+
+	running := make(map[string]bool)
+	for _, entry := range entries {
+		i, ok := index[entry.Task]
+		if entry.Task == "" || !ok {
+			continue
+		}
+		tasks[i].AccumulatedTime += entry.Duration()
+		if entry.IsRunning() {
+			running[entry.Task] = true
+		}
+	}
+
+	for i := range tasks {
+		switch {
+		case tasks[i].IsCompleted():
+			tasks[i].Status = models.StatusCompleted
+		case running[tasks[i].ID]:
+			tasks[i].Status = models.StatusActive
+		case tasks[i].AccumulatedTime > 0:
+			tasks[i].Status = models.StatusPaused
+		}
+	}
+
+	return tasks
+}
+
+// AddTask adds a task to an existing project
+func (tm *TaskManager) AddTask(project, name string) (_ *models.Task, err error) {
+	defer tm.record(fmt.Sprintf("add task %s", describeEntry(project, name)))(&err)
+
+	project = strings.TrimSpace(project)
+	name = strings.TrimSpace(name)
+	if project == "" {
+		return nil, fmt.Errorf("project name cannot be empty")
+	}
+	if name == "" {
+		return nil, fmt.Errorf("task name cannot be empty")
+	}
+
+	projects, err := tm.storage.LoadProjects()
+	if err != nil {
+		return nil, err
+	}
+	projectName := ""
+	for _, existing := range projects {
+		if strings.EqualFold(existing.Name, project) {
+			projectName = existing.Name
+			break
+		}
+	}
+	if projectName == "" {
+		return nil, fmt.Errorf("project %q not found", project)
+	}
+
+	tasks, err := tm.storage.LoadTasks()
+	if err != nil {
+		return nil, err
+	}
+	if existing := findOpenTask(tasks, projectName, name); existing != nil {
+		return nil, fmt.Errorf("task %q already exists in project %q (ID %s)", existing.Name, projectName, existing.ID)
+	}
+
+	newTask := models.Task{
+		ID:      nextTaskID(tasks),
+		Project: projectName,
+		Name:    name,
+		Created: time.Now(),
+	}
+	tasks = append(tasks, newTask)
+
+	if err := tm.storage.SaveTasks(tasks); err != nil {
+		return nil, err
+	}
+
+	newTask.Status = models.StatusNotStarted
+	return &newTask, nil
+}
+
+// CompleteTask marks a task as done and returns it with its accumulated time
+func (tm *TaskManager) CompleteTask(id string) (_ *models.Task, err error) {
+	defer tm.record(fmt.Sprintf("complete task %s", strings.TrimSpace(id)))(&err)
+
+	tasks, err := tm.storage.LoadTasks()
+	if err != nil {
+		return nil, err
+	}
+
+	idx, err := taskIndex(tasks, id)
+	if err != nil {
+		return nil, err
+	}
+	if tasks[idx].IsCompleted() {
+		return nil, fmt.Errorf("task %s is already done", tasks[idx].ID)
+	}
+
+	completed := time.Now()
+	tasks[idx].Completed = &completed
+
+	if err := tm.storage.SaveTasks(tasks); err != nil {
+		return nil, err
+	}
+
+	entries, err := tm.storage.Load()
+	if err != nil {
+		return nil, err
+	}
+	task := SummarizeTasks(tasks, entries)[idx]
+	return &task, nil
+}
+
+// StartTaskAt starts a new entry for the task at startTime, reopening the task if it was done
+func (tm *TaskManager) StartTaskAt(id string, startTime time.Time) (_ *models.TimeEntry, err error) {
+	defer tm.record(fmt.Sprintf("start task %s", strings.TrimSpace(id)))(&err)
+
+	tasks, err := tm.storage.LoadTasks()
+	if err != nil {
+		return nil, err
+	}
+
+	idx, err := taskIndex(tasks, id)
+	if err != nil {
+		return nil, err
+	}
+	task := tasks[idx]
+
+	entry, err := tm.startEntryAt(task.Project, task.Name, startTime, task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.IsCompleted() {
+		tasks[idx].Completed = nil
+		if err := tm.storage.SaveTasks(tasks); err != nil {
+			return nil, err
+		}
+	}
+
+	return entry, nil
+}
+
+// findOpenTask returns the task of the project with the given name that is not done, if any
+func findOpenTask(tasks []models.Task, project, name string) *models.Task {
+	for i := range tasks {
+		task := &tasks[i]
+		if !task.IsCompleted() && strings.EqualFold(task.Project, project) && strings.EqualFold(task.Name, name) {
+			return task
+		}
+	}
+	return nil
+}
+
+// linkedTaskID returns the ID of the open task an entry with this project and title belongs to
+func (tm *TaskManager) linkedTaskID(project, title string) (string, error) {
+	if strings.TrimSpace(project) == "" || strings.TrimSpace(title) == "" {
+		return "", nil
+	}
+
+	tasks, err := tm.storage.LoadTasks()
+	if err != nil {
+		return "", err
+	}
+	if task := findOpenTask(tasks, strings.TrimSpace(project), strings.TrimSpace(title)); task != nil {
+		return task.ID, nil
+	}
+	return "", nil
+}
+
+func taskIndex(tasks []models.Task, id string) (int, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return -1, fmt.Errorf("task ID cannot be empty")
+	}
+	for i, task := range tasks {
+		if task.ID == id {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("task %s not found", id)
+}
+
+// nextTaskID returns the next unused ID; task IDs are sequence numbers so they are easy to type
+func nextTaskID(tasks []models.Task) string {
+	highest := 0
+	for _, task := range tasks {
+		if number, err := strconv.Atoi(task.ID); err == nil && number > highest {
+			highest = number
+		}
+	}
+	return strconv.Itoa(highest + 1)
+}
+
+// sortTasks orders tasks by ID, numerically where possible
+func sortTasks(tasks []models.Task) []models.Task {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, errA := strconv.Atoi(tasks[i].ID)
+		b, errB := strconv.Atoi(tasks[j].ID)
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		if (errA == nil) != (errB == nil) {
+			return errA == nil
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks
+}
+
+// FormatTaskStatus returns a task status for display, e.g. "not started"
+func FormatTaskStatus(status models.TaskStatus) string {
+	switch status {
+	case models.StatusCompleted:
+		return "done"
+	case "":
+		return string(models.StatusNotStarted)
+	default:
+		return strings.ReplaceAll(string(status), "_", " ")
+	}
+}
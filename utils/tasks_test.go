@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+)
+
+func newTaskTestManager(t *testing.T) (*TaskManager, *MemoryStorage) {
+	t.Helper()
+
+	storage := NewMemoryStorage()
+	if err := storage.SaveProjects([]models.Project{{Name: "Acme"}, {Name: "Beta"}}); err != nil {
+		t.Fatalf("failed to seed projects: %v", err)
+	}
+	return NewTaskManager(storage), storage
+}
+
+func TestAddTask(t *testing.T) {
+	tm, _ := newTaskTestManager(t)
+
+	task, err := tm.AddTask("acme", "  Build  ")
+	if err != nil {
+		t.Fatalf("AddTask returned error: %v", err)
+	}
+	if task.ID != "1" || task.Project != "Acme" || task.Name != "Build" || task.Status != models.StatusNotStarted {
+		t.Fatalf("unexpected task: %+v", task)
+	}
+
+	second, err := tm.AddTask("Acme", "Review")
+	if err != nil || second.ID != "2" {
+		t.Fatalf("expected task 2, got %+v (%v)", second, err)
+	}
+
+	if _, err := tm.AddTask("Acme", "build"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected duplicate task error, got %v", err)
+	}
+	if _, err := tm.AddTask("Nope", "Build"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected unknown project error, got %v", err)
+	}
+	if _, err := tm.AddTask("Acme", " "); err == nil {
+		t.Fatalf("expected empty name error")
+	}
+}
+
+func TestStartEntryAt_LinksOpenTask(t *testing.T) {
+	tm, storage := newTaskTestManager(t)
+	if _, err := tm.AddTask("Acme", "Build"); err != nil {
+		t.Fatalf("AddTask returned error: %v", err)
+	}
+
+	start := time.Now().Add(-2 * time.Hour)
+	if _, err := tm.StartEntryAt("Acme", "Build", start); err != nil {
+		t.Fatalf("StartEntryAt returned error: %v", err)
+	}
+	if _, err := tm.StartEntryAt("Acme", "Other", start.Add(time.Hour)); err != nil {
+		t.Fatalf("StartEntryAt returned error: %v", err)
+	}
+
+	entries, _ := storage.Load()
+	if entries[0].Task != "1" || entries[1].Task != "" {
+		t.Fatalf("expected only the matching entry to reference the task, got %+v", entries)
+	}
+
+	tasks, err := tm.ListTasks()
+	if err != nil {
+		t.Fatalf("ListTasks returned error: %v", err)
+	}
+	if tasks[0].Status != models.StatusPaused || tasks[0].AccumulatedTime != time.Hour {
+		t.Fatalf("expected a paused task with 1h, got %+v", tasks[0])
+	}
+}
+
+func TestStartTaskAt_ActivatesAndReopens(t *testing.T) {
+	tm, _ := newTaskTestManager(t)
+	if _, err := tm.AddTask("Acme", "Build"); err != nil {
+		t.Fatalf("AddTask returned error: %v", err)
+	}
+	if _, err := tm.CompleteTask("1"); err != nil {
+		t.Fatalf("CompleteTask returned error: %v", err)
+	}
+	if _, err := tm.CompleteTask("1"); err == nil || !strings.Contains(err.Error(), "already done") {
+		t.Fatalf("expected already done error, got %v", err)
+	}
+
+	entry, err := tm.StartTaskAt("1", time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("StartTaskAt returned error: %v", err)
+	}
+	if entry.Project != "Acme" || entry.Title != "Build" || entry.Task != "1" {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+
+	tasks, _ := tm.ListTasks()
+	if tasks[0].Status != models.StatusActive || tasks[0].IsCompleted() {
+		t.Fatalf("expected an active, reopened task, got %+v", tasks[0])
+	}
+
+	if _, err := tm.StartTaskAt("9", time.Now()); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected unknown task error, got %v", err)
+	}
+}
+
+func TestUpdateEntry_RelinksTaskWhenTitleChanges(t *testing.T) {
+	tm, storage := newTaskTestManager(t)
+	if _, err := tm.AddTask("Acme", "Build"); err != nil {
+		t.Fatalf("AddTask returned error: %v", err)
+	}
+	start := time.Now().Add(-time.Hour)
+	if _, err := tm.StartEntryAt("Acme", "Build", start); err != nil {
+		t.Fatalf("StartEntryAt returned error: %v", err)
+	}
+
+	if err := tm.UpdateEntry(0, "Acme", "Build", start, []string{"dev"}, ""); err != nil {
+		t.Fatalf("UpdateEntry returned error: %v", err)
+	}
+	entries, _ := storage.Load()
+	if entries[0].Task != "1" {
+		t.Fatalf("expected task reference to be kept, got %+v", entries[0])
+	}
+
+	if err := tm.UpdateEntry(0, "Acme", "Meeting", start, nil, ""); err != nil {
+		t.Fatalf("UpdateEntry returned error: %v", err)
+	}
+	entries, _ = storage.Load()
+	if entries[0].Task != "" {
+		t.Fatalf("expected task reference to be cleared, got %+v", entries[0])
+	}
+}
+
+func TestEditProject_MovesTasks(t *testing.T) {
+	tm, storage := newTaskTestManager(t)
+	if _, err := tm.AddTask("Acme", "Build"); err != nil {
+		t.Fatalf("AddTask returned error: %v", err)
+	}
+
+	if _, err := tm.EditProject("Acme", "Acme Corp", "", ""); err != nil {
+		t.Fatalf("EditProject returned error: %v", err)
+	}
+	tasks, _ := storage.LoadTasks()
+	if tasks[0].Project != "Acme Corp" {
+		t.Fatalf("expected task to move to the renamed project, got %+v", tasks[0])
+	}
+
+	if err := tm.RemoveProject("Acme Corp"); err != nil {
+		t.Fatalf("RemoveProject returned error: %v", err)
+	}
+	tasks, _ = storage.LoadTasks()
+	if len(tasks) != 0 {
+		t.Fatalf("expected tasks of the removed project to be removed, got %+v", tasks)
+	}
+}
+
+func TestUndo_AddTask(t *testing.T) {
+	tm, storage := newTaskTestManager(t)
+	tm.SetHistory(NewMemoryHistoryStore())
+
+	if _, err := tm.AddTask("Acme", "Build"); err != nil {
+		t.Fatalf("AddTask returned error: %v", err)
+	}
+	record, err := tm.Undo()
+	if err != nil {
+		t.Fatalf("Undo returned error: %v", err)
+	}
+	if record.Description != "add task Acme: Build" {
+		t.Fatalf("unexpected description %q", record.Description)
+	}
+
+	tasks, _ := storage.LoadTasks()
+	if len(tasks) != 0 {
+		t.Fatalf("expected the task to be undone, got %+v", tasks)
+	}
+}
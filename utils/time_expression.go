@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	agoPattern   = regexp.MustCompile(`^(?:-\s*(\S+)|(\S+)\s+ago)$`)
+	clockPattern = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+	datePattern  = regexp.MustCompile(`^(\d{4})-(\d{1,2})-(\d{1,2})$`)
+	isoPattern   = regexp.MustCompile(`^(\d{4}-\d{1,2}-\d{1,2})t(\d)`)
+)
+
+// ParseTimeExpression parses a time like "now", "9:15am", "yesterday 17:30", "2025-03-10 09:15", or "10m ago"
+func ParseTimeExpression(expr string, now time.Time) (time.Time, error) {
+	normalized := strings.ToLower(strings.Join(strings.Fields(expr), " "))
+	normalized = isoPattern.ReplaceAllString(normalized, "$1 $2")
+	if normalized == "" {
+		return time.Time{}, fmt.Errorf("time cannot be empty")
+	}
+	if normalized == "now" {
+		return now, nil
+	}
+
+	if match := agoPattern.FindStringSubmatch(normalized); match != nil {
+		return ParseAgo(match[1]+match[2], now)
+	}
+
+	day, clock := "today", normalized
+	if fields := strings.SplitN(normalized, " ", 2); len(fields) == 2 && (fields[0] == "today" || fields[0] == "yesterday" || datePattern.MatchString(fields[0])) {
+		day, clock = fields[0], fields[1]
+	}
+
+	year, month, dayOfMonth := now.Date()
+	switch day {
+	case "today":
+	case "yesterday":
+		year, month, dayOfMonth = now.AddDate(0, 0, -1).Date()
+	default:
+		match := datePattern.FindStringSubmatch(day)
+		year, _ = strconv.Atoi(match[1])
+		monthNumber, _ := strconv.Atoi(match[2])
+		month = time.Month(monthNumber)
+		dayOfMonth, _ = strconv.Atoi(match[3])
+	}
+
+	hour, minute, err := parseClock(clock)
+	if err != nil {
+		return time.Time{}, invalidTimeExpression(expr)
+	}
+
+	parsed := time.Date(year, month, dayOfMonth, hour, minute, 0, 0, now.Location())
+	if parsed.Year() != year || parsed.Month() != month || parsed.Day() != dayOfMonth {
+		return time.Time{}, fmt.Errorf("invalid day for month/year in %q", expr)
+	}
+	return parsed, nil
+}
+
+// ParseEndTime parses the end of an interval, a bare clock time being on the start's day or the next
+func ParseEndTime(expr string, start, now time.Time) (time.Time, error) {
+	normalized := strings.ToLower(strings.Join(strings.Fields(expr), " "))
+	if !clockPattern.MatchString(normalized) {
+		return ParseTimeExpression(expr, now)
+	}
+
+	hour, minute, err := parseClock(normalized)
+	if err != nil {
+		return time.Time{}, invalidTimeExpression(expr)
+	}
+	end := time.Date(start.Year(), start.Month(), start.Day(), hour, minute, 0, 0, start.Location())
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end, nil
+}
+
+// ParseAgo parses a positive duration such as "10m" or "1h30m" and returns that long before now
+func ParseAgo(value string, now time.Time) (time.Time, error) {
+	duration, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil || duration <= 0 {
+		return time.Time{}, fmt.Errorf("invalid duration %q: use a positive duration like 10m or 1h30m", value)
+	}
+	return now.Add(-duration), nil
+}
+
+func parseClock(clock string) (int, int, error) {
+	match := clockPattern.FindStringSubmatch(clock)
+	if match == nil {
+		return 0, 0, fmt.Errorf("invalid clock time %q", clock)
+	}
+
+	hour, _ := strconv.Atoi(match[1])
+	minute := 0
+	if match[2] != "" {
+		minute, _ = strconv.Atoi(match[2])
+	} else if match[3] == "" {
+		// A bare number is ambiguous; require HH:MM unless am/pm is given
+		return 0, 0, fmt.Errorf("invalid clock time %q", clock)
+	}
+
+	switch match[3] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, fmt.Errorf("invalid clock time %q", clock)
+		}
+		hour %= 12
+		if match[3] == "pm" {
+			hour += 12
+		}
+	default:
+		if hour > 23 {
+			return 0, 0, fmt.Errorf("invalid clock time %q", clock)
+		}
+	}
+	if minute > 59 {
+		return 0, 0, fmt.Errorf("invalid clock time %q", clock)
+	}
+
+	return hour, minute, nil
+}
+
+func invalidTimeExpression(expr string) error {
+	return fmt.Errorf(`invalid time %q: use HH:MM, "yesterday HH:MM", "YYYY-MM-DD HH:MM", or a duration like "10m ago"`, expr)
+}
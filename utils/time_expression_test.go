@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTimeExpression(t *testing.T) {
+	now := time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"now", now},
+		{"09:15", time.Date(2025, 3, 10, 9, 15, 0, 0, time.UTC)},
+		{"9:05", time.Date(2025, 3, 10, 9, 5, 0, 0, time.UTC)},
+		{"5pm", time.Date(2025, 3, 10, 17, 0, 0, 0, time.UTC)},
+		{"12:30 am", time.Date(2025, 3, 10, 0, 30, 0, 0, time.UTC)},
+		{"today 08:00", time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)},
+		{"Yesterday 17:30", time.Date(2025, 3, 9, 17, 30, 0, 0, time.UTC)},
+		{"2025-02-28 23:59", time.Date(2025, 2, 28, 23, 59, 0, 0, time.UTC)},
+		{"2025-02-28T08:05", time.Date(2025, 2, 28, 8, 5, 0, 0, time.UTC)},
+		{"10m ago", now.Add(-10 * time.Minute)},
+		{"-1h30m", now.Add(-90 * time.Minute)},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTimeExpression(tt.expr, now)
+		if err != nil {
+			t.Errorf("ParseTimeExpression(%q) returned error: %v", tt.expr, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseTimeExpression(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestParseTimeExpression_Invalid(t *testing.T) {
+	now := time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)
+
+	for _, expr := range []string{"", "9", "25:00", "13pm", "tomorrow 09:00", "2025-02-29 09:00", "0m ago", "soon"} {
+		if _, err := ParseTimeExpression(expr, now); err == nil {
+			t.Errorf("expected ParseTimeExpression(%q) to fail", expr)
+		}
+	}
+
+	_, err := ParseTimeExpression("2025-02-29 09:00", now)
+	if err == nil || !strings.Contains(err.Error(), "day") {
+		t.Fatalf("expected a day error, got %v", err)
+	}
+}
+
+func TestStartEntryAt_RejectsTimeBeforePreviousEntry(t *testing.T) {
+	tm := NewTaskManager(NewMemoryStorage())
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	if _, err := tm.StartEntryAt("Alpha", "Design", start); err != nil {
+		t.Fatalf("StartEntryAt failed: %v", err)
+	}
+	_, err := tm.StartEntryAt("Beta", "Review", start.Add(-time.Minute))
+	if err == nil || !strings.Contains(err.Error(), "must be after the start of the previous entry") {
+		t.Fatalf("expected ordering error, got %v", err)
+	}
+}
+
+func TestStopEntryAt_BackdatesAndValidates(t *testing.T) {
+	storage := NewMemoryStorage()
+	tm := NewTaskManager(storage)
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	if _, err := tm.StartEntryAt("Alpha", "Design", start); err != nil {
+		t.Fatalf("StartEntryAt failed: %v", err)
+	}
+	if _, err := tm.StopEntryAt(start); err == nil {
+		t.Fatal("expected stopping at the start time to fail")
+	}
+
+	stop := start.Add(45 * time.Minute)
+	entry, err := tm.StopEntryAt(stop)
+	if err != nil {
+		t.Fatalf("StopEntryAt failed: %v", err)
+	}
+	if entry.Duration() != 45*time.Minute {
+		t.Fatalf("expected a 45m entry, got %v", entry.Duration())
+	}
+
+	// Starting exactly when the last entry stopped replaces the blank gap
+	if _, err := tm.StartEntryAt("Beta", "Review", stop); err != nil {
+		t.Fatalf("StartEntryAt failed: %v", err)
+	}
+	entries, _ := storage.Load()
+	if len(entries) != 2 || entries[1].Project != "Beta" {
+		t.Fatalf("expected the gap to be replaced, got %+v", entries)
+	}
+}
+
+func TestParseEndTime(t *testing.T) {
+	now := time.Date(2025, 3, 10, 14, 30, 0, 0, time.UTC)
+	start := time.Date(2025, 3, 8, 22, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"23:30", time.Date(2025, 3, 8, 23, 30, 0, 0, time.UTC)},
+		{"01:15", time.Date(2025, 3, 9, 1, 15, 0, 0, time.UTC)},
+		{"2025-03-09T08:00", time.Date(2025, 3, 9, 8, 0, 0, 0, time.UTC)},
+		{"yesterday 09:00", time.Date(2025, 3, 9, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseEndTime(tt.expr, start, now)
+		if err != nil {
+			t.Errorf("ParseEndTime(%q) returned error: %v", tt.expr, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseEndTime(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+
+	if _, err := ParseEndTime("25:00", start, now); err == nil {
+		t.Error("expected ParseEndTime(\"25:00\") to fail")
+	}
+}
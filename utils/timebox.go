@@ -0,0 +1,310 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"time-tracker/models"
+)
+
+// Timebox limits the running entry to a fixed length
+type Timebox struct {
+	Start    time.Time      `json:"start"` // Start of the entry or break the box applies to
+	Until    time.Time      `json:"until"`
+	Pomodoro *PomodoroCycle `json:"pomodoro,omitempty"`
+}
+
+// PomodoroCycle alternates entries for the same project and title with breaks recorded as gaps
+type PomodoroCycle struct {
+	Project   string        `json:"project"`
+	Title     string        `json:"title"`
+	Tags      []string      `json:"tags,omitempty"`
+	Work      time.Duration `json:"work"`
+	Break     time.Duration `json:"break"`
+	LongBreak time.Duration `json:"long-break"` // Zero ends the cycle after the last pomodoro
+	Rounds    int           `json:"rounds"`
+	Round     int           `json:"round"` // Current pomodoro, from 1
+	OnBreak   bool          `json:"on-break"`
+}
+
+// Label describes the phase of the box, e.g. "pomodoro 2/4" or "break"
+func (b *Timebox) Label() string {
+	switch {
+	case b.Pomodoro == nil:
+		return "timebox"
+	case !b.Pomodoro.OnBreak:
+		return fmt.Sprintf("pomodoro %d/%d", b.Pomodoro.Round, b.Pomodoro.Rounds)
+	case b.Pomodoro.Round >= b.Pomodoro.Rounds:
+		return "long break"
+	default:
+		return "break"
+	}
+}
+
+// Remaining returns how long the box has left at now, never less than zero
+func (b *Timebox) Remaining(now time.Time) time.Duration {
+	return max(b.Until.Sub(now), 0)
+}
+
+// appliesTo reports whether the box still governs the running entry
+func (b *Timebox) appliesTo(entries []models.TimeEntry) bool {
+	if len(entries) == 0 {
+		return false
+	}
+	last := entries[len(entries)-1]
+	onBreak := b.Pomodoro != nil && b.Pomodoro.OnBreak
+	return last.IsRunning() && last.Start.Equal(b.Start) && last.IsBlank() == onBreak
+}
+
+// TimeboxStore persists the timebox of the running entry, shared by the CLI and the TUI
+type TimeboxStore interface {
+	LoadTimebox() (*Timebox, error)
+	SaveTimebox(*Timebox) error // Saving nil removes the timebox
+}
+
+// FileTimeboxStore implements TimeboxStore using a JSON file
+type FileTimeboxStore struct {
+	FilePath string
+}
+
+func NewFileTimeboxStore(filePath string) *FileTimeboxStore {
+	return &FileTimeboxStore{FilePath: filePath}
+}
+
+func (ts *FileTimeboxStore) LoadTimebox() (*Timebox, error) {
+	jsonData, err := os.ReadFile(ts.FilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read timebox file: %w", err)
+	}
+
+	var box Timebox
+	if err := json.Unmarshal(jsonData, &box); err != nil {
+		return nil, fmt.Errorf("failed to parse timebox file: %w", err)
+	}
+	return &box, nil
+}
+
+func (ts *FileTimeboxStore) SaveTimebox(box *Timebox) error {
+	if box == nil {
+		if err := os.Remove(ts.FilePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove timebox file: %w", err)
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(ts.FilePath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	jsonData, err := json.MarshalIndent(box, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal timebox: %w", err)
+	}
+	return writeFileAtomic(ts.FilePath, jsonData)
+}
+
+// MemoryTimeboxStore implements TimeboxStore in memory for testing
+type MemoryTimeboxStore struct {
+	box *Timebox
+}
+
+func NewMemoryTimeboxStore() *MemoryTimeboxStore {
+	return &MemoryTimeboxStore{}
+}
+
+func (ts *MemoryTimeboxStore) LoadTimebox() (*Timebox, error) {
+	if ts.box == nil {
+		return nil, nil
+	}
+	box := *ts.box
+	return &box, nil
+}
+
+func (ts *MemoryTimeboxStore) SaveTimebox(box *Timebox) error {
+	if box == nil {
+		ts.box = nil
+		return nil
+	}
+	saved := *box
+	ts.box = &saved
+	return nil
+}
+
+// TimeboxChangeKind is what happened when a timebox expired
+type TimeboxChangeKind int
+
+const (
+	TimeboxStopped  TimeboxChangeKind = iota // A timeboxed entry was stopped
+	PomodoroBreak                            // A pomodoro ended and a break started
+	PomodoroResumed                          // A break ended and the next pomodoro started
+	PomodoroDone                             // The pomodoro cycle ended
+)
+
+// TimeboxChange is one expiry applied by ExpireTimebox
+type TimeboxChange struct {
+	Kind    TimeboxChangeKind
+	At      time.Time // When the box expired
+	Project string
+	Title   string
+	Label   string    // Label of the phase that started, e.g. "pomodoro 3/4" or "break"
+	Until   time.Time // End of the phase that started
+}
+
+func (c TimeboxChange) String() string {
+	entry := fmt.Sprintf("\"%s\" in project \"%s\"", c.Title, c.Project)
+	at := FormatDateTime(c.At)
+	switch c.Kind {
+	case PomodoroBreak:
+		return fmt.Sprintf("Pomodoro on %s ended at %s; %s until %s", entry, at, c.Label, FormatDateTime(c.Until))
+	case PomodoroResumed:
+		return fmt.Sprintf("Break ended at %s; started %s on %s until %s", at, c.Label, entry, FormatDateTime(c.Until))
+	case PomodoroDone:
+		return fmt.Sprintf("Pomodoro cycle on %s finished at %s", entry, at)
+	default:
+		return fmt.Sprintf("Timebox of %s ended at %s; stopped tracking", entry, at)
+	}
+}
+
+// StartTimebox starts an entry at startTime that is stopped once it has run for length
+func (tm *TaskManager) StartTimebox(store TimeboxStore, project, title string, startTime time.Time, length time.Duration, tags ...string) (*models.TimeEntry, *Timebox, error) {
+	if length <= 0 {
+		return nil, nil, fmt.Errorf("timebox length must be positive, got %s", length)
+	}
+
+	entry, err := tm.StartEntryAt(project, title, startTime, tags...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	box := &Timebox{Start: entry.Start, Until: entry.Start.Add(length)}
+	if err := store.SaveTimebox(box); err != nil {
+		return nil, nil, err
+	}
+	return entry, box, nil
+}
+
+// StartPomodoro starts the first pomodoro of the cycle at startTime
+func (tm *TaskManager) StartPomodoro(store TimeboxStore, cycle PomodoroCycle, startTime time.Time) (*models.TimeEntry, *Timebox, error) {
+	if cycle.Work <= 0 || cycle.Break <= 0 || cycle.LongBreak < 0 {
+		return nil, nil, fmt.Errorf("pomodoro and break lengths must be positive")
+	}
+	if cycle.Rounds < 1 {
+		return nil, nil, fmt.Errorf("a pomodoro cycle needs at least one round, got %d", cycle.Rounds)
+	}
+
+	entry, err := tm.StartEntryAt(cycle.Project, cycle.Title, startTime, cycle.Tags...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cycle.Tags = slices.Clone(entry.Tags)
+	cycle.Round = 1
+	cycle.OnBreak = false
+	box := &Timebox{Start: entry.Start, Until: entry.Start.Add(cycle.Work), Pomodoro: &cycle}
+	if err := store.SaveTimebox(box); err != nil {
+		return nil, nil, err
+	}
+	return entry, box, nil
+}
+
+// ActiveTimebox returns the timebox of the running entry, or nil when there is none
+func ActiveTimebox(store TimeboxStore, entries []models.TimeEntry) (*Timebox, error) {
+	box, err := store.LoadTimebox()
+	if err != nil || box == nil || !box.appliesTo(entries) {
+		return nil, err
+	}
+	return box, nil
+}
+
+// ExpireTimebox applies every expiry of the stored timebox up to now, without recording undo
+func (tm *TaskManager) ExpireTimebox(store TimeboxStore, now time.Time) ([]TimeboxChange, error) {
+	box, err := store.LoadTimebox()
+	if err != nil || box == nil {
+		return nil, err
+	}
+
+	defer tm.beginOperation("expire timebox")()
+	unrecorded := NewTaskManager(tm.backingStorage())
+	var changes []TimeboxChange
+	for box != nil {
+		entries, err := unrecorded.ListEntries()
+		if err != nil {
+			return changes, err
+		}
+		if !box.appliesTo(entries) {
+			return changes, store.SaveTimebox(nil)
+		}
+		if now.Before(box.Until) {
+			return changes, nil
+		}
+
+		var change TimeboxChange
+		change, box, err = unrecorded.expireTimebox(*box, entries[len(entries)-1])
+		if err != nil {
+			return changes, err
+		}
+		changes = append(changes, change)
+
+		// Saved after every step, so an interrupted catch-up resumes where it stopped
+		if err := store.SaveTimebox(box); err != nil {
+			return changes, err
+		}
+	}
+	return changes, nil
+}
+
+// expireTimebox ends the phase of an expired box and returns the box of the next phase, if any
+func (tm *TaskManager) expireTimebox(box Timebox, running models.TimeEntry) (TimeboxChange, *Timebox, error) {
+	change := TimeboxChange{Kind: TimeboxStopped, At: box.Until, Project: running.Project, Title: running.Title}
+	cycle := box.Pomodoro
+	if cycle == nil {
+		_, err := tm.StopEntryAt(box.Until)
+		return change, nil, err
+	}
+
+	change.Project, change.Title = cycle.Project, cycle.Title
+	next := *cycle
+	if !cycle.OnBreak {
+		if _, err := tm.StopEntryAt(box.Until); err != nil {
+			return change, nil, err
+		}
+
+		length := cycle.Break
+		if cycle.Round >= cycle.Rounds {
+			length = cycle.LongBreak
+		}
+		if length <= 0 {
+			change.Kind = PomodoroDone
+			return change, nil, nil
+		}
+
+		next.OnBreak = true
+		nextBox := &Timebox{Start: box.Until, Until: box.Until.Add(length), Pomodoro: &next}
+		change.Kind, change.Label, change.Until = PomodoroBreak, nextBox.Label(), nextBox.Until
+		return change, nextBox, nil
+	}
+
+	// The long break after the last pomodoro ends the cycle; the gap simply continues
+	if cycle.Round >= cycle.Rounds {
+		change.Kind = PomodoroDone
+		return change, nil, nil
+	}
+
+	if _, err := tm.StartEntryAt(cycle.Project, cycle.Title, box.Until, cycle.Tags...); err != nil {
+		return change, nil, err
+	}
+
+	next.Round++
+	next.OnBreak = false
+	nextBox := &Timebox{Start: box.Until, Until: box.Until.Add(cycle.Work), Pomodoro: &next}
+	change.Kind, change.Label, change.Until = PomodoroResumed, nextBox.Label(), nextBox.Until
+	return change, nextBox, nil
+}
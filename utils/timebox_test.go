@@ -0,0 +1,187 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"time-tracker/models"
+)
+
+func TestExpireTimebox_StopsEntryAtEndOfBox(t *testing.T) {
+	storage, tm := newHistoryTaskManager()
+	store := NewMemoryTimeboxStore()
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+
+	if _, _, err := tm.StartTimebox(store, "Acme", "Build", start, 25*time.Minute); err != nil {
+		t.Fatalf("StartTimebox failed: %v", err)
+	}
+
+	changes, err := tm.ExpireTimebox(store, start.Add(10*time.Minute))
+	if err != nil || len(changes) != 0 {
+		t.Fatalf("expected nothing to expire yet, got %+v (%v)", changes, err)
+	}
+
+	changes, err = tm.ExpireTimebox(store, start.Add(3*time.Hour))
+	if err != nil {
+		t.Fatalf("ExpireTimebox failed: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Kind != TimeboxStopped || !changes[0].At.Equal(start.Add(25*time.Minute)) {
+		t.Fatalf("expected the entry to be stopped at the end of the box, got %+v", changes)
+	}
+
+	entries, _ := storage.Load()
+	if len(entries) != 2 || entries[0].End == nil || !entries[0].End.Equal(start.Add(25*time.Minute)) || !entries[1].IsBlank() {
+		t.Fatalf("expected the entry to end at 09:25 followed by a gap, got %+v", entries)
+	}
+	if box, _ := store.LoadTimebox(); box != nil {
+		t.Fatalf("expected the expired box to be removed, got %+v", box)
+	}
+
+	// The expiry is not a step of its own: undo goes back to before the start
+	record, err := tm.Undo()
+	if err != nil || record.Description != "start Acme: Build" {
+		t.Fatalf("expected undo to revert the start, got %+v (%v)", record, err)
+	}
+}
+
+func TestExpireTimebox_DiscardsBoxOfEntryStoppedByHand(t *testing.T) {
+	storage, tm := newHistoryTaskManager()
+	store := NewMemoryTimeboxStore()
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+
+	if _, _, err := tm.StartTimebox(store, "Acme", "Build", start, 25*time.Minute); err != nil {
+		t.Fatalf("StartTimebox failed: %v", err)
+	}
+	if _, err := tm.StartEntryAt("Acme", "Review", start.Add(10*time.Minute)); err != nil {
+		t.Fatalf("StartEntryAt failed: %v", err)
+	}
+
+	changes, err := tm.ExpireTimebox(store, start.Add(time.Hour))
+	if err != nil || len(changes) != 0 {
+		t.Fatalf("expected the stale box to be discarded, got %+v (%v)", changes, err)
+	}
+	entries, _ := storage.Load()
+	if last := entries[len(entries)-1]; !last.IsRunning() || last.Title != "Review" {
+		t.Fatalf("expected the entry started by hand to keep running, got %+v", entries)
+	}
+	if box, _ := store.LoadTimebox(); box != nil {
+		t.Fatalf("expected the stale box to be removed, got %+v", box)
+	}
+}
+
+func TestExpireTimebox_CatchesUpOnPomodoroCycle(t *testing.T) {
+	storage, tm := newHistoryTaskManager()
+	store := NewMemoryTimeboxStore()
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	cycle := PomodoroCycle{Project: "Acme", Title: "Build", Tags: []string{"focus"}, Work: 25 * time.Minute, Break: 5 * time.Minute, LongBreak: 15 * time.Minute, Rounds: 2}
+
+	if _, _, err := tm.StartPomodoro(store, cycle, start); err != nil {
+		t.Fatalf("StartPomodoro failed: %v", err)
+	}
+
+	// 09:40 is in the second pomodoro: the first one and its break are over
+	changes, err := tm.ExpireTimebox(store, start.Add(40*time.Minute))
+	if err != nil {
+		t.Fatalf("ExpireTimebox failed: %v", err)
+	}
+	if len(changes) != 2 || changes[0].Kind != PomodoroBreak || changes[1].Kind != PomodoroResumed || changes[1].Label != "pomodoro 2/2" {
+		t.Fatalf("expected a break and the second pomodoro, got %+v", changes)
+	}
+	box, err := ActiveTimebox(store, mustLoad(t, storage))
+	if err != nil || box == nil || !box.Until.Equal(start.Add(55*time.Minute)) {
+		t.Fatalf("expected the second pomodoro to run until 09:55, got %+v (%v)", box, err)
+	}
+
+	changes, err = tm.ExpireTimebox(store, start.Add(5*time.Hour))
+	if err != nil {
+		t.Fatalf("ExpireTimebox failed: %v", err)
+	}
+	if len(changes) != 2 || changes[0].Label != "long break" || changes[1].Kind != PomodoroDone || !changes[1].At.Equal(start.Add(70*time.Minute)) {
+		t.Fatalf("expected the long break and the end of the cycle, got %+v", changes)
+	}
+
+	entries := mustLoad(t, storage)
+	assertTimeline(t, entries,
+		[]string{"Acme: Build", "blank entry", "Acme: Build", "blank entry"},
+		[]time.Time{start, start.Add(25 * time.Minute), start.Add(30 * time.Minute), start.Add(55 * time.Minute)})
+	if FormatTags(entries[2].Tags) != "focus" {
+		t.Fatalf("expected the second pomodoro to keep the tags, got %+v", entries[2])
+	}
+	if box, _ := store.LoadTimebox(); box != nil {
+		t.Fatalf("expected the finished cycle to be removed, got %+v", box)
+	}
+}
+
+func TestExpireTimebox_CycleWithoutLongBreakEndsAfterLastPomodoro(t *testing.T) {
+	storage, tm := newHistoryTaskManager()
+	store := NewMemoryTimeboxStore()
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+	cycle := PomodoroCycle{Project: "Acme", Title: "Build", Work: 25 * time.Minute, Break: 5 * time.Minute, Rounds: 1}
+
+	if _, _, err := tm.StartPomodoro(store, cycle, start); err != nil {
+		t.Fatalf("StartPomodoro failed: %v", err)
+	}
+	changes, err := tm.ExpireTimebox(store, start.Add(time.Hour))
+	if err != nil || len(changes) != 1 || changes[0].Kind != PomodoroDone {
+		t.Fatalf("expected the cycle to end with the pomodoro, got %+v (%v)", changes, err)
+	}
+	if entries := mustLoad(t, storage); len(entries) != 2 || !entries[1].IsBlank() {
+		t.Fatalf("expected the pomodoro to be stopped, got %+v", entries)
+	}
+}
+
+func TestStartPomodoro_RejectsInvalidCycle(t *testing.T) {
+	_, tm := newHistoryTaskManager()
+	store := NewMemoryTimeboxStore()
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+
+	invalid := []PomodoroCycle{
+		{Project: "Acme", Title: "Build", Break: time.Minute, Rounds: 1},
+		{Project: "Acme", Title: "Build", Work: time.Minute, Rounds: 1},
+		{Project: "Acme", Title: "Build", Work: time.Minute, Break: time.Minute},
+	}
+	for _, cycle := range invalid {
+		if _, _, err := tm.StartPomodoro(store, cycle, start); err == nil {
+			t.Errorf("expected %+v to be rejected", cycle)
+		}
+	}
+	if _, _, err := tm.StartTimebox(store, "Acme", "Build", start, 0); err == nil {
+		t.Error("expected an empty timebox to be rejected")
+	}
+}
+
+func TestFileTimeboxStore_RoundTripsAndRemoves(t *testing.T) {
+	store := NewFileTimeboxStore(filepath.Join(t.TempDir(), "timebox.json"))
+	start := time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC)
+
+	if box, err := store.LoadTimebox(); err != nil || box != nil {
+		t.Fatalf("expected no timebox in a missing file, got %+v (%v)", box, err)
+	}
+
+	saved := &Timebox{Start: start, Until: start.Add(25 * time.Minute), Pomodoro: &PomodoroCycle{Project: "Acme", Title: "Build", Work: 25 * time.Minute, Rounds: 4, Round: 2}}
+	if err := store.SaveTimebox(saved); err != nil {
+		t.Fatalf("SaveTimebox failed: %v", err)
+	}
+	box, err := store.LoadTimebox()
+	if err != nil || box == nil || !box.Until.Equal(saved.Until) || box.Label() != "pomodoro 2/4" {
+		t.Fatalf("expected the saved box back, got %+v (%v)", box, err)
+	}
+
+	if err := store.SaveTimebox(nil); err != nil {
+		t.Fatalf("SaveTimebox(nil) failed: %v", err)
+	}
+	if box, err := store.LoadTimebox(); err != nil || box != nil {
+		t.Fatalf("expected the timebox to be removed, got %+v (%v)", box, err)
+	}
+}
+
+func mustLoad(t *testing.T, storage *MemoryStorage) []models.TimeEntry {
+	t.Helper()
+
+	entries, err := storage.Load()
+	if err != nil {
+		t.Fatalf("failed to load entries: %v", err)
+	}
+	return entries
+}